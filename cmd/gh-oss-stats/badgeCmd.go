@@ -1,7 +1,6 @@
 package main
 
 import (
-	"encoding/json"
 	"flag"
 	"fmt"
 	"os"
@@ -17,6 +16,7 @@ var badgeCmd = flag.NewFlagSet("badge", flag.ExitOnError)
 var (
 	badgeFromFile = badgeCmd.String("from-file", "", "Path to stats JSON file")
 	badgeData     = badgeCmd.String("data", "", "Stats as JSON string")
+	badgeHistory  = badgeCmd.String("history-file", "", "History file used by --badge-delta (default: <user data dir>/gh-oss-stats/history.jsonl)")
 )
 
 func init() {
@@ -32,6 +32,8 @@ func init() {
 		fmt.Fprintf(os.Stderr, "  gh-oss-stats badge --from-file stats.json --badge-style summary\n\n")
 		fmt.Fprintf(os.Stderr, "  # Generate badge from JSON string\n")
 		fmt.Fprintf(os.Stderr, "  gh-oss-stats badge --data '{\"username\":\"...\",...}' --badge-style compact\n\n")
+		fmt.Fprintf(os.Stderr, "  # Generate a PNG badge, at 2x by default\n")
+		fmt.Fprintf(os.Stderr, "  gh-oss-stats badge --from-file stats.json --badge-output badge.png\n\n")
 	}
 }
 
@@ -75,8 +77,7 @@ func runBadgeCmd(args []string) {
 }
 
 func generateBadgeFromJSONString(statsJSON string, badgeConfig BadgeConfig) error {
-	var stats ossstats.Stats
-	err := json.Unmarshal([]byte(statsJSON), &stats)
+	stats, err := ossstats.ParseStats([]byte(statsJSON))
 	if err != nil {
 		return err
 	}
@@ -86,5 +87,9 @@ func generateBadgeFromJSONString(statsJSON string, badgeConfig BadgeConfig) erro
 		return err
 	}
 
-	return writeBadge(badgeOption, badgeConfig.output, verbose, &stats)
+	if err := applyBadgeDelta(&badgeOption, badgeConfig, *badgeHistory, stats); err != nil {
+		return err
+	}
+
+	return writeBadge(badgeOption, badgeConfig.output, verbose, stats)
 }
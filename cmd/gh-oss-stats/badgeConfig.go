@@ -13,6 +13,9 @@ type BadgeConfig struct {
 	output  string
 	sort    string
 	limit   int
+	metric  string
+	scale   float64
+	delta   bool
 	// Custom color overrides (empty = use theme default)
 	colorBackground    string
 	colorBackgroundAlt string
@@ -34,6 +37,8 @@ func newBadgeConfig() *BadgeConfig {
 		output:  "",
 		sort:    string(badge.DefaultSortBy),
 		limit:   badge.DefaultPRsLimit,
+		metric:  string(badge.DefaultShieldsMetric),
+		scale:   badge.DefaultPNGScale,
 	}
 }
 
@@ -41,9 +46,12 @@ func (bf *BadgeConfig) registerBadgeFlags(fs *flag.FlagSet) {
 	fs.StringVar(&bf.style, "badge-style", string(badge.DefaultBadgeStyle), "Badge style: summary, compact, detailed")
 	fs.StringVar(&bf.variant, "badge-variant", string(badge.DefaultBadgeVariant), "Badge variants: default, text-based")
 	fs.StringVar(&bf.theme, "badge-theme", string(badge.DefaultBadgeTheme), "Badge theme: dark, light, nord, dracula, ...")
-	fs.StringVar(&bf.output, "badge-output", "", "Badge output file (default: badge.svg)")
+	fs.StringVar(&bf.output, "badge-output", "", "Badge output file, PNG if it ends in .png (default: badge.svg)")
 	fs.StringVar(&bf.sort, "badge-sort", string(badge.DefaultSortBy), "Sort contributions by: prs, stars, commits")
 	fs.IntVar(&bf.limit, "badge-limit", badge.DefaultPRsLimit, "Number of contributions to show")
+	fs.StringVar(&bf.metric, "badge-metric", string(badge.DefaultShieldsMetric), "Metric of the shields format: projects, prs, lines")
+	fs.Float64Var(&bf.scale, "badge-scale", badge.DefaultPNGScale, "Pixels per SVG pixel of PNG badges, e.g. 1, or 3 for sharper slides")
+	fs.BoolVar(&bf.delta, "badge-delta", false, "Show PRs merged since the previous history snapshot, e.g. \"+4 PRs in the last 30 days\"")
 
 	fs.StringVar(&bf.colorBackground, "badge-color-background", "", "Custom background color (hex, e.g. #1a1b26)")
 	fs.StringVar(&bf.colorBackgroundAlt, "badge-color-background-alt", "", "Custom alt background color (hex)")
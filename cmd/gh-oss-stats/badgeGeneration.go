@@ -3,10 +3,13 @@ package main
 import (
 	"fmt"
 	"os"
+	"path/filepath"
 	"regexp"
+	"strings"
 
 	"github.com/mabd-dev/gh-oss-stats/pkg/ossstats"
 	"github.com/mabd-dev/gh-oss-stats/pkg/ossstats/badge"
+	"github.com/mabd-dev/gh-oss-stats/pkg/ossstats/output"
 )
 
 var hexColorRE = regexp.MustCompile(`^#([0-9a-fA-F]{3}|[0-9a-fA-F]{6}|[0-9a-fA-F]{8})$`)
@@ -43,6 +46,19 @@ func createBadgeOptions(conf BadgeConfig) (badge.BadgeOptions, error) {
 		os.Exit(1)
 	}
 
+	badgeMetric := badge.DefaultShieldsMetric
+	if conf.metric != "" {
+		badgeMetric, err = badge.ShieldsMetricFromName(conf.metric)
+		if err != nil {
+			fmt.Fprint(os.Stderr, err.Error())
+			os.Exit(1)
+		}
+	}
+
+	if conf.scale <= 0 || conf.scale > 8 {
+		return badge.BadgeOptions{}, fmt.Errorf("--badge-scale must be > 0 and <= 8 (got: %g)", conf.scale)
+	}
+
 	colorFlags := []struct {
 		flag  string
 		value string
@@ -89,31 +105,28 @@ func createBadgeOptions(conf BadgeConfig) (badge.BadgeOptions, error) {
 		Theme:        badgeTheme,
 		SortBy:       badgeSortBy,
 		Limit:        conf.limit,
+		Metric:       badgeMetric,
+		Scale:        conf.scale,
 		CustomColors: customColors,
 	}, nil
 }
 
 func writeBadge(
 	opts badge.BadgeOptions,
-	output string,
+	path string,
 	verbose *bool,
 	stats *ossstats.Stats,
 ) error {
-	// Generate SVG
-	svg, err := badge.RenderSVG(stats, opts)
-	if err != nil {
-		return fmt.Errorf("failed to render badge: %w", err)
-	}
-
 	// Determine output file
-	outputFile := output
+	outputFile := path
 	if outputFile == "" {
 		outputFile = "badge.svg"
 	}
 
-	// Write badge to file
-	if err := os.WriteFile(outputFile, []byte(svg), 0644); err != nil {
-		return fmt.Errorf("failed to write badge: %w", err)
+	// Render and write the badge
+	target := outputTarget{format: badgeFormat(outputFile), path: outputFile}
+	if err := writeOutput(target, output.Options{Badge: opts}, stats); err != nil {
+		return err
 	}
 
 	if *verbose {
@@ -122,3 +135,12 @@ func writeBadge(
 
 	return nil
 }
+
+// badgeFormat returns the output format of a badge file: png for .png files,
+// svg otherwise.
+func badgeFormat(path string) string {
+	if strings.EqualFold(filepath.Ext(path), ".png") {
+		return "png"
+	}
+	return "svg"
+}
@@ -13,6 +13,7 @@ func TestCreateBadgeOptionsNoCustomColors(t *testing.T) {
 		theme:   string(badge.DefaultBadgeTheme),
 		sort:    string(badge.DefaultSortBy),
 		limit:   badge.DefaultPRsLimit,
+		scale:   badge.DefaultPNGScale,
 		// all color fields left as zero value ("")
 	}
 
@@ -33,6 +34,7 @@ func TestCreateBadgeOptionsAllCustomColors(t *testing.T) {
 		theme:              string(badge.DefaultBadgeTheme),
 		sort:               string(badge.DefaultSortBy),
 		limit:              badge.DefaultPRsLimit,
+		scale:              badge.DefaultPNGScale,
 		colorBackground:    "#0d1117",
 		colorBackgroundAlt: "#161b22",
 		colorText:          "#c9d1d9",
@@ -85,6 +87,7 @@ func TestCreateBadgeOptionsPartialCustomColors(t *testing.T) {
 		theme:       string(badge.DefaultBadgeTheme),
 		sort:        string(badge.DefaultSortBy),
 		limit:       badge.DefaultPRsLimit,
+		scale:       badge.DefaultPNGScale,
 		colorAccent: "#ff6b6b",
 		colorStar:   "#ffd700",
 	}
@@ -224,6 +227,7 @@ func baseConf() BadgeConfig {
 		theme:   string(badge.DefaultBadgeTheme),
 		sort:    string(badge.DefaultSortBy),
 		limit:   badge.DefaultPRsLimit,
+		scale:   badge.DefaultPNGScale,
 	}
 }
 
@@ -332,3 +336,34 @@ func contains(s, sub string) bool {
 			return false
 		}())
 }
+
+func TestCreateBadgeOptionsScale(t *testing.T) {
+	conf := baseConf()
+	conf.scale = 3
+	opts, err := createBadgeOptions(conf)
+	if err != nil || opts.Scale != 3 {
+		t.Errorf("createBadgeOptions() scale = %v, %v, want 3", opts.Scale, err)
+	}
+
+	for _, scale := range []float64{-1, 0, 9} {
+		conf.scale = scale
+		if _, err := createBadgeOptions(conf); err == nil || !contains(err.Error(), "--badge-scale") {
+			t.Errorf("createBadgeOptions() with scale %v error = %v, want a --badge-scale error", scale, err)
+		}
+	}
+}
+
+func TestBadgeFormat(t *testing.T) {
+	tests := map[string]string{
+		"badge.svg":        "svg",
+		"badge.png":        "png",
+		"assets/Badge.PNG": "png",
+		"badge":            "svg",
+		"png/badge.svg":    "svg",
+	}
+	for path, want := range tests {
+		if got := badgeFormat(path); got != want {
+			t.Errorf("badgeFormat(%q) = %s, want %s", path, got, want)
+		}
+	}
+}
@@ -0,0 +1,138 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"log"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/mabd-dev/gh-oss-stats/pkg/ossstats"
+)
+
+// contributorsCmd flag set
+var contributorsCmd = flag.NewFlagSet("contributors", flag.ExitOnError)
+
+// Contributors command flags
+var (
+	contributorsToken      = contributorsCmd.String("token", "", "GitHub token (default: $GH_TOKEN, $GITHUB_TOKEN or gh CLI login)")
+	contributorsHostname   = contributorsCmd.String("hostname", "", "GitHub host, for GitHub Enterprise Server (default: $GH_HOST or github.com)")
+	contributorsIncludeLOC = contributorsCmd.Bool("include-loc", false, "Include commit and line counts (slower)")
+	contributorsMaxPRs     = contributorsCmd.Int("max-prs", ossstats.DefaultMaxPRS, "Max PRs to fetch")
+	contributorsTimeout    = contributorsCmd.Int("timeout", int(ossstats.DefaultTimeout.Seconds()), "Timeout in seconds")
+	contributorsCheckpoint = contributorsCmd.String("checkpoint", "", "Checkpoint file to save progress to and resume from")
+	contributorsFormat     = contributorsCmd.String("format", "json", "Output format: json or markdown")
+	contributorsOutput     = contributorsCmd.String("output", "", "Output file (default: stdout)")
+	contributorsVerbose    = contributorsCmd.Bool("verbose", false, "Verbose logging to stderr")
+	contributorsDebug      = contributorsCmd.String("debug-scenario", "", "Uses fake data from a JSON scenario file")
+)
+
+func init() {
+	contributorsCmd.Usage = func() {
+		fmt.Fprintf(os.Stderr, "Usage: gh-oss-stats contributors [options] <owner/repo>\n\n")
+		fmt.Fprintf(os.Stderr, "List the external contributors of a repository: authors of merged PRs\n")
+		fmt.Fprintf(os.Stderr, "outside the owning organization, with PR counts and first and last merge dates.\n\n")
+		fmt.Fprintf(os.Stderr, "Options:\n")
+		contributorsCmd.PrintDefaults()
+		fmt.Fprintf(os.Stderr, "\nExamples:\n")
+		fmt.Fprintf(os.Stderr, "  # JSON list of external contributors\n")
+		fmt.Fprintf(os.Stderr, "  gh-oss-stats contributors mabd-dev/gh-oss-stats\n\n")
+		fmt.Fprintf(os.Stderr, "  # Markdown table with line counts\n")
+		fmt.Fprintf(os.Stderr, "  gh-oss-stats contributors --include-loc --format markdown --output THANKS.md golang/go\n\n")
+	}
+}
+
+func runContributorsCmd(args []string) {
+	positional := parseInterspersed(contributorsCmd, args)
+	if len(positional) != 1 {
+		fmt.Fprintf(os.Stderr, "Error: contributors requires exactly one repository (got %d)\n\n", len(positional))
+		contributorsCmd.Usage()
+		os.Exit(1)
+	}
+
+	format := strings.TrimSpace(*contributorsFormat)
+	if format != "json" && format != "markdown" && format != "md" {
+		fmt.Fprintf(os.Stderr, "Error: invalid --format %q (valid: json, markdown)\n\n", format)
+		os.Exit(1)
+	}
+	if *contributorsMaxPRs <= 0 {
+		fmt.Fprintf(os.Stderr, "Error: --max-prs must be > 0 (got: %d)\n\n", *contributorsMaxPRs)
+		os.Exit(1)
+	}
+	if *contributorsTimeout <= 0 {
+		fmt.Fprintf(os.Stderr, "Error: --timeout must be > 0 seconds (got: %d)\n\n", *contributorsTimeout)
+		os.Exit(1)
+	}
+
+	opts := []ossstats.Option{
+		ossstats.WithLOC(*contributorsIncludeLOC),
+		ossstats.WithMaxPRs(*contributorsMaxPRs),
+		ossstats.WithTimeout(time.Duration(*contributorsTimeout) * time.Second),
+	}
+
+	scenario := strings.TrimSpace(*contributorsDebug)
+	if scenario != "" {
+		opts = append(opts, ossstats.WithDebugScenario(scenario))
+	} else {
+		opts = append(opts, createGitHubOptions(*contributorsToken, *contributorsHostname)...)
+	}
+
+	if strings.TrimSpace(*contributorsCheckpoint) != "" {
+		opts = append(opts, ossstats.WithCheckpoint(strings.TrimSpace(*contributorsCheckpoint)))
+	}
+	if *contributorsVerbose {
+		opts = append(opts, ossstats.WithLogger(log.New(os.Stderr, "[gh-oss-stats] ", log.LstdFlags)))
+	}
+
+	result, err := ossstats.New(opts...).GetRepoContributors(context.Background(), positional[0])
+	if err != nil {
+		if partialErr, ok := err.(*ossstats.ErrPartialResults); ok {
+			fmt.Fprintf(os.Stderr, "Warning: %v\n", partialErr)
+			printPartialErrorHints(partialErr)
+		} else {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			printErrorHint(err)
+			os.Exit(1)
+		}
+	}
+	if result.Truncated {
+		fmt.Fprintf(os.Stderr, "Warning: only the %d most recently updated of %d merged PRs were searched; raise --max-prs (GitHub returns at most 1000) for complete counts and first contribution dates\n",
+			result.SearchedPRs, result.TotalPRs)
+	}
+
+	content, err := renderRepoContributors(result, format)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		os.Exit(1)
+	}
+
+	if output := strings.TrimSpace(*contributorsOutput); output != "" {
+		if err := os.WriteFile(output, content, 0644); err != nil {
+			fmt.Fprintf(os.Stderr, "Error writing to file: %v\n", err)
+			os.Exit(1)
+		}
+		os.Exit(0)
+	}
+
+	fmt.Print(string(content))
+	os.Exit(0)
+}
+
+// renderRepoContributors renders a repository's external contributors in the
+// given output format.
+func renderRepoContributors(result *ossstats.RepoContributors, format string) ([]byte, error) {
+	switch format {
+	case "markdown", "md":
+		return []byte(result.Markdown()), nil
+	case "json":
+		data, err := json.MarshalIndent(result, "", "  ")
+		if err != nil {
+			return nil, fmt.Errorf("encoding JSON: %w", err)
+		}
+		return append(data, '\n'), nil
+	}
+	return nil, fmt.Errorf("invalid --format %q (valid: json, markdown)", format)
+}
@@ -27,6 +27,8 @@ func init() {
 		fmt.Fprintf(os.Stderr, "  gh-oss-stats demo --badge-style summary --badge-theme dark\n\n")
 		fmt.Fprintf(os.Stderr, "  # Generate compact demo badge\n")
 		fmt.Fprintf(os.Stderr, "  gh-oss-stats demo --badge-style compact --badge-output demo.svg\n\n")
+		fmt.Fprintf(os.Stderr, "  # Generate a PNG demo badge at 3x\n")
+		fmt.Fprintf(os.Stderr, "  gh-oss-stats demo --badge-scale 3 --badge-output demo.png\n\n")
 	}
 }
 
@@ -36,8 +38,9 @@ func runDemoCmd(args []string) {
 	demoCmd.Parse(args)
 
 	var stats = ossstats.Stats{
-		Username:    "mabd-dev",
-		GeneratedAt: time.Date(2025, 12, 31, 5, 33, 15, 31869_000, time.UTC),
+		SchemaVersion: ossstats.StatsSchemaVersion,
+		Username:      "mabd-dev",
+		GeneratedAt:   time.Date(2025, 12, 31, 5, 33, 15, 31869_000, time.UTC),
 		Summary: ossstats.Summary{
 			TotalProjects:  7,
 			TotalPRsMerged: 17,
@@ -48,6 +51,8 @@ func runDemoCmd(args []string) {
 		Contributions: []ossstats.Contribution{
 			{
 				Repo:              "ibad-al-rahman/android-public",
+				Forge:             ossstats.ForgeGitHub,
+				Host:              "github.com",
 				Owner:             "ibad-al-rahman",
 				RepoName:          "android-public",
 				Description:       "Android app for Ibad Al-Rahman",
@@ -62,6 +67,8 @@ func runDemoCmd(args []string) {
 			},
 			{
 				Repo:              "nsh07/Tomato",
+				Forge:             ossstats.ForgeGitHub,
+				Host:              "github.com",
 				Owner:             "nsh07",
 				RepoName:          "Tomato",
 				Description:       "Android app for Ibad Al-Rahman",
@@ -76,6 +83,8 @@ func runDemoCmd(args []string) {
 			},
 			{
 				Repo:              "qamarelsafadi/JetpackComposeTracker",
+				Forge:             ossstats.ForgeGitHub,
+				Host:              "github.com",
 				Owner:             "qamarelsafadi",
 				RepoName:          "JetpackComposeTracker",
 				Description:       "Android app for Ibad Al-Rahman",
@@ -90,6 +99,8 @@ func runDemoCmd(args []string) {
 			},
 			{
 				Repo:              "android/nav3-recipes",
+				Forge:             ossstats.ForgeGitHub,
+				Host:              "github.com",
 				Owner:             "android",
 				RepoName:          "nav3-recipes",
 				Description:       "Android app for Ibad Al-Rahman",
@@ -104,6 +115,8 @@ func runDemoCmd(args []string) {
 			},
 			{
 				Repo:              "android/cahier",
+				Forge:             ossstats.ForgeGitHub,
+				Host:              "github.com",
 				Owner:             "android",
 				RepoName:          "cahier",
 				Description:       "Android app for Ibad Al-Rahman",
@@ -118,6 +131,8 @@ func runDemoCmd(args []string) {
 			},
 			{
 				Repo:              "esatgozcu/Compose-Rolling-Number",
+				Forge:             ossstats.ForgeGitHub,
+				Host:              "github.com",
 				Owner:             "esatgozcu",
 				RepoName:          "Compose-Rolling-Number",
 				Description:       "Android app for Ibad Al-Rahman",
@@ -132,6 +147,8 @@ func runDemoCmd(args []string) {
 			},
 			{
 				Repo:              "zuzmuz/nvimawscli",
+				Forge:             ossstats.ForgeGitHub,
+				Host:              "github.com",
 				Owner:             "zuzmuz",
 				RepoName:          "nvimawscli",
 				Description:       "Android app for Ibad Al-Rahman",
@@ -0,0 +1,119 @@
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/mabd-dev/gh-oss-stats/pkg/ossstats"
+)
+
+// diffCmd flag set
+var diffCmd = flag.NewFlagSet("diff", flag.ExitOnError)
+
+// Diff command flags
+var (
+	diffFormat = diffCmd.String("format", "text", "Output format: text, markdown or json")
+	diffOutput = diffCmd.String("output", "", "Output file (default: stdout)")
+)
+
+func init() {
+	diffCmd.Usage = func() {
+		fmt.Fprintf(os.Stderr, "Usage: gh-oss-stats diff [options] old.json new.json\n\n")
+		fmt.Fprintf(os.Stderr, "Compare two stats JSON files and report what changed:\n")
+		fmt.Fprintf(os.Stderr, "new repositories, repositories with new merged PRs, star changes and summary deltas.\n\n")
+		fmt.Fprintf(os.Stderr, "Options:\n")
+		diffCmd.PrintDefaults()
+		fmt.Fprintf(os.Stderr, "\nExamples:\n")
+		fmt.Fprintf(os.Stderr, "  # Show changes since last week\n")
+		fmt.Fprintf(os.Stderr, "  gh-oss-stats diff last-week.json stats.json\n\n")
+		fmt.Fprintf(os.Stderr, "  # Write a Markdown section for a newsletter\n")
+		fmt.Fprintf(os.Stderr, "  gh-oss-stats diff --format markdown --output changes.md last-week.json stats.json\n\n")
+	}
+}
+
+func runDiffCmd(args []string) {
+	files := parseInterspersed(diffCmd, args)
+	if len(files) != 2 {
+		fmt.Fprintf(os.Stderr, "Error: diff requires exactly two stats files (got %d)\n\n", len(files))
+		diffCmd.Usage()
+		os.Exit(1)
+	}
+
+	older, err := readStatsFile(files[0])
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		os.Exit(1)
+	}
+	newer, err := readStatsFile(files[1])
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		os.Exit(1)
+	}
+
+	content, err := renderDiff(older.Diff(newer), strings.TrimSpace(*diffFormat))
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		os.Exit(1)
+	}
+
+	if output := strings.TrimSpace(*diffOutput); output != "" {
+		if err := os.WriteFile(output, content, 0644); err != nil {
+			fmt.Fprintf(os.Stderr, "Error writing to file: %v\n", err)
+			os.Exit(1)
+		}
+		os.Exit(0)
+	}
+
+	fmt.Print(string(content))
+	os.Exit(0)
+}
+
+// renderDiff renders a diff in the given output format.
+func renderDiff(diff *ossstats.StatsDiff, format string) ([]byte, error) {
+	switch format {
+	case "text":
+		return []byte(diff.Text()), nil
+	case "markdown", "md":
+		return []byte(diff.Markdown()), nil
+	case "json":
+		data, err := json.MarshalIndent(diff, "", "  ")
+		if err != nil {
+			return nil, fmt.Errorf("encoding JSON: %w", err)
+		}
+		return append(data, '\n'), nil
+	}
+	return nil, fmt.Errorf("invalid --format %q (valid: text, markdown, json)", format)
+}
+
+// readStatsFile reads a stats JSON file written by the main command,
+// migrating files written by older versions.
+func readStatsFile(path string) (*ossstats.Stats, error) {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	stats, err := ossstats.ParseStats(content)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse %s: %w", path, err)
+	}
+	return stats, nil
+}
+
+// parseInterspersed parses flags that may appear before, between or after
+// positional arguments, and returns the positional arguments.
+func parseInterspersed(fs *flag.FlagSet, args []string) []string {
+	var positional []string
+	for {
+		fs.Parse(args)
+		args = fs.Args()
+		if len(args) == 0 {
+			return positional
+		}
+		positional = append(positional, args[0])
+		args = args[1:]
+	}
+}
@@ -0,0 +1,202 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"log"
+	"os"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/mabd-dev/gh-oss-stats/pkg/ossstats"
+	"github.com/mabd-dev/gh-oss-stats/pkg/ossstats/badge"
+)
+
+// historyCmd flag set
+var historyCmd = flag.NewFlagSet("history", flag.ExitOnError)
+
+// History command flags
+var (
+	historyUser   = historyCmd.String("user", "", "GitHub username (default: all users in the history)")
+	historyPath   = historyCmd.String("history-file", "", "History file (default: <user data dir>/gh-oss-stats/history.jsonl)")
+	historyFormat = historyCmd.String("format", "text", "Output format: text or json")
+	historyOutput = historyCmd.String("output", "", "Output file (default: stdout)")
+)
+
+func init() {
+	historyCmd.Usage = func() {
+		fmt.Fprintf(os.Stderr, "Usage: gh-oss-stats history [options]\n\n")
+		fmt.Fprintf(os.Stderr, "Show growth over time from snapshots recorded with --history:\n")
+		fmt.Fprintf(os.Stderr, "projects and PRs per snapshot, plus stars gained by contributed repositories.\n\n")
+		fmt.Fprintf(os.Stderr, "Options:\n")
+		historyCmd.PrintDefaults()
+		fmt.Fprintf(os.Stderr, "\nExamples:\n")
+		fmt.Fprintf(os.Stderr, "  # Record a snapshot on every run\n")
+		fmt.Fprintf(os.Stderr, "  gh-oss-stats --user octocat --history -o stats.json\n\n")
+		fmt.Fprintf(os.Stderr, "  # Show the trend\n")
+		fmt.Fprintf(os.Stderr, "  gh-oss-stats history --user octocat\n\n")
+	}
+}
+
+func runHistoryCmd(args []string) {
+	historyCmd.Parse(args)
+
+	history, err := openHistory(*historyPath)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		os.Exit(1)
+	}
+
+	snapshots, err := history.Snapshots(strings.TrimSpace(*historyUser))
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		os.Exit(1)
+	}
+	if len(snapshots) == 0 {
+		fmt.Fprintf(os.Stderr, "No snapshots found in %s\n", history.Path())
+		fmt.Fprintf(os.Stderr, "Hint: Run gh-oss-stats with --history to record one\n")
+		os.Exit(1)
+	}
+
+	content, err := renderHistory(snapshots, strings.TrimSpace(*historyFormat))
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		os.Exit(1)
+	}
+
+	if output := strings.TrimSpace(*historyOutput); output != "" {
+		if err := os.WriteFile(output, content, 0644); err != nil {
+			fmt.Fprintf(os.Stderr, "Error writing to file: %v\n", err)
+			os.Exit(1)
+		}
+		os.Exit(0)
+	}
+
+	fmt.Print(string(content))
+	os.Exit(0)
+}
+
+// renderHistory renders the trend of each user's snapshots in the given
+// output format. Snapshots must be sorted oldest first.
+func renderHistory(snapshots []ossstats.Stats, format string) ([]byte, error) {
+	var users []string
+	byUser := make(map[string][]ossstats.Stats)
+	for _, snapshot := range snapshots {
+		user := strings.ToLower(snapshot.Username)
+		if _, ok := byUser[user]; !ok {
+			users = append(users, user)
+		}
+		byUser[user] = append(byUser[user], snapshot)
+	}
+
+	switch format {
+	case "text":
+		var buf bytes.Buffer
+		for i, user := range users {
+			if i > 0 {
+				buf.WriteString("\n")
+			}
+			writeTrendText(&buf, byUser[user][0].Username, ossstats.Trend(byUser[user]))
+		}
+		return buf.Bytes(), nil
+	case "json":
+		type userTrend struct {
+			Username string                `json:"username"`
+			Trend    []ossstats.TrendPoint `json:"trend"`
+		}
+		trends := make([]userTrend, len(users))
+		for i, user := range users {
+			trends[i] = userTrend{Username: byUser[user][0].Username, Trend: ossstats.Trend(byUser[user])}
+		}
+		data, err := json.MarshalIndent(trends, "", "  ")
+		if err != nil {
+			return nil, fmt.Errorf("encoding JSON: %w", err)
+		}
+		return append(data, '\n'), nil
+	}
+	return nil, fmt.Errorf("invalid --format %q (valid: text, json)", format)
+}
+
+// writeTrendText writes a user's trend as an aligned table.
+func writeTrendText(buf *bytes.Buffer, username string, points []ossstats.TrendPoint) {
+	fmt.Fprintf(buf, "History for %s (%d snapshots)\n\n", username, len(points))
+
+	w := tabwriter.NewWriter(buf, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "DATE\tPROJECTS\tPRS MERGED\tSTARS\tSTARS GAINED")
+	for i, point := range points {
+		projects := fmt.Sprintf("%d", point.Projects)
+		prs := fmt.Sprintf("%d", point.PRsMerged)
+		gained := "-"
+		if i > 0 {
+			projects += fmt.Sprintf(" (%s)", signedInt(point.ProjectsDelta))
+			prs += fmt.Sprintf(" (%s)", signedInt(point.NewPRs))
+			gained = signedInt(point.StarsGained)
+		}
+		fmt.Fprintf(w, "%s\t%s\t%s\t%d\t%s\n",
+			point.GeneratedAt.UTC().Format("2006-01-02 15:04"), projects, prs, point.Stars, gained)
+	}
+	w.Flush()
+
+	if len(points) > 1 {
+		first, last := points[0], points[len(points)-1]
+		starsGained := 0
+		for _, point := range points[1:] {
+			starsGained += point.StarsGained
+		}
+		fmt.Fprintf(buf, "\nSince %s: %s projects, %s PRs merged, %s stars gained\n",
+			first.GeneratedAt.UTC().Format("2006-01-02"),
+			signedInt(last.Projects-first.Projects),
+			signedInt(last.PRsMerged-first.PRsMerged),
+			signedInt(starsGained))
+	}
+}
+
+// signedInt formats n with an explicit sign, e.g. "+3", "-2" or "0".
+func signedInt(n int) string {
+	if n > 0 {
+		return fmt.Sprintf("+%d", n)
+	}
+	return fmt.Sprintf("%d", n)
+}
+
+// openHistory opens the history store at path, or at the default location
+// under the user data directory when path is empty. Skipped history lines
+// are reported on stderr.
+func openHistory(path string) (*ossstats.History, error) {
+	path = strings.TrimSpace(path)
+	if path == "" {
+		defaultPath, err := ossstats.DefaultHistoryPath()
+		if err != nil {
+			return nil, fmt.Errorf("locating history file: %w", err)
+		}
+		path = defaultPath
+	}
+	history := ossstats.NewHistory(path)
+	history.SetLogger(log.New(os.Stderr, "Warning: ", 0))
+	return history, nil
+}
+
+// applyBadgeDelta sets the badge's previous snapshot from the history when
+// --badge-delta is set. Without an earlier snapshot the badge has no delta.
+func applyBadgeDelta(opts *badge.BadgeOptions, conf BadgeConfig, historyFile string, stats *ossstats.Stats) error {
+	if !conf.delta {
+		return nil
+	}
+
+	history, err := openHistory(historyFile)
+	if err != nil {
+		return err
+	}
+	previous, err := history.Previous(stats)
+	if err != nil {
+		return err
+	}
+	if previous == nil && *verbose {
+		fmt.Fprintf(os.Stderr, "No earlier snapshot of %s in %s, badge has no delta\n", stats.Username, history.Path())
+	}
+
+	opts.Previous = previous
+	return nil
+}
@@ -23,9 +23,30 @@ func main() {
 	case "badge":
 		telemetry.Send(version, "badge")
 		runBadgeCmd(args[1:])
+	case "contributors":
+		telemetry.Send(version, "contributors")
+		runContributorsCmd(args[1:])
 	case "demo":
 		telemetry.Send(version, "demo")
 		runDemoCmd(args[1:])
+	case "diff":
+		telemetry.Send(version, "diff")
+		runDiffCmd(args[1:])
+	case "history":
+		telemetry.Send(version, "history")
+		runHistoryCmd(args[1:])
+	case "org":
+		telemetry.Send(version, "org")
+		runOrgCmd(args[1:])
+	case "report":
+		telemetry.Send(version, "report")
+		runReportCmd(args[1:])
+	case "schema":
+		telemetry.Send(version, "schema")
+		runSchemaCmd(args[1:])
+	case "update-readme":
+		telemetry.Send(version, "update-readme")
+		runUpdateReadmeCmd(args[1:])
 	case "version":
 		fmt.Printf("gh-oss-stats v%s\n", version)
 		os.Exit(0)
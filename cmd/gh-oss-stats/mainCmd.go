@@ -1,43 +1,77 @@
 package main
 
 import (
+	"bytes"
 	"context"
-	"encoding/json"
+	"errors"
 	"flag"
 	"fmt"
 	"log"
+	"net/url"
 	"os"
+	"strconv"
 	"strings"
 	"time"
 
+	"github.com/mabd-dev/gh-oss-stats/internal/ghcli"
 	"github.com/mabd-dev/gh-oss-stats/pkg/ossstats"
+	"github.com/mabd-dev/gh-oss-stats/pkg/ossstats/output"
 )
 
 var (
-	username     = flag.String("user", "", "GitHub username (required)")
+	username     = flag.String("user", "", "GitHub username, or comma-separated accounts of one person to merge (default: gh CLI login)")
 	userShort    = flag.String("u", "", "GitHub username (short)")
-	token        = flag.String("token", os.Getenv("GITHUB_TOKEN"), "GitHub token (default: $GITHUB_TOKEN)")
+	token        = flag.String("token", "", "GitHub token (default: $GH_TOKEN, $GITHUB_TOKEN or gh CLI login)")
+	hostname     = flag.String("hostname", "", "GitHub host, for GitHub Enterprise Server (default: $GH_HOST or github.com)")
 	tokenShort   = flag.String("t", "", "GitHub token (short)")
+	tokens       = flag.String("tokens", os.Getenv("GITHUB_TOKENS"), "Comma-separated GitHub tokens to rotate between (default: $GITHUB_TOKENS)")
+	appID        = flag.String("app-id", os.Getenv("GITHUB_APP_ID"), "GitHub App ID (default: $GITHUB_APP_ID)")
+	appInstallID = flag.String("app-installation-id", os.Getenv("GITHUB_APP_INSTALLATION_ID"), "GitHub App installation ID (default: $GITHUB_APP_INSTALLATION_ID)")
+	appKeyFile   = flag.String("app-private-key", os.Getenv("GITHUB_APP_PRIVATE_KEY_PATH"), "Path to GitHub App private key PEM (default: $GITHUB_APP_PRIVATE_KEY_PATH)")
 	includeLOC   = flag.Bool("include-loc", ossstats.DefaultIncludeLOC, "Include LOC metrics")
-	includePRs   = flag.Bool("include-prs", ossstats.DefaultIncludePRDetails, "Include PR details")
+	includePRs   = flag.Bool("include-prs", ossstats.DefaultIncludePRDetails, "Include the merged PRs of each contribution")
 	minStars     = flag.Int("min-stars", ossstats.DefaultMinStars, "Minimum repo stars")
+	unknownStars = flag.String("unknown-stars", ossstats.UnknownStarsExclude.String(), "How --min-stars treats repos whose stars could not be fetched: exclude or include")
 	maxPRs       = flag.Int("max-prs", ossstats.DefaultMaxPRS, "Max PRs to fetch")
 	excludeOrgs  = flag.String("exclude-orgs", "", "Comma-separated list of organizations to exclude")
-	output       = flag.String("output", "", "Output file (default: stdout)")
+	outputFile   = flag.String("output", "", "Output file (default: stdout)")
 	outputShort  = flag.String("o", "", "Output file (short)")
+	format       = flag.String("format", "json", "Output format: "+strings.Join(output.Formats(), ", "))
 	verbose      = flag.Bool("verbose", false, "Verbose logging to stderr")
 	verboseShort = flag.Bool("v", false, "Verbose logging (short)")
 	timeoutSec   = flag.Int("timeout", int(ossstats.DefaultTimeout.Seconds()), "Timeout in seconds")
+	checkpoint   = flag.String("checkpoint", "", "Checkpoint file to save progress to and resume from")
+	failPartial  = flag.Bool("fail-on-partial", false, "Exit with status 2 without writing output when results are partial")
+	recordDir    = flag.String("record", "", "Record GitHub API requests and responses to a directory (tokens are scrubbed)")
+	replayDir    = flag.String("replay", "", "Replay GitHub API responses recorded with --record, without network access")
+	saveHistory  = flag.Bool("history", false, "Append the stats of successful runs to the local history (see the history sub-command)")
+	historyFile  = flag.String("history-file", "", "History file (default: <user data dir>/gh-oss-stats/history.jsonl)")
+	gitlabHosts  = flag.String("gitlab", "", "Comma-separated GitLab instances to include, e.g. gitlab.com,gitlab.gnome.org")
+	gitlabUser   = flag.String("gitlab-user", "", "GitLab username, if different from --user")
+	gitlabToken  = flag.String("gitlab-token", os.Getenv("GITLAB_TOKEN"), "GitLab token with the read_api scope (default: $GITLAB_TOKEN)")
+	giteaHosts   = flag.String("gitea", "", "Comma-separated Gitea/Forgejo instances to include, e.g. codeberg.org")
+	giteaUser    = flag.String("gitea-user", "", "Gitea/Forgejo username, if different from --user")
+	giteaToken   = flag.String("gitea-token", os.Getenv("GITEA_TOKEN"), "Gitea/Forgejo token; without one of the user, PRs are found via their activity feed (default: $GITEA_TOKEN)")
+	deps         = flag.String("deps", "", "Comma-separated dependency manifests (go.mod, package.json, Cargo.toml, requirements.txt, pom.xml) whose upstream repos to flag")
+	depsMapping  = flag.String("deps-mapping", "", "JSON file mapping dependencies to GitHub repos, per ecosystem")
+	depsOffline  = flag.Bool("deps-offline", false, "Resolve dependencies from conventions and --deps-mapping only, without registry lookups")
 
 	generateBadge = flag.Bool("badge", false, "Generate SVG badge")
 
-	debug = flag.Bool("debug", false, "Uses fake data when true")
+	debug         = flag.Bool("debug", false, "Uses fake data when true")
+	debugScenario = flag.String("debug-scenario", "", "Uses fake data from a JSON scenario file (implies --debug)")
 )
 
+// exitPartialResults is the exit status used with --fail-on-partial, so CI
+// can tell partial results apart from other failures.
+const exitPartialResults = 2
+
 func runMainCmd(args []string) {
 	// Initialize local badge configuration
 	badgeConfig := newBadgeConfig()
 	badgeConfig.registerBadgeFlags(flag.CommandLine)
+	reportConfig := newReportConfig()
+	reportConfig.registerReportFlags(flag.CommandLine)
 	flag.Parse()
 
 	// Merge short and long flags
@@ -48,12 +82,51 @@ func runMainCmd(args []string) {
 		token = tokenShort
 	}
 	if *outputShort != "" {
-		output = outputShort
+		outputFile = outputShort
 	}
 	if *verboseShort {
 		*verbose = true
 	}
 
+	tokenList := splitCommaList(*tokens)
+
+	appOption, err := createAppOption(*appID, *appInstallID, *appKeyFile)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		os.Exit(1)
+	}
+
+	*debugScenario = strings.TrimSpace(*debugScenario)
+	if *debugScenario != "" {
+		*debug = true
+	}
+
+	*recordDir = strings.TrimSpace(*recordDir)
+	*replayDir = strings.TrimSpace(*replayDir)
+	if *recordDir != "" && *replayDir != "" {
+		fmt.Fprintf(os.Stderr, "Error: --record and --replay cannot be used together\n\n")
+		os.Exit(1)
+	}
+
+	// Reuse the gh CLI's host, login and token when not given explicitly
+	host := ghcli.Host()
+	if strings.TrimSpace(*hostname) != "" {
+		host = ghcli.NormalizeHost(*hostname)
+	}
+	if !*debug && *replayDir == "" {
+		if *token == "" && len(tokenList) == 0 && appOption == nil {
+			if discovered, source := ghcli.ResolveToken(host); discovered != "" {
+				*token = discovered
+				if *verbose {
+					fmt.Fprintf(os.Stderr, "Using token from %s\n", source)
+				}
+			}
+		}
+		if *username == "" {
+			*username = ghcli.User(host)
+		}
+	}
+
 	// Validate required flags
 	if *username == "" {
 		fmt.Fprintf(os.Stderr, "Error: --user is required\n\n")
@@ -66,6 +139,11 @@ func runMainCmd(args []string) {
 		fmt.Fprintf(os.Stderr, "Error: --min-stars must be >= 0 (got: %d)\n\n", *minStars)
 		os.Exit(1)
 	}
+	unknownStarsPolicy, err := ossstats.ParseUnknownStarsPolicy(strings.TrimSpace(*unknownStars))
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: --unknown-stars: %v\n\n", err)
+		os.Exit(1)
+	}
 	if *maxPRs <= 0 {
 		fmt.Fprintf(os.Stderr, "Error: --max-prs must be > 0 (got: %d)\n\n", *maxPRs)
 		os.Exit(1)
@@ -85,10 +163,26 @@ func runMainCmd(args []string) {
 		os.Exit(1)
 	}
 
+	reportOption, err := createReportOptions(*reportConfig)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n\n", err)
+		os.Exit(1)
+	}
+
+	formatSet := false
+	flag.Visit(func(f *flag.Flag) { formatSet = formatSet || f.Name == "format" })
+	targets := outputTargets(*format, formatSet, strings.TrimSpace(*outputFile), *generateBadge, badgeConfig.output)
+	for _, target := range targets {
+		if _, err := output.Lookup(target.format); err != nil {
+			fmt.Fprintf(os.Stderr, "Error: --format: %v\n\n", err)
+			os.Exit(1)
+		}
+	}
+
 	// Warn if no token provided (not an error, but rate limits will be severe)
-	if *token == "" {
+	if *token == "" && len(tokenList) == 0 && appOption == nil && !*debug && *replayDir == "" {
 		fmt.Fprintf(os.Stderr, "Warning: No GitHub token provided. You'll hit rate limits quickly (60 requests/hour).\n")
-		fmt.Fprintf(os.Stderr, "Hint: Set GITHUB_TOKEN environment variable or use --token flag\n")
+		fmt.Fprintf(os.Stderr, "Hint: Run 'gh auth login', set GITHUB_TOKEN environment variable or use --token flag\n")
 		fmt.Fprintf(os.Stderr, "      Create a token at: https://github.com/settings/tokens\n\n")
 	}
 
@@ -98,11 +192,31 @@ func runMainCmd(args []string) {
 		logger = log.New(os.Stderr, "[gh-oss-stats] ", log.LstdFlags)
 	}
 
+	// Resolve dependencies before fetching, so a bad manifest fails fast
+	var dependencies []ossstats.Dependency
+	manifests := splitCommaList(*deps)
+	if len(manifests) > 0 {
+		dependencies, err = ossstats.ResolveDependencies(context.Background(), ossstats.DependencyConfig{
+			Manifests:   manifests,
+			MappingFile: strings.TrimSpace(*depsMapping),
+			Offline:     *depsOffline,
+			Logger:      logger,
+		})
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error: --deps: %v\n", err)
+			os.Exit(1)
+		}
+	} else if strings.TrimSpace(*depsMapping) != "" {
+		fmt.Fprintf(os.Stderr, "Error: --deps-mapping requires --deps\n\n")
+		os.Exit(1)
+	}
+
 	// Create client with options
 	opts := []ossstats.Option{
 		ossstats.WithLOC(*includeLOC),
 		ossstats.WithPRDetails(*includePRs),
 		ossstats.WithMinStars(*minStars),
+		ossstats.WithUnknownStarsPolicy(unknownStarsPolicy),
 		ossstats.WithMaxPRs(*maxPRs),
 		ossstats.WithTimeout(time.Duration(*timeoutSec) * time.Second),
 		ossstats.WithDebug(*debug),
@@ -112,37 +226,89 @@ func runMainCmd(args []string) {
 		opts = append(opts, ossstats.WithToken(*token))
 	}
 
+	if len(tokenList) > 0 {
+		opts = append(opts, ossstats.WithTokens(tokenList))
+	}
+
+	if ghcli.IsEnterprise(host) {
+		opts = append(opts, ossstats.WithBaseURL(ghcli.APIBaseURL(host)))
+	}
+
+	if appOption != nil {
+		opts = append(opts, appOption)
+	}
+
 	if *excludeOrgs != "" {
-		orgs := strings.Split(*excludeOrgs, ",")
-		// Trim whitespace from each org name
-		for i, org := range orgs {
-			orgs[i] = strings.TrimSpace(org)
-		}
-		opts = append(opts, ossstats.WithExcludeOrgs(orgs))
+		opts = append(opts, ossstats.WithExcludeOrgs(splitCommaList(*excludeOrgs)))
 	}
 
 	if logger != nil {
 		opts = append(opts, ossstats.WithLogger(logger))
 	}
 
+	if strings.TrimSpace(*checkpoint) != "" {
+		opts = append(opts, ossstats.WithCheckpoint(strings.TrimSpace(*checkpoint)))
+	}
+
+	if *debugScenario != "" {
+		opts = append(opts, ossstats.WithDebugScenario(*debugScenario))
+	}
+
+	if *recordDir != "" {
+		opts = append(opts, ossstats.WithRecord(*recordDir))
+	}
+
+	if *replayDir != "" {
+		opts = append(opts, ossstats.WithReplay(*replayDir))
+	}
+
+	opts = append(opts, createGitLabOptions(*gitlabHosts, *gitlabUser, *gitlabToken)...)
+	opts = append(opts, createGiteaOptions(*giteaHosts, *giteaUser, *giteaToken)...)
+
 	client := ossstats.New(opts...)
 
 	// Fetch contributions
 	ctx := context.Background()
-	stats, err := client.GetContributions(ctx, *username)
+	var stats *ossstats.Stats
+	if accounts := splitCommaList(*username); len(accounts) > 1 {
+		stats, err = client.GetMergedContributions(ctx, accounts)
+	} else {
+		stats, err = client.GetContributions(ctx, *username)
+	}
+	printTokenUsage(client.TokenUsage())
 
 	// Handle errors
 	if err != nil {
 		// Check for partial results
 		if partialErr, ok := err.(*ossstats.ErrPartialResults); ok {
 			fmt.Fprintf(os.Stderr, "Warning: %v\n", partialErr)
+			printPartialErrorHints(partialErr)
+			printCheckpointHint()
+			if *failPartial {
+				fmt.Fprintf(os.Stderr, "Error: results are partial and --fail-on-partial is set, no output written\n")
+				os.Exit(exitPartialResults)
+			}
 			stats = partialErr.Stats
 		} else if rateLimitErr, ok := err.(*ossstats.ErrRateLimited); ok {
 			fmt.Fprintf(os.Stderr, "Error: %v\n", rateLimitErr)
+			printCheckpointHint()
+			os.Exit(1)
+		} else if secondaryErr, ok := err.(*ossstats.ErrSecondaryRateLimited); ok {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", secondaryErr)
+			printErrorHint(secondaryErr)
+			printCheckpointHint()
+			os.Exit(1)
+		} else if ssoErr, ok := err.(*ossstats.ErrSSORequired); ok {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", ssoErr)
+			printErrorHint(ssoErr)
+			os.Exit(1)
+		} else if forbiddenErr, ok := err.(*ossstats.ErrForbidden); ok {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", forbiddenErr)
+			printErrorHint(forbiddenErr)
 			os.Exit(1)
 		} else if authErr, ok := err.(*ossstats.ErrAuthentication); ok {
 			fmt.Fprintf(os.Stderr, "Error: %v\n", authErr)
-			fmt.Fprintf(os.Stderr, "Hint: Provide a token with --token or set GITHUB_TOKEN\n")
+			fmt.Fprintf(os.Stderr, "Hint: Provide a token with --token, set GITHUB_TOKEN or run 'gh auth login'\n")
 			os.Exit(1)
 		} else if notFoundErr, ok := err.(*ossstats.ErrNotFound); ok {
 			fmt.Fprintf(os.Stderr, "Error: %v\n", notFoundErr)
@@ -153,39 +319,311 @@ func runMainCmd(args []string) {
 		}
 	}
 
-	if strings.TrimSpace(*output) != "" {
-		writeStatsToFile(output, stats)
-		if *verbose {
-			fmt.Fprintf(os.Stderr, "Output written to %s\n", *output)
+	if len(manifests) > 0 {
+		stats.MarkDependencies(dependencies)
+		fmt.Fprintf(os.Stderr, "%d of our %d direct dependencies received contributions\n",
+			stats.Dependencies.Contributed, stats.Dependencies.Total)
+	}
+
+	if *generateBadge {
+		if err := applyBadgeDelta(&badgeOption, *badgeConfig, *historyFile, stats); err != nil {
+			fmt.Fprintf(os.Stderr, "Warning: badge delta unavailable: %v\n", err)
 		}
-	} else if *generateBadge {
-		if err := writeBadge(badgeOption, badgeConfig.output, verbose, stats); err != nil {
-			fmt.Fprintf(os.Stderr, "Error generating badge: %v\n", err)
+	}
+
+	// Only complete runs are recorded, so partial results don't distort the trend
+	if *saveHistory && err == nil {
+		if err := recordHistory(*historyFile, stats); err != nil {
+			fmt.Fprintf(os.Stderr, "Warning: %v\n", err)
+		}
+	}
+
+	outputOpts := output.Options{Badge: badgeOption, Report: reportOption}
+	for _, target := range targets {
+		if err := writeOutput(target, outputOpts, stats); err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
 			os.Exit(1)
 		}
-	} else { // Write stats to stdout
-		jsonData := formatStats(*stats)
-		fmt.Println(string(jsonData))
+		if *verbose && target.path != "" {
+			fmt.Fprintf(os.Stderr, "Output written to %s (%s)\n", target.path, target.format)
+		}
 	}
 
 	os.Exit(0)
 }
 
-func writeStatsToFile(output *string, stats *ossstats.Stats) {
-	jsonData := formatStats(*stats)
+// createAppOption builds the GitHub App authentication option from the
+// --app-* flags. Returns nil when no App flags are set.
+func createAppOption(appIDValue, installationIDValue, keyFile string) (ossstats.Option, error) {
+	appIDValue = strings.TrimSpace(appIDValue)
+	installationIDValue = strings.TrimSpace(installationIDValue)
+	keyFile = strings.TrimSpace(keyFile)
 
-	if err := os.WriteFile(*output, jsonData, 0644); err != nil {
-		fmt.Fprintf(os.Stderr, "Error writing to file: %v\n", err)
-		os.Exit(1)
+	if appIDValue == "" && installationIDValue == "" && keyFile == "" {
+		return nil, nil
 	}
+	if appIDValue == "" || installationIDValue == "" || keyFile == "" {
+		return nil, fmt.Errorf("--app-id, --app-installation-id and --app-private-key must be set together")
+	}
+
+	id, err := strconv.ParseInt(appIDValue, 10, 64)
+	if err != nil || id <= 0 {
+		return nil, fmt.Errorf("--app-id must be a positive integer (got: %s)", appIDValue)
+	}
+	installationID, err := strconv.ParseInt(installationIDValue, 10, 64)
+	if err != nil || installationID <= 0 {
+		return nil, fmt.Errorf("--app-installation-id must be a positive integer (got: %s)", installationIDValue)
+	}
+
+	privateKey, err := os.ReadFile(keyFile)
+	if err != nil {
+		return nil, fmt.Errorf("reading GitHub App private key: %w", err)
+	}
+
+	return ossstats.WithGitHubApp(id, installationID, privateKey), nil
 }
 
-func formatStats(stats ossstats.Stats) []byte {
-	jsonData, encodeErr := json.MarshalIndent(stats, "", "  ")
+// forgeInstance is an instance of another forge given on the command line.
+type forgeInstance struct {
+	baseURL string
+	token   string
+}
 
-	if encodeErr != nil {
-		fmt.Fprintf(os.Stderr, "Error encoding JSON: %v\n", encodeErr)
-		os.Exit(1)
+// parseForgeInstances parses a comma-separated list of instance URLs or
+// hosts. A <envPrefix>_<HOST> environment variable, e.g.
+// GITLAB_TOKEN_GITLAB_GNOME_ORG, overrides the token for one instance.
+func parseForgeInstances(instances, token, envPrefix string) []forgeInstance {
+	var parsed []forgeInstance
+	for _, instance := range splitCommaList(instances) {
+		if instance == "" {
+			continue
+		}
+		baseURL := instance
+		if !strings.Contains(baseURL, "://") {
+			baseURL = "https://" + baseURL
+		}
+
+		instanceToken := token
+		if override := os.Getenv(forgeTokenEnv(envPrefix, baseURL)); override != "" {
+			instanceToken = override
+		}
+		parsed = append(parsed, forgeInstance{baseURL: baseURL, token: instanceToken})
+	}
+	return parsed
+}
+
+// forgeTokenEnv returns the environment variable holding the token of one
+// instance, e.g. GITLAB_TOKEN_GITLAB_GNOME_ORG.
+func forgeTokenEnv(prefix, baseURL string) string {
+	host := baseURL
+	if u, err := url.Parse(baseURL); err == nil && u.Host != "" {
+		host = u.Host
+	}
+	name := strings.Map(func(r rune) rune {
+		if (r >= 'a' && r <= 'z') || (r >= 'A' && r <= 'Z') || (r >= '0' && r <= '9') {
+			return r
+		}
+		return '_'
+	}, host)
+	return prefix + "_" + strings.ToUpper(name)
+}
+
+// createGitLabOptions builds one provider option per GitLab instance in the
+// --gitlab flag.
+func createGitLabOptions(instances, user, token string) []ossstats.Option {
+	var opts []ossstats.Option
+	for _, instance := range parseForgeInstances(instances, token, "GITLAB_TOKEN") {
+		opts = append(opts, ossstats.WithGitLab(ossstats.GitLabConfig{
+			BaseURL:  instance.baseURL,
+			Token:    instance.token,
+			Username: strings.TrimSpace(user),
+		}))
+	}
+	return opts
+}
+
+// createGiteaOptions builds one provider option per Gitea, Forgejo or
+// Codeberg instance in the --gitea flag.
+func createGiteaOptions(instances, user, token string) []ossstats.Option {
+	var opts []ossstats.Option
+	for _, instance := range parseForgeInstances(instances, token, "GITEA_TOKEN") {
+		opts = append(opts, ossstats.WithGitea(ossstats.GiteaConfig{
+			BaseURL:  instance.baseURL,
+			Token:    instance.token,
+			Username: strings.TrimSpace(user),
+		}))
+	}
+	return opts
+}
+
+// splitCommaList splits a comma-separated flag value, trimming whitespace
+// from each entry.
+func splitCommaList(value string) []string {
+	if strings.TrimSpace(value) == "" {
+		return nil
+	}
+
+	items := strings.Split(value, ",")
+	for i, item := range items {
+		items[i] = strings.TrimSpace(item)
+	}
+	return items
+}
+
+// createGitHubOptions returns the options to reach the GitHub host of a
+// sub-command: the token, falling back to $GH_TOKEN, $GITHUB_TOKEN or the gh
+// CLI login, and the API URL of a GitHub Enterprise Server host.
+func createGitHubOptions(token, hostname string) []ossstats.Option {
+	host := ghcli.Host()
+	if strings.TrimSpace(hostname) != "" {
+		host = ghcli.NormalizeHost(hostname)
+	}
+	if token == "" {
+		token, _ = ghcli.ResolveToken(host)
+	}
+
+	var opts []ossstats.Option
+	if token == "" {
+		fmt.Fprintf(os.Stderr, "Warning: No GitHub token provided. You'll hit rate limits quickly.\n")
+		fmt.Fprintf(os.Stderr, "Hint: Run 'gh auth login', set GITHUB_TOKEN environment variable or use --token flag\n\n")
+	} else {
+		opts = append(opts, ossstats.WithToken(token))
+	}
+	if ghcli.IsEnterprise(host) {
+		opts = append(opts, ossstats.WithBaseURL(ghcli.APIBaseURL(host)))
+	}
+	return opts
+}
+
+// printTokenUsage reports how many requests each pooled token served.
+func printTokenUsage(usage []ossstats.TokenUsage) {
+	if len(usage) == 0 {
+		return
+	}
+
+	fmt.Fprintf(os.Stderr, "Token usage:\n")
+	for _, u := range usage {
+		remaining := "unknown"
+		if u.Remaining >= 0 {
+			remaining = fmt.Sprintf("%d remaining, resets at %s", u.Remaining, u.Reset.Format(time.RFC3339))
+		}
+		fmt.Fprintf(os.Stderr, "  %s: %d requests (%s)\n", u.Token, u.Requests, remaining)
+	}
+}
+
+// printErrorHint prints an actionable hint for rate limit and permission errors.
+func printErrorHint(err error) {
+	var secondaryErr *ossstats.ErrSecondaryRateLimited
+	var ssoErr *ossstats.ErrSSORequired
+	var forbiddenErr *ossstats.ErrForbidden
+
+	switch {
+	case errors.As(err, &secondaryErr):
+		fmt.Fprintf(os.Stderr, "Hint: Too many requests in a short period. Wait a few minutes and retry,\n")
+		fmt.Fprintf(os.Stderr, "      or lower --max-prs to reduce the number of requests\n")
+	case errors.As(err, &ssoErr):
+		if ssoErr.URL != "" {
+			fmt.Fprintf(os.Stderr, "Hint: Authorize your token for the organization's SAML SSO: %s\n", ssoErr.URL)
+		} else {
+			fmt.Fprintf(os.Stderr, "Hint: Authorize your token for the organization's SAML SSO at https://github.com/settings/tokens\n")
+		}
+	case errors.As(err, &forbiddenErr):
+		fmt.Fprintf(os.Stderr, "Hint: Your token does not have access to this resource. Check the token's scopes\n")
+		fmt.Fprintf(os.Stderr, "      or use --exclude-orgs to skip organizations you cannot access\n")
+	}
+}
+
+// printPartialErrorHints prints each distinct hint for the errors collected
+// in partial results.
+func printPartialErrorHints(partialErr *ossstats.ErrPartialResults) {
+	printed := make(map[string]bool)
+	for _, err := range partialErr.Errors {
+		var kind string
+		var secondaryErr *ossstats.ErrSecondaryRateLimited
+		var ssoErr *ossstats.ErrSSORequired
+		var forbiddenErr *ossstats.ErrForbidden
+		switch {
+		case errors.As(err, &secondaryErr):
+			kind = "secondary"
+		case errors.As(err, &ssoErr):
+			kind = "sso:" + ssoErr.URL
+		case errors.As(err, &forbiddenErr):
+			kind = "forbidden"
+		default:
+			continue
+		}
+
+		if !printed[kind] {
+			printed[kind] = true
+			printErrorHint(err)
+		}
+	}
+}
+
+// printCheckpointHint tells the user how to resume an incomplete run.
+func printCheckpointHint() {
+	if strings.TrimSpace(*checkpoint) == "" {
+		return
+	}
+	if len(splitCommaList(*username)) > 1 {
+		fmt.Fprintf(os.Stderr, "Hint: Progress saved next to %s (one file per account), re-run with the same --checkpoint to resume\n", *checkpoint)
+		return
+	}
+	fmt.Fprintf(os.Stderr, "Hint: Progress saved to %s, re-run with the same --checkpoint to resume\n", *checkpoint)
+}
+
+// recordHistory appends stats to the history store.
+func recordHistory(path string, stats *ossstats.Stats) error {
+	history, err := openHistory(path)
+	if err != nil {
+		return err
+	}
+	if err := history.Append(stats); err != nil {
+		return fmt.Errorf("recording history: %w", err)
+	}
+	if *verbose {
+		fmt.Fprintf(os.Stderr, "Snapshot recorded in %s\n", history.Path())
+	}
+	return nil
+}
+
+// outputTarget is a format to render the stats in and the file to write them
+// to, or "" for stdout.
+type outputTarget struct {
+	format string
+	path   string
+}
+
+// outputTargets returns where the main command writes its stats: the --format
+// to --output or stdout, and with --badge, a badge to --badge-output, in PNG
+// if it ends in .png and SVG otherwise.
+// Without an explicit --format or --output, --badge only writes the badge.
+func outputTargets(format string, formatSet bool, path string, withBadge bool, badgePath string) []outputTarget {
+	var targets []outputTarget
+	if !withBadge || formatSet || path != "" {
+		targets = append(targets, outputTarget{format: strings.TrimSpace(format), path: path})
+	}
+	if withBadge {
+		if badgePath == "" {
+			badgePath = "badge.svg"
+		}
+		targets = append(targets, outputTarget{format: badgeFormat(badgePath), path: badgePath})
+	}
+	return targets
+}
+
+// writeOutput renders stats in the target's format to its file, or stdout.
+func writeOutput(target outputTarget, opts output.Options, stats *ossstats.Stats) error {
+	if target.path == "" {
+		return output.Render(os.Stdout, target.format, stats, opts)
+	}
+
+	var buf bytes.Buffer
+	if err := output.Render(&buf, target.format, stats, opts); err != nil {
+		return err
+	}
+	if err := os.WriteFile(target.path, buf.Bytes(), 0644); err != nil {
+		return fmt.Errorf("writing %s: %w", target.path, err)
 	}
-	return jsonData
+	return nil
 }
@@ -0,0 +1,144 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"log"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/mabd-dev/gh-oss-stats/pkg/ossstats"
+)
+
+// orgCmd flag set
+var orgCmd = flag.NewFlagSet("org", flag.ExitOnError)
+
+// Org command flags
+var (
+	orgToken       = orgCmd.String("token", "", "GitHub token (default: $GH_TOKEN, $GITHUB_TOKEN or gh CLI login)")
+	orgHostname    = orgCmd.String("hostname", "", "GitHub host, for GitHub Enterprise Server (default: $GH_HOST or github.com)")
+	orgMinStars    = orgCmd.Int("min-stars", ossstats.DefaultMinStars, "Minimum repo stars")
+	orgMaxPRs      = orgCmd.Int("max-prs", ossstats.DefaultMaxPRS, "Max PRs to fetch per member")
+	orgExcludeOrgs = orgCmd.String("exclude-orgs", "", "Comma-separated list of further organizations to exclude")
+	orgTimeout     = orgCmd.Int("timeout", int(ossstats.DefaultTimeout.Seconds()), "Timeout in seconds, per member")
+	orgCheckpoint  = orgCmd.String("checkpoint", "", "Checkpoint file to save progress to and resume from (one file per member)")
+	orgFormat      = orgCmd.String("format", "json", "Output format: json or markdown")
+	orgOutput      = orgCmd.String("output", "", "Output file (default: stdout)")
+	orgVerbose     = orgCmd.Bool("verbose", false, "Verbose logging to stderr")
+	orgDebug       = orgCmd.String("debug-scenario", "", "Uses fake data from a JSON scenario file")
+)
+
+func init() {
+	orgCmd.Usage = func() {
+		fmt.Fprintf(os.Stderr, "Usage: gh-oss-stats org [options] <organization>\n\n")
+		fmt.Fprintf(os.Stderr, "Report the upstream projects an organization's members contribute to,\n")
+		fmt.Fprintf(os.Stderr, "with the contributing members, PR counts and date ranges of each project.\n")
+		fmt.Fprintf(os.Stderr, "The organization's own repositories are excluded. All members are listed\n")
+		fmt.Fprintf(os.Stderr, "when the token belongs to a member, only public members otherwise.\n\n")
+		fmt.Fprintf(os.Stderr, "Options:\n")
+		orgCmd.PrintDefaults()
+		fmt.Fprintf(os.Stderr, "\nExamples:\n")
+		fmt.Fprintf(os.Stderr, "  # JSON report of the upstream projects of acme's members\n")
+		fmt.Fprintf(os.Stderr, "  gh-oss-stats org acme --output acme.json\n\n")
+		fmt.Fprintf(os.Stderr, "  # Markdown report for the OSPO\n")
+		fmt.Fprintf(os.Stderr, "  gh-oss-stats org --format markdown --output upstream.md acme\n\n")
+	}
+}
+
+func runOrgCmd(args []string) {
+	positional := parseInterspersed(orgCmd, args)
+	if len(positional) != 1 {
+		fmt.Fprintf(os.Stderr, "Error: org requires exactly one organization (got %d)\n\n", len(positional))
+		orgCmd.Usage()
+		os.Exit(1)
+	}
+	org := strings.TrimSpace(positional[0])
+
+	format := strings.TrimSpace(*orgFormat)
+	if format != "json" && format != "markdown" && format != "md" {
+		fmt.Fprintf(os.Stderr, "Error: invalid --format %q (valid: json, markdown)\n\n", format)
+		os.Exit(1)
+	}
+	if *orgMinStars < 0 {
+		fmt.Fprintf(os.Stderr, "Error: --min-stars must be >= 0 (got: %d)\n\n", *orgMinStars)
+		os.Exit(1)
+	}
+	if *orgMaxPRs <= 0 {
+		fmt.Fprintf(os.Stderr, "Error: --max-prs must be > 0 (got: %d)\n\n", *orgMaxPRs)
+		os.Exit(1)
+	}
+	if *orgTimeout <= 0 {
+		fmt.Fprintf(os.Stderr, "Error: --timeout must be > 0 seconds (got: %d)\n\n", *orgTimeout)
+		os.Exit(1)
+	}
+
+	opts := []ossstats.Option{
+		ossstats.WithMinStars(*orgMinStars),
+		ossstats.WithMaxPRs(*orgMaxPRs),
+		ossstats.WithTimeout(time.Duration(*orgTimeout) * time.Second),
+	}
+
+	scenario := strings.TrimSpace(*orgDebug)
+	if scenario != "" {
+		opts = append(opts, ossstats.WithDebugScenario(scenario))
+	} else {
+		opts = append(opts, createGitHubOptions(*orgToken, *orgHostname)...)
+	}
+
+	if *orgExcludeOrgs != "" {
+		opts = append(opts, ossstats.WithExcludeOrgs(splitCommaList(*orgExcludeOrgs)))
+	}
+	if strings.TrimSpace(*orgCheckpoint) != "" {
+		opts = append(opts, ossstats.WithCheckpoint(strings.TrimSpace(*orgCheckpoint)))
+	}
+	if *orgVerbose {
+		opts = append(opts, ossstats.WithLogger(log.New(os.Stderr, "[gh-oss-stats] ", log.LstdFlags)))
+	}
+
+	report, err := ossstats.New(opts...).GetOrgMemberContributions(context.Background(), org)
+	if err != nil {
+		if partialErr, ok := err.(*ossstats.ErrPartialResults); ok {
+			fmt.Fprintf(os.Stderr, "Warning: %v\n", partialErr)
+			printPartialErrorHints(partialErr)
+		} else {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			printErrorHint(err)
+			os.Exit(1)
+		}
+	}
+
+	content, err := renderOrgReport(report, format)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		os.Exit(1)
+	}
+
+	if output := strings.TrimSpace(*orgOutput); output != "" {
+		if err := os.WriteFile(output, content, 0644); err != nil {
+			fmt.Fprintf(os.Stderr, "Error writing to file: %v\n", err)
+			os.Exit(1)
+		}
+		os.Exit(0)
+	}
+
+	fmt.Print(string(content))
+	os.Exit(0)
+}
+
+// renderOrgReport renders an organization report in the given output format.
+func renderOrgReport(report *ossstats.OrgReport, format string) ([]byte, error) {
+	switch format {
+	case "markdown", "md":
+		return []byte(report.Markdown()), nil
+	case "json":
+		data, err := json.MarshalIndent(report, "", "  ")
+		if err != nil {
+			return nil, fmt.Errorf("encoding JSON: %w", err)
+		}
+		return append(data, '\n'), nil
+	}
+	return nil, fmt.Errorf("invalid --format %q (valid: json, markdown)", format)
+}
@@ -0,0 +1,126 @@
+package main
+
+import (
+	"fmt"
+	"html"
+	"path/filepath"
+	"regexp"
+	"strings"
+)
+
+// readmeMarker matches the markers around a generated README block, e.g.
+// "<!-- gh-oss-stats:start -->" or "<!-- gh-oss-stats:end badge -->".
+var readmeMarker = regexp.MustCompile(`<!--\s*gh-oss-stats:(start|end)(?:\s+([A-Za-z0-9_.-]+))?\s*-->`)
+
+// defaultReadmeBlock is the name of blocks with unnamed markers.
+const defaultReadmeBlock = "default"
+
+// readmeSection is the content of the README blocks of one name.
+type readmeSection struct {
+	kind string // "markdown" or "badge"
+	path string // Badge image, or "" for --badge-output
+}
+
+// parseReadmeSections parses comma-separated NAME=CONTENT pairs, where
+// CONTENT is markdown, badge or badge:PATH.
+func parseReadmeSections(s string) (map[string]readmeSection, error) {
+	sections := make(map[string]readmeSection)
+	for pair := range strings.SplitSeq(s, ",") {
+		pair = strings.TrimSpace(pair)
+		if pair == "" {
+			continue
+		}
+		name, content, ok := strings.Cut(pair, "=")
+		name, content = strings.TrimSpace(name), strings.TrimSpace(content)
+		if !ok || name == "" {
+			return nil, fmt.Errorf("invalid section %q, expected NAME=CONTENT", pair)
+		}
+
+		kind, path, _ := strings.Cut(content, ":")
+		switch kind {
+		case "markdown":
+			if path != "" {
+				return nil, fmt.Errorf("invalid section %q, markdown takes no path", pair)
+			}
+		case "badge":
+		default:
+			return nil, fmt.Errorf("invalid section %q, content must be markdown, badge or badge:PATH", pair)
+		}
+		sections[name] = readmeSection{kind: kind, path: strings.TrimSpace(path)}
+	}
+	return sections, nil
+}
+
+// replaceReadmeBlocks replaces the content between each pair of start and end
+// markers with render(name), keeping the markers. Replacing twice with the
+// same content is a no-op.
+func replaceReadmeBlocks(content string, render func(name string) (string, error)) (string, error) {
+	newline := "\n"
+	if strings.Contains(content, "\r\n") {
+		newline = "\r\n"
+	}
+
+	var b strings.Builder
+	last, blocks := 0, 0
+	open := "" // Name of the block being replaced
+	inside := false
+	for _, m := range readmeMarker.FindAllStringSubmatchIndex(content, -1) {
+		kind := content[m[2]:m[3]]
+		name := defaultReadmeBlock
+		if m[4] >= 0 {
+			name = content[m[4]:m[5]]
+		}
+		line := strings.Count(content[:m[0]], "\n") + 1
+
+		if kind == "start" {
+			if inside {
+				return "", fmt.Errorf("line %d: start marker of block %q before the end of block %q", line, name, open)
+			}
+			b.WriteString(content[last:m[1]])
+			last, open, inside = m[1], name, true
+			continue
+		}
+
+		if !inside {
+			return "", fmt.Errorf("line %d: end marker of block %q without a start marker", line, name)
+		}
+		if name != open {
+			return "", fmt.Errorf("line %d: end marker of block %q closes block %q", line, name, open)
+		}
+		generated, err := render(name)
+		if err != nil {
+			return "", fmt.Errorf("block %q: %w", name, err)
+		}
+		generated = strings.TrimRight(generated, "\r\n")
+		b.WriteString(newline)
+		if generated != "" {
+			b.WriteString(strings.ReplaceAll(strings.ReplaceAll(generated, "\r\n", "\n"), "\n", newline))
+			b.WriteString(newline)
+		}
+		b.WriteString(content[m[0]:m[1]])
+		last, inside = m[1], false
+		blocks++
+	}
+
+	if inside {
+		return "", fmt.Errorf("block %q has no end marker", open)
+	}
+	if blocks == 0 {
+		return "", fmt.Errorf("no <!-- gh-oss-stats:start --> and <!-- gh-oss-stats:end --> markers found")
+	}
+	b.WriteString(content[last:])
+	return b.String(), nil
+}
+
+// readmeBadge returns an <img> referencing the badge at path, relative to
+// the README at readmePath. URLs are referenced as they are.
+func readmeBadge(readmePath, path, alt string) string {
+	src := path
+	if !strings.Contains(path, "://") {
+		if rel, err := filepath.Rel(filepath.Dir(readmePath), path); err == nil {
+			src = rel
+		}
+		src = filepath.ToSlash(src)
+	}
+	return fmt.Sprintf(`<img src="%s" alt="%s">`, html.EscapeString(src), html.EscapeString(alt))
+}
@@ -0,0 +1,145 @@
+package main
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/mabd-dev/gh-oss-stats/pkg/ossstats"
+	"github.com/mabd-dev/gh-oss-stats/pkg/ossstats/output"
+)
+
+func TestParseReadmeSections(t *testing.T) {
+	sections, err := parseReadmeSections("default=markdown, badge=badge ,detailed=badge:assets/detailed.svg")
+	if err != nil {
+		t.Fatalf("parseReadmeSections() error = %v", err)
+	}
+	want := map[string]readmeSection{
+		"default":  {kind: "markdown"},
+		"badge":    {kind: "badge"},
+		"detailed": {kind: "badge", path: "assets/detailed.svg"},
+	}
+	if len(sections) != len(want) {
+		t.Fatalf("parseReadmeSections() = %+v, want %+v", sections, want)
+	}
+	for name, section := range want {
+		if sections[name] != section {
+			t.Errorf("section %q = %+v, want %+v", name, sections[name], section)
+		}
+	}
+
+	for _, invalid := range []string{"default", "=markdown", "default=html", "default=markdown:x.md"} {
+		if _, err := parseReadmeSections(invalid); err == nil {
+			t.Errorf("parseReadmeSections(%q) error = nil, want error", invalid)
+		}
+	}
+}
+
+func TestReplaceReadmeBlocks(t *testing.T) {
+	render := func(name string) (string, error) {
+		switch name {
+		case "default":
+			return "| Repository |\n|---|\n| golang/go |\n", nil
+		case "badge":
+			return `<img src="badge.svg" alt="">`, nil
+		}
+		return "", errors.New("unknown block")
+	}
+
+	tests := []struct {
+		name    string
+		content string
+		want    string
+		wantErr string
+	}{
+		{
+			name:    "unnamed block",
+			content: "# Hi\n<!-- gh-oss-stats:start -->\nold\n<!-- gh-oss-stats:end -->\nBye\n",
+			want:    "# Hi\n<!-- gh-oss-stats:start -->\n| Repository |\n|---|\n| golang/go |\n<!-- gh-oss-stats:end -->\nBye\n",
+		},
+		{
+			name:    "named blocks",
+			content: "<!--gh-oss-stats:start badge--><!--gh-oss-stats:end badge-->\n\n<!-- gh-oss-stats:start default -->\n<!-- gh-oss-stats:end default -->",
+			want:    "<!--gh-oss-stats:start badge-->\n<img src=\"badge.svg\" alt=\"\">\n<!--gh-oss-stats:end badge-->\n\n<!-- gh-oss-stats:start default -->\n| Repository |\n|---|\n| golang/go |\n<!-- gh-oss-stats:end default -->",
+		},
+		{
+			name:    "CRLF line endings",
+			content: "a\r\n<!-- gh-oss-stats:start badge -->\r\n<!-- gh-oss-stats:end badge -->\r\n",
+			want:    "a\r\n<!-- gh-oss-stats:start badge -->\r\n<img src=\"badge.svg\" alt=\"\">\r\n<!-- gh-oss-stats:end badge -->\r\n",
+		},
+		{name: "no markers", content: "# Hi\n", wantErr: "no <!-- gh-oss-stats:start -->"},
+		{name: "missing end", content: "<!-- gh-oss-stats:start -->\n", wantErr: `block "default" has no end marker`},
+		{name: "missing start", content: "<!-- gh-oss-stats:end -->\n", wantErr: "line 1: end marker"},
+		{name: "mismatched end", content: "<!-- gh-oss-stats:start badge -->\n<!-- gh-oss-stats:end -->", wantErr: `line 2: end marker of block "default" closes block "badge"`},
+		{name: "nested", content: "<!-- gh-oss-stats:start -->\n<!-- gh-oss-stats:start badge -->", wantErr: "before the end"},
+		{name: "render error", content: "<!-- gh-oss-stats:start other --><!-- gh-oss-stats:end other -->", wantErr: `block "other": unknown block`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := replaceReadmeBlocks(tt.content, render)
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("replaceReadmeBlocks() error = %v, want %q", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("replaceReadmeBlocks() error = %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("replaceReadmeBlocks() =\n%q\nwant\n%q", got, tt.want)
+			}
+
+			again, err := replaceReadmeBlocks(got, render)
+			if err != nil || again != got {
+				t.Errorf("replaceReadmeBlocks() is not idempotent: %q, error %v", again, err)
+			}
+		})
+	}
+}
+
+func TestReplaceReadmeBlocksIgnoresGeneratedAt(t *testing.T) {
+	render := func(generatedAt time.Time) func(string) (string, error) {
+		stats := &ossstats.Stats{
+			Username:      "octocat",
+			GeneratedAt:   generatedAt,
+			Summary:       ossstats.Summary{TotalProjects: 1, TotalPRsMerged: 2},
+			Contributions: []ossstats.Contribution{{Repo: "golang/go", RepoURL: "https://github.com/golang/go", PRsMerged: 2}},
+		}
+		return func(string) (string, error) {
+			return readmeMarkdown(stats, output.ReportOptions{})
+		}
+	}
+
+	readme := "# Hi\n<!-- gh-oss-stats:start -->\n<!-- gh-oss-stats:end -->\n"
+	first, err := replaceReadmeBlocks(readme, render(time.Date(2025, 6, 1, 0, 0, 0, 0, time.UTC)))
+	if err != nil {
+		t.Fatalf("replaceReadmeBlocks() error = %v", err)
+	}
+	second, err := replaceReadmeBlocks(first, render(time.Date(2025, 6, 2, 0, 0, 0, 0, time.UTC)))
+	if err != nil {
+		t.Fatalf("replaceReadmeBlocks() error = %v", err)
+	}
+	if second != first {
+		t.Errorf("replaceReadmeBlocks() with a later GeneratedAt changed the README:\n%s\nwant:\n%s", second, first)
+	}
+	if !strings.Contains(first, "golang/go") {
+		t.Errorf("README block = %q, want the report", first)
+	}
+}
+
+func TestReadmeBadge(t *testing.T) {
+	tests := []struct {
+		readme, path, want string
+	}{
+		{"README.md", "badge.svg", `<img src="badge.svg" alt="OSS">`},
+		{"profile/README.md", "assets/badge.svg", `<img src="../assets/badge.svg" alt="OSS">`},
+		{"README.md", "https://example.com/b.svg?a=1&b=2", `<img src="https://example.com/b.svg?a=1&amp;b=2" alt="OSS">`},
+	}
+	for _, tt := range tests {
+		if got := readmeBadge(tt.readme, tt.path, "OSS"); got != tt.want {
+			t.Errorf("readmeBadge(%q, %q) = %s, want %s", tt.readme, tt.path, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,85 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/mabd-dev/gh-oss-stats/pkg/ossstats/output"
+)
+
+// reportCmd flag set
+var reportCmd = flag.NewFlagSet("report", flag.ExitOnError)
+
+// Report command flags
+var (
+	reportFormat = reportCmd.String("format", "markdown", "Output format: "+strings.Join(output.Formats(), ", "))
+	reportOutput = reportCmd.String("output", "", "Output file (default: stdout)")
+)
+
+func init() {
+	reportCmd.Usage = func() {
+		fmt.Fprintf(os.Stderr, "Usage: gh-oss-stats report [options] <stats.json>\n\n")
+		fmt.Fprintf(os.Stderr, "Render a stats JSON file as a report, e.g. a Markdown summary and table of\n")
+		fmt.Fprintf(os.Stderr, "contributions to paste into a README, or convert it to another format such\n")
+		fmt.Fprintf(os.Stderr, "as CSV or HTML, without re-fetching data from GitHub.\n\n")
+		fmt.Fprintf(os.Stderr, "Options:\n")
+		reportCmd.PrintDefaults()
+		fmt.Fprintf(os.Stderr, "\nExamples:\n")
+		fmt.Fprintf(os.Stderr, "  # Markdown report of all contributions\n")
+		fmt.Fprintf(os.Stderr, "  gh-oss-stats report stats.json\n\n")
+		fmt.Fprintf(os.Stderr, "  # Top 10 projects by stars, with selected columns\n")
+		fmt.Fprintf(os.Stderr, "  gh-oss-stats report --report-sort stars --report-limit 10 --report-columns repo,stars,prs --output OSS.md stats.json\n\n")
+		fmt.Fprintf(os.Stderr, "  # Convert to CSV, one row per contribution or per PR\n")
+		fmt.Fprintf(os.Stderr, "  gh-oss-stats report --format csv --output contributions.csv stats.json\n")
+		fmt.Fprintf(os.Stderr, "  gh-oss-stats report --format csv-prs --output prs.csv stats.json\n\n")
+	}
+}
+
+func runReportCmd(args []string) {
+	reportConfig := newReportConfig()
+	reportConfig.registerReportFlags(reportCmd)
+	badgeConfig := newBadgeConfig()
+	badgeConfig.registerBadgeFlags(reportCmd)
+
+	files := parseInterspersed(reportCmd, args)
+	if len(files) != 1 {
+		fmt.Fprintf(os.Stderr, "Error: report requires exactly one stats file (got %d)\n\n", len(files))
+		reportCmd.Usage()
+		os.Exit(1)
+	}
+
+	format := strings.TrimSpace(*reportFormat)
+	if _, err := output.Lookup(format); err != nil {
+		fmt.Fprintf(os.Stderr, "Error: --format: %v\n\n", err)
+		os.Exit(1)
+	}
+	reportOption, err := createReportOptions(*reportConfig)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n\n", err)
+		os.Exit(1)
+	}
+	if badgeConfig.limit <= 0 {
+		fmt.Fprintf(os.Stderr, "Error: --badge-limit must be > 0 (got: %d)\n\n", badgeConfig.limit)
+		os.Exit(1)
+	}
+	badgeOption, err := createBadgeOptions(*badgeConfig)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		os.Exit(1)
+	}
+
+	stats, err := readStatsFile(files[0])
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		os.Exit(1)
+	}
+
+	target := outputTarget{format: format, path: strings.TrimSpace(*reportOutput)}
+	if err := writeOutput(target, output.Options{Badge: badgeOption, Report: reportOption}, stats); err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		os.Exit(1)
+	}
+	os.Exit(0)
+}
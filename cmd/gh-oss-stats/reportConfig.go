@@ -0,0 +1,63 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"strings"
+
+	"github.com/mabd-dev/gh-oss-stats/pkg/ossstats/badge"
+	"github.com/mabd-dev/gh-oss-stats/pkg/ossstats/output"
+)
+
+// ReportConfig holds the flags of the report formats, such as markdown.
+type ReportConfig struct {
+	columns string
+	sort    string
+	limit   int
+}
+
+// newReportConfig creates a new ReportConfig with default values
+func newReportConfig() *ReportConfig {
+	return &ReportConfig{
+		columns: joinColumns(output.DefaultColumns),
+		sort:    string(badge.DefaultSortBy),
+	}
+}
+
+func (rc *ReportConfig) registerReportFlags(fs *flag.FlagSet) {
+	fs.StringVar(&rc.columns, "report-columns", joinColumns(output.DefaultColumns), "Comma-separated report columns: "+joinColumns(output.Columns))
+	fs.StringVar(&rc.sort, "report-sort", string(badge.DefaultSortBy), "Sort report rows by: prs, stars, commits")
+	fs.IntVar(&rc.limit, "report-limit", 0, "Number of contributions in the report (0 for all)")
+}
+
+// createReportOptions validates the report flags.
+func createReportOptions(conf ReportConfig) (output.ReportOptions, error) {
+	columns, err := output.ParseColumns(conf.columns)
+	if err != nil {
+		return output.ReportOptions{}, fmt.Errorf("--report-columns: %w", err)
+	}
+
+	sortBy, err := badge.SortByFromName(strings.TrimSpace(conf.sort))
+	if err != nil {
+		return output.ReportOptions{}, fmt.Errorf("--report-sort: %w", err)
+	}
+
+	if conf.limit < 0 {
+		return output.ReportOptions{}, fmt.Errorf("--report-limit must be >= 0 (got: %d)", conf.limit)
+	}
+
+	return output.ReportOptions{
+		Columns: columns,
+		SortBy:  sortBy,
+		Limit:   conf.limit,
+	}, nil
+}
+
+// joinColumns joins column names with commas.
+func joinColumns(columns []output.Column) string {
+	names := make([]string, len(columns))
+	for i, column := range columns {
+		names[i] = string(column)
+	}
+	return strings.Join(names, ",")
+}
@@ -0,0 +1,46 @@
+package main
+
+import (
+	"flag"
+	"slices"
+	"testing"
+
+	"github.com/mabd-dev/gh-oss-stats/pkg/ossstats/badge"
+	"github.com/mabd-dev/gh-oss-stats/pkg/ossstats/output"
+)
+
+func TestCreateReportOptions(t *testing.T) {
+	rc := newReportConfig()
+	fs := flag.NewFlagSet("test", flag.ContinueOnError)
+	rc.registerReportFlags(fs)
+
+	opts, err := createReportOptions(*rc)
+	if err != nil {
+		t.Fatalf("createReportOptions() with defaults error = %v", err)
+	}
+	if !slices.Equal(opts.Columns, output.DefaultColumns) || opts.SortBy != badge.DefaultSortBy || opts.Limit != 0 {
+		t.Errorf("createReportOptions() defaults = %+v", opts)
+	}
+
+	if err := fs.Parse([]string{"--report-columns", "repo,stars", "--report-sort", "STARS", "--report-limit", "3"}); err != nil {
+		t.Fatal(err)
+	}
+	opts, err = createReportOptions(*rc)
+	if err != nil {
+		t.Fatalf("createReportOptions() error = %v", err)
+	}
+	if !slices.Equal(opts.Columns, []output.Column{output.ColumnRepo, output.ColumnStars}) || opts.SortBy != badge.SortByStars || opts.Limit != 3 {
+		t.Errorf("createReportOptions() = %+v", opts)
+	}
+
+	invalid := []ReportConfig{
+		{columns: "repo,owner", sort: "prs"},
+		{columns: "repo", sort: "forks"},
+		{columns: "repo", sort: "prs", limit: -1},
+	}
+	for _, conf := range invalid {
+		if _, err := createReportOptions(conf); err == nil {
+			t.Errorf("createReportOptions(%+v) error = nil", conf)
+		}
+	}
+}
@@ -0,0 +1,45 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/mabd-dev/gh-oss-stats/pkg/ossstats"
+)
+
+// schemaCmd flag set
+var schemaCmd = flag.NewFlagSet("schema", flag.ExitOnError)
+
+// Schema command flags
+var (
+	schemaOutput = schemaCmd.String("output", "", "Output file (default: stdout)")
+)
+
+func init() {
+	schemaCmd.Usage = func() {
+		fmt.Fprintf(os.Stderr, "Usage: gh-oss-stats schema [options]\n\n")
+		fmt.Fprintf(os.Stderr, "Print the JSON Schema of the stats JSON written by gh-oss-stats (schema version %d).\n\n", ossstats.StatsSchemaVersion)
+		fmt.Fprintf(os.Stderr, "Options:\n")
+		schemaCmd.PrintDefaults()
+		fmt.Fprintf(os.Stderr, "\nExamples:\n")
+		fmt.Fprintf(os.Stderr, "  # Regenerate the published schema\n")
+		fmt.Fprintf(os.Stderr, "  gh-oss-stats schema --output docs/stats.schema.json\n\n")
+	}
+}
+
+func runSchemaCmd(args []string) {
+	schemaCmd.Parse(args)
+
+	if output := strings.TrimSpace(*schemaOutput); output != "" {
+		if err := os.WriteFile(output, ossstats.JSONSchema(), 0644); err != nil {
+			fmt.Fprintf(os.Stderr, "Error writing to file: %v\n", err)
+			os.Exit(1)
+		}
+		os.Exit(0)
+	}
+
+	fmt.Print(string(ossstats.JSONSchema()))
+	os.Exit(0)
+}
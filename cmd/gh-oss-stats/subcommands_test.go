@@ -5,8 +5,13 @@ import (
 	"flag"
 	"io"
 	"os"
+	"path/filepath"
 	"strings"
 	"testing"
+	"time"
+
+	"github.com/mabd-dev/gh-oss-stats/pkg/ossstats"
+	"github.com/mabd-dev/gh-oss-stats/pkg/ossstats/badge"
 )
 
 // Helper to capture stderr output
@@ -386,3 +391,223 @@ func TestDemoCmdHasBadgeFlags(t *testing.T) {
 		})
 	}
 }
+
+func TestParseInterspersed(t *testing.T) {
+	fs := flag.NewFlagSet("test", flag.ContinueOnError)
+	format := fs.String("format", "text", "")
+	output := fs.String("output", "", "")
+
+	files := parseInterspersed(fs, []string{"old.json", "--format", "json", "new.json", "--output", "out.json"})
+
+	if len(files) != 2 || files[0] != "old.json" || files[1] != "new.json" {
+		t.Errorf("positional = %v, want [old.json new.json]", files)
+	}
+	if *format != "json" || *output != "out.json" {
+		t.Errorf("format = %q, output = %q", *format, *output)
+	}
+}
+
+func TestRenderDiff(t *testing.T) {
+	older := &ossstats.Stats{Username: "octocat"}
+	newer := &ossstats.Stats{
+		Username:      "octocat",
+		Summary:       ossstats.Summary{TotalProjects: 1, TotalPRsMerged: 2},
+		Contributions: []ossstats.Contribution{{Repo: "golang/go", PRsMerged: 2}},
+	}
+	diff := older.Diff(newer)
+
+	tests := []struct {
+		format  string
+		want    string
+		wantErr bool
+	}{
+		{"text", "New repositories (1):", false},
+		{"markdown", "### New repositories", false},
+		{"md", "### New repositories", false},
+		{"json", `"newRepos"`, false},
+		{"xml", "", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.format, func(t *testing.T) {
+			got, err := renderDiff(diff, tt.format)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("renderDiff() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !strings.Contains(string(got), tt.want) {
+				t.Errorf("renderDiff() = %s, want to contain %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRenderOrgReport(t *testing.T) {
+	report := ossstats.NewOrgReport("acme", &ossstats.Stats{
+		Username:      "octocat",
+		Contributions: []ossstats.Contribution{{Repo: "golang/go", PRsMerged: 2}},
+	})
+
+	tests := []struct {
+		format  string
+		want    string
+		wantErr bool
+	}{
+		{"markdown", "## Upstream contributions by acme members", false},
+		{"md", "| golang/go |", false},
+		{"json", `"contributors"`, false},
+		{"xml", "", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.format, func(t *testing.T) {
+			got, err := renderOrgReport(report, tt.format)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("renderOrgReport() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !strings.Contains(string(got), tt.want) {
+				t.Errorf("renderOrgReport() = %s, want to contain %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRenderRepoContributors(t *testing.T) {
+	result := &ossstats.RepoContributors{
+		Repo:         "golang/go",
+		Summary:      ossstats.RepoContributorsSummary{TotalContributors: 1, TotalPRsMerged: 2},
+		Contributors: []ossstats.ExternalContributor{{Username: "octocat", PRsMerged: 2}},
+	}
+
+	tests := []struct {
+		format  string
+		want    string
+		wantErr bool
+	}{
+		{"markdown", "## External contributors to golang/go", false},
+		{"md", "[octocat](https://github.com/octocat)", false},
+		{"json", `"totalContributors": 1`, false},
+		{"xml", "", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.format, func(t *testing.T) {
+			got, err := renderRepoContributors(result, tt.format)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("renderRepoContributors() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !strings.Contains(string(got), tt.want) {
+				t.Errorf("renderRepoContributors() = %s, want to contain %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestReadStatsFile(t *testing.T) {
+	dir := t.TempDir()
+
+	valid := filepath.Join(dir, "stats.json")
+	os.WriteFile(valid, []byte(`{"username": "octocat", "summary": {"totalProjects": 3}}`), 0644)
+	stats, err := readStatsFile(valid)
+	if err != nil {
+		t.Fatalf("readStatsFile() error: %v", err)
+	}
+	if stats.Username != "octocat" || stats.Summary.TotalProjects != 3 {
+		t.Errorf("readStatsFile() = %+v", stats)
+	}
+
+	invalid := filepath.Join(dir, "invalid.json")
+	os.WriteFile(invalid, []byte(`{`), 0644)
+	if _, err := readStatsFile(invalid); err == nil {
+		t.Error("Expected error for invalid JSON")
+	}
+
+	if _, err := readStatsFile(filepath.Join(dir, "missing.json")); err == nil {
+		t.Error("Expected error for missing file")
+	}
+}
+
+func TestRenderHistory(t *testing.T) {
+	day1 := time.Date(2025, 6, 1, 0, 0, 0, 0, time.UTC)
+	snapshots := []ossstats.Stats{
+		{
+			Username:      "octocat",
+			GeneratedAt:   day1,
+			Summary:       ossstats.Summary{TotalProjects: 1, TotalPRsMerged: 2},
+			Contributions: []ossstats.Contribution{{Repo: "golang/go", PRsMerged: 2, Stars: 100}},
+		},
+		{
+			Username:      "hubot",
+			GeneratedAt:   day1,
+			Summary:       ossstats.Summary{TotalProjects: 1, TotalPRsMerged: 1},
+			Contributions: []ossstats.Contribution{{Repo: "acme/app", PRsMerged: 1, Stars: 5}},
+		},
+		{
+			Username:      "octocat",
+			GeneratedAt:   day1.AddDate(0, 0, 7),
+			Summary:       ossstats.Summary{TotalProjects: 2, TotalPRsMerged: 5},
+			Contributions: []ossstats.Contribution{{Repo: "golang/go", PRsMerged: 4, Stars: 110}, {Repo: "acme/app", PRsMerged: 1, Stars: 5}},
+		},
+	}
+
+	text, err := renderHistory(snapshots, "text")
+	if err != nil {
+		t.Fatalf("renderHistory() error: %v", err)
+	}
+	for _, want := range []string{
+		"History for octocat (2 snapshots)",
+		"History for hubot (1 snapshots)",
+		"2025-06-08 00:00  2 (+1)",
+		"Since 2025-06-01: +1 projects, +3 PRs merged, +10 stars gained",
+	} {
+		if !strings.Contains(string(text), want) {
+			t.Errorf("renderHistory() missing %q:\n%s", want, text)
+		}
+	}
+
+	data, err := renderHistory(snapshots, "json")
+	if err != nil {
+		t.Fatalf("renderHistory() error: %v", err)
+	}
+	if !strings.Contains(string(data), `"starsGained": 10`) {
+		t.Errorf("renderHistory() JSON = %s, want starsGained 10", data)
+	}
+
+	if _, err := renderHistory(snapshots, "xml"); err == nil {
+		t.Error("Expected error for invalid format")
+	}
+}
+
+func TestApplyBadgeDelta(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "history.jsonl")
+	now := time.Date(2025, 6, 30, 0, 0, 0, 0, time.UTC)
+	history := ossstats.NewHistory(path)
+	if err := history.Append(&ossstats.Stats{Username: "octocat", GeneratedAt: now.AddDate(0, 0, -10)}); err != nil {
+		t.Fatal(err)
+	}
+
+	stats := &ossstats.Stats{Username: "octocat", GeneratedAt: now}
+	conf := newBadgeConfig()
+
+	var opts badge.BadgeOptions
+	if err := applyBadgeDelta(&opts, *conf, path, stats); err != nil || opts.Previous != nil {
+		t.Errorf("applyBadgeDelta() without --badge-delta = %v, %v, want no previous snapshot", opts.Previous, err)
+	}
+
+	conf.delta = true
+	if err := applyBadgeDelta(&opts, *conf, path, stats); err != nil {
+		t.Fatalf("applyBadgeDelta() error: %v", err)
+	}
+	if opts.Previous == nil || !opts.Previous.GeneratedAt.Equal(now.AddDate(0, 0, -10)) {
+		t.Errorf("applyBadgeDelta() previous = %v, want the recorded snapshot", opts.Previous)
+	}
+}
+
+func TestReadStatsFileUnsupportedVersion(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "stats.json")
+	os.WriteFile(path, []byte(`{"schemaVersion": 99, "username": "octocat"}`), 0644)
+
+	_, err := readStatsFile(path)
+	if err == nil || !strings.Contains(err.Error(), "unsupported stats schema version 99") {
+		t.Errorf("readStatsFile() error = %v, want unsupported schema version", err)
+	}
+}
@@ -0,0 +1,144 @@
+package main
+
+import (
+	"bytes"
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/mabd-dev/gh-oss-stats/pkg/ossstats"
+	"github.com/mabd-dev/gh-oss-stats/pkg/ossstats/output"
+)
+
+// updateReadmeCmd flag set
+var updateReadmeCmd = flag.NewFlagSet("update-readme", flag.ExitOnError)
+
+// Update-readme command flags
+var (
+	readmeStats      = updateReadmeCmd.String("stats", "", "Stats JSON file, required by markdown sections")
+	readmeSections   = updateReadmeCmd.String("sections", "default=markdown,badge=badge", "Comma-separated block contents: NAME=markdown, NAME=badge or NAME=badge:PATH")
+	readmeBadgeImage = updateReadmeCmd.String("badge-output", "badge.svg", "Badge file referenced by badge sections")
+)
+
+func init() {
+	updateReadmeCmd.Usage = func() {
+		fmt.Fprintf(os.Stderr, "Usage: gh-oss-stats update-readme [options] <README.md>\n\n")
+		fmt.Fprintf(os.Stderr, "Replace the content between gh-oss-stats markers in a file, e.g. a profile README:\n\n")
+		fmt.Fprintf(os.Stderr, "  <!-- gh-oss-stats:start -->\n")
+		fmt.Fprintf(os.Stderr, "  <!-- gh-oss-stats:end -->\n\n")
+		fmt.Fprintf(os.Stderr, "Named blocks, e.g. <!-- gh-oss-stats:start badge --> ... <!-- gh-oss-stats:end badge -->,\n")
+		fmt.Fprintf(os.Stderr, "get the content configured by --sections. The file is left untouched when its\n")
+		fmt.Fprintf(os.Stderr, "content is unchanged.\n\n")
+		fmt.Fprintf(os.Stderr, "Options:\n")
+		updateReadmeCmd.PrintDefaults()
+		fmt.Fprintf(os.Stderr, "\nExamples:\n")
+		fmt.Fprintf(os.Stderr, "  # Markdown report in the unnamed block, badge.svg in the badge block\n")
+		fmt.Fprintf(os.Stderr, "  gh-oss-stats --badge --output stats.json\n")
+		fmt.Fprintf(os.Stderr, "  gh-oss-stats update-readme --stats stats.json README.md\n\n")
+		fmt.Fprintf(os.Stderr, "  # Top 5 projects in a \"projects\" block and two badges\n")
+		fmt.Fprintf(os.Stderr, "  gh-oss-stats update-readme --stats stats.json --report-limit 5 \\\n")
+		fmt.Fprintf(os.Stderr, "    --sections projects=markdown,summary=badge:assets/summary.svg,detailed=badge:assets/detailed.svg README.md\n\n")
+	}
+}
+
+func runUpdateReadmeCmd(args []string) {
+	reportConfig := newReportConfig()
+	reportConfig.registerReportFlags(updateReadmeCmd)
+
+	files := parseInterspersed(updateReadmeCmd, args)
+	if len(files) != 1 {
+		fmt.Fprintf(os.Stderr, "Error: update-readme requires exactly one target file (got %d)\n\n", len(files))
+		updateReadmeCmd.Usage()
+		os.Exit(1)
+	}
+	path := files[0]
+
+	sections, err := parseReadmeSections(*readmeSections)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: --sections: %v\n\n", err)
+		os.Exit(1)
+	}
+	reportOption, err := createReportOptions(*reportConfig)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n\n", err)
+		os.Exit(1)
+	}
+
+	var stats *ossstats.Stats
+	if statsPath := strings.TrimSpace(*readmeStats); statsPath != "" {
+		stats, err = readStatsFile(statsPath)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(1)
+		}
+	}
+
+	info, err := os.Stat(path)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		os.Exit(1)
+	}
+	content, err := os.ReadFile(path)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		os.Exit(1)
+	}
+
+	render := func(name string) (string, error) {
+		section, ok := sections[name]
+		if !ok {
+			return "", fmt.Errorf("no content configured, add %s=markdown or %s=badge to --sections", name, name)
+		}
+
+		if section.kind == "badge" {
+			image := section.path
+			if image == "" {
+				image = strings.TrimSpace(*readmeBadgeImage)
+			}
+			if _, err := os.Stat(image); err != nil && !strings.Contains(image, "://") {
+				fmt.Fprintf(os.Stderr, "Warning: badge %s does not exist yet, generate it with --badge --badge-output %s\n", image, image)
+			}
+			alt := "Open source contributions"
+			if stats != nil {
+				alt += " by " + stats.Username
+			}
+			return readmeBadge(path, image, alt), nil
+		}
+
+		if stats == nil {
+			return "", errors.New("markdown sections require --stats")
+		}
+		return readmeMarkdown(stats, reportOption)
+	}
+
+	updated, err := replaceReadmeBlocks(string(content), render)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %s: %v\n", path, err)
+		os.Exit(1)
+	}
+
+	if updated == string(content) {
+		fmt.Fprintf(os.Stderr, "%s is up to date\n", path)
+		os.Exit(0)
+	}
+	if err := os.WriteFile(path, []byte(updated), info.Mode().Perm()); err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		os.Exit(1)
+	}
+	fmt.Fprintf(os.Stderr, "Updated %s\n", path)
+	os.Exit(0)
+}
+
+// readmeMarkdown renders stats as a Markdown report for a README block,
+// without the generation date, so the README is only rewritten when the
+// numbers change.
+func readmeMarkdown(stats *ossstats.Stats, report output.ReportOptions) (string, error) {
+	report.OmitGenerated = true
+	var buf bytes.Buffer
+	if err := output.Render(&buf, "markdown", stats, output.Options{Report: report}); err != nil {
+		return "", err
+	}
+	return buf.String(), nil
+}
@@ -0,0 +1,257 @@
+// Package ghcli discovers the host and credentials configured for the GitHub
+// CLI (gh), so gh-oss-stats can reuse `gh auth login` instead of requiring a
+// separate personal access token.
+package ghcli
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"time"
+)
+
+const (
+	// DefaultHost is the host used when GH_HOST is not set
+	DefaultHost = "github.com"
+
+	hostsFileName = "hosts.yml"
+)
+
+// Token sources reported by ResolveToken.
+const (
+	SourceEnvGHToken               = "GH_TOKEN"
+	SourceEnvGitHubToken           = "GITHUB_TOKEN"
+	SourceEnvGHEnterpriseToken     = "GH_ENTERPRISE_TOKEN"
+	SourceEnvGitHubEnterpriseToken = "GITHUB_ENTERPRISE_TOKEN"
+	SourceHostsFile                = "gh hosts.yml"
+	SourceGHAuthToken              = "gh auth token"
+)
+
+// ghAuthTokenTimeout bounds how long `gh auth token` may run.
+const ghAuthTokenTimeout = 5 * time.Second
+
+// HostConfig is the configuration stored for one host in gh's hosts.yml.
+type HostConfig struct {
+	User       string
+	OAuthToken string
+	// UserTokens holds per-account tokens written by gh's multi-account support
+	UserTokens map[string]string
+}
+
+// Host returns the GitHub host gh is configured to use: $GH_HOST, or
+// github.com when unset.
+func Host() string {
+	if host := strings.TrimSpace(os.Getenv("GH_HOST")); host != "" {
+		return NormalizeHost(host)
+	}
+	return DefaultHost
+}
+
+// NormalizeHost strips a scheme and trailing slash from a host name and
+// lower-cases it, e.g. "https://GHE.example.com/" -> "ghe.example.com".
+func NormalizeHost(host string) string {
+	host = strings.TrimSpace(host)
+	host = strings.TrimPrefix(host, "https://")
+	host = strings.TrimPrefix(host, "http://")
+	host = strings.TrimSuffix(host, "/")
+	return strings.ToLower(host)
+}
+
+// IsEnterprise reports whether host is a GitHub Enterprise Server host.
+func IsEnterprise(host string) bool {
+	host = NormalizeHost(host)
+	return host != DefaultHost && host != "api.github.com"
+}
+
+// APIBaseURL returns the REST API base URL for a host.
+func APIBaseURL(host string) string {
+	if !IsEnterprise(host) {
+		return "https://api.github.com"
+	}
+	return "https://" + NormalizeHost(host) + "/api/v3"
+}
+
+// ResolveToken finds a token for host the way gh does: environment variables
+// first, then the hosts.yml config file, then `gh auth token` for tokens gh
+// keeps in the system keyring. It returns the token and where it came from,
+// or two empty strings when no token is found.
+func ResolveToken(host string) (token, source string) {
+	host = NormalizeHost(host)
+
+	envVars := []string{SourceEnvGHToken, SourceEnvGitHubToken}
+	if IsEnterprise(host) {
+		envVars = []string{SourceEnvGHEnterpriseToken, SourceEnvGitHubEnterpriseToken}
+	}
+	for _, name := range envVars {
+		if token := strings.TrimSpace(os.Getenv(name)); token != "" {
+			return token, name
+		}
+	}
+
+	if hosts, err := ReadHostsFile(HostsFilePath()); err == nil {
+		if cfg, ok := hosts[host]; ok {
+			if token := cfg.Token(); token != "" {
+				return token, SourceHostsFile
+			}
+		}
+	}
+
+	if token := ghAuthToken(host); token != "" {
+		return token, SourceGHAuthToken
+	}
+
+	return "", ""
+}
+
+// User returns the account gh is logged in with for host, or "" if unknown.
+func User(host string) string {
+	hosts, err := ReadHostsFile(HostsFilePath())
+	if err != nil {
+		return ""
+	}
+	return hosts[NormalizeHost(host)].User
+}
+
+// Token returns the token for the active user of the host.
+func (hc HostConfig) Token() string {
+	if hc.OAuthToken != "" {
+		return hc.OAuthToken
+	}
+	return hc.UserTokens[hc.User]
+}
+
+// ConfigDir returns gh's configuration directory, following gh's lookup
+// order: $GH_CONFIG_DIR, $XDG_CONFIG_HOME/gh, %AppData%/GitHub CLI on
+// Windows, then ~/.config/gh.
+func ConfigDir() string {
+	if dir := os.Getenv("GH_CONFIG_DIR"); dir != "" {
+		return dir
+	}
+	if dir := os.Getenv("XDG_CONFIG_HOME"); dir != "" {
+		return filepath.Join(dir, "gh")
+	}
+	if runtime.GOOS == "windows" {
+		if dir := os.Getenv("AppData"); dir != "" {
+			return filepath.Join(dir, "GitHub CLI")
+		}
+	}
+
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return ""
+	}
+	return filepath.Join(home, ".config", "gh")
+}
+
+// HostsFilePath returns the path of gh's hosts.yml.
+func HostsFilePath() string {
+	return filepath.Join(ConfigDir(), hostsFileName)
+}
+
+// ReadHostsFile reads gh's hosts.yml, keyed by host name.
+func ReadHostsFile(path string) (map[string]HostConfig, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	return ParseHosts(data), nil
+}
+
+// ParseHosts parses the subset of gh's hosts.yml used for authentication:
+//
+//	github.com:
+//	    user: octocat
+//	    oauth_token: gho_xxx
+//	    users:
+//	        octocat:
+//	            oauth_token: gho_xxx
+//
+// It is not a general YAML parser; unknown keys are ignored.
+func ParseHosts(data []byte) map[string]HostConfig {
+	hosts := make(map[string]HostConfig)
+
+	var host, usersUser string
+	var hostIndent, usersIndent, userIndent = -1, -1, -1
+
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	for scanner.Scan() {
+		line := strings.TrimRight(scanner.Text(), " \t\r")
+		trimmed := strings.TrimLeft(line, " ")
+		if trimmed == "" || strings.HasPrefix(trimmed, "#") {
+			continue
+		}
+		indent := len(line) - len(trimmed)
+
+		key, value, ok := strings.Cut(trimmed, ":")
+		if !ok {
+			continue
+		}
+		key = strings.TrimSpace(key)
+		value = unquote(strings.TrimSpace(value))
+
+		switch {
+		case indent == 0:
+			host = NormalizeHost(key)
+			hostIndent, usersIndent, userIndent = -1, -1, -1
+			usersUser = ""
+			if _, exists := hosts[host]; !exists {
+				hosts[host] = HostConfig{UserTokens: make(map[string]string)}
+			}
+		case host == "":
+			continue
+		case hostIndent == -1 || indent == hostIndent:
+			hostIndent = indent
+			usersIndent, userIndent = -1, -1
+			cfg := hosts[host]
+			switch key {
+			case "user":
+				cfg.User = value
+			case "oauth_token":
+				cfg.OAuthToken = value
+			case "users":
+				usersIndent = indent
+			}
+			hosts[host] = cfg
+		case usersIndent != -1 && (userIndent == -1 || indent == userIndent) && indent > usersIndent:
+			userIndent = indent
+			usersUser = key
+		case usersIndent != -1 && userIndent != -1 && indent > userIndent && key == "oauth_token":
+			hosts[host].UserTokens[usersUser] = value
+		}
+	}
+
+	return hosts
+}
+
+// unquote removes matching single or double quotes around a YAML scalar.
+func unquote(value string) string {
+	if len(value) >= 2 {
+		if (value[0] == '"' && value[len(value)-1] == '"') || (value[0] == '\'' && value[len(value)-1] == '\'') {
+			return value[1 : len(value)-1]
+		}
+	}
+	return value
+}
+
+// ghAuthToken asks the gh binary for the host's token, which covers tokens
+// stored in the system keyring rather than hosts.yml.
+func ghAuthToken(host string) string {
+	path, err := exec.LookPath("gh")
+	if err != nil {
+		return ""
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), ghAuthTokenTimeout)
+	defer cancel()
+
+	out, err := exec.CommandContext(ctx, path, "auth", "token", "--hostname", host).Output()
+	if err != nil {
+		return ""
+	}
+	return strings.TrimSpace(string(out))
+}
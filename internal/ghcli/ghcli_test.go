@@ -0,0 +1,167 @@
+package ghcli
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+// isolate clears gh related environment so tests don't pick up the
+// developer's real gh login.
+func isolate(t *testing.T) string {
+	t.Helper()
+
+	configDir := t.TempDir()
+	t.Setenv("GH_CONFIG_DIR", configDir)
+	t.Setenv("PATH", t.TempDir()) // no gh binary
+	for _, name := range []string{"GH_HOST", "GH_TOKEN", "GITHUB_TOKEN", "GH_ENTERPRISE_TOKEN", "GITHUB_ENTERPRISE_TOKEN"} {
+		t.Setenv(name, "")
+	}
+	return configDir
+}
+
+func writeHostsFile(t *testing.T, dir, content string) {
+	t.Helper()
+	if err := os.WriteFile(filepath.Join(dir, hostsFileName), []byte(content), 0o600); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestParseHosts(t *testing.T) {
+	data := []byte(`# gh hosts file
+github.com:
+    user: octocat
+    oauth_token: gho_legacy
+    git_protocol: https
+ghe.example.com:
+    users:
+        alice:
+            oauth_token: "gho_alice"
+        bob:
+            oauth_token: 'gho_bob'
+    git_protocol: ssh
+    user: bob
+`)
+
+	hosts := ParseHosts(data)
+
+	gh, ok := hosts["github.com"]
+	if !ok {
+		t.Fatal("github.com host not parsed")
+	}
+	if gh.User != "octocat" || gh.Token() != "gho_legacy" {
+		t.Errorf("github.com = %+v, want user octocat and token gho_legacy", gh)
+	}
+
+	ghe, ok := hosts["ghe.example.com"]
+	if !ok {
+		t.Fatal("ghe.example.com host not parsed")
+	}
+	if ghe.User != "bob" {
+		t.Errorf("User = %q, want bob", ghe.User)
+	}
+	if ghe.UserTokens["alice"] != "gho_alice" {
+		t.Errorf("UserTokens[alice] = %q, want gho_alice", ghe.UserTokens["alice"])
+	}
+	if ghe.Token() != "gho_bob" {
+		t.Errorf("Token() = %q, want active user's token gho_bob", ghe.Token())
+	}
+}
+
+func TestResolveTokenPrecedence(t *testing.T) {
+	configDir := isolate(t)
+	writeHostsFile(t, configDir, "github.com:\n    user: octocat\n    oauth_token: gho_hosts\n")
+
+	token, source := ResolveToken("github.com")
+	if token != "gho_hosts" || source != SourceHostsFile {
+		t.Errorf("ResolveToken() = (%q, %q), want hosts.yml token", token, source)
+	}
+
+	t.Setenv("GITHUB_TOKEN", "ghp_github")
+	token, source = ResolveToken("github.com")
+	if token != "ghp_github" || source != SourceEnvGitHubToken {
+		t.Errorf("ResolveToken() = (%q, %q), want GITHUB_TOKEN", token, source)
+	}
+
+	t.Setenv("GH_TOKEN", "ghp_gh")
+	token, source = ResolveToken("github.com")
+	if token != "ghp_gh" || source != SourceEnvGHToken {
+		t.Errorf("ResolveToken() = (%q, %q), want GH_TOKEN", token, source)
+	}
+}
+
+func TestResolveTokenEnterprise(t *testing.T) {
+	isolate(t)
+	t.Setenv("GH_TOKEN", "ghp_dotcom")
+	t.Setenv("GH_ENTERPRISE_TOKEN", "ghp_enterprise")
+
+	token, source := ResolveToken("ghe.example.com")
+	if token != "ghp_enterprise" || source != SourceEnvGHEnterpriseToken {
+		t.Errorf("ResolveToken() = (%q, %q), want GH_ENTERPRISE_TOKEN", token, source)
+	}
+}
+
+func TestResolveTokenNotFound(t *testing.T) {
+	isolate(t)
+
+	token, source := ResolveToken("github.com")
+	if token != "" || source != "" {
+		t.Errorf("ResolveToken() = (%q, %q), want empty", token, source)
+	}
+}
+
+func TestUser(t *testing.T) {
+	configDir := isolate(t)
+	writeHostsFile(t, configDir, "github.com:\n    user: octocat\n")
+
+	if got := User("github.com"); got != "octocat" {
+		t.Errorf("User() = %q, want octocat", got)
+	}
+	if got := User("ghe.example.com"); got != "" {
+		t.Errorf("User() for unknown host = %q, want empty", got)
+	}
+}
+
+func TestHost(t *testing.T) {
+	isolate(t)
+
+	if got := Host(); got != DefaultHost {
+		t.Errorf("Host() = %q, want %q", got, DefaultHost)
+	}
+
+	t.Setenv("GH_HOST", "https://GHE.example.com/")
+	if got := Host(); got != "ghe.example.com" {
+		t.Errorf("Host() = %q, want ghe.example.com", got)
+	}
+}
+
+func TestAPIBaseURL(t *testing.T) {
+	tests := []struct {
+		host string
+		want string
+	}{
+		{"github.com", "https://api.github.com"},
+		{"api.github.com", "https://api.github.com"},
+		{"ghe.example.com", "https://ghe.example.com/api/v3"},
+		{"https://ghe.example.com/", "https://ghe.example.com/api/v3"},
+	}
+
+	for _, tt := range tests {
+		if got := APIBaseURL(tt.host); got != tt.want {
+			t.Errorf("APIBaseURL(%q) = %q, want %q", tt.host, got, tt.want)
+		}
+	}
+}
+
+func TestConfigDir(t *testing.T) {
+	t.Setenv("GH_CONFIG_DIR", "/custom/gh")
+	if got := ConfigDir(); got != "/custom/gh" {
+		t.Errorf("ConfigDir() = %q, want /custom/gh", got)
+	}
+
+	t.Setenv("GH_CONFIG_DIR", "")
+	t.Setenv("XDG_CONFIG_HOME", "/xdg")
+	if got := ConfigDir(); got != filepath.Join("/xdg", "gh") {
+		t.Errorf("ConfigDir() = %q, want /xdg/gh", got)
+	}
+}
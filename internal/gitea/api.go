@@ -0,0 +1,205 @@
+// Package gitea is a low-level client for the parts of the Gitea REST API
+// (v1) used to find a user's merged pull requests. Forgejo and Codeberg
+// serve the same API.
+package gitea
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// APIClient is a low-level Gitea API client.
+type APIClient struct {
+	httpClient *http.Client
+	token      string
+	baseURL    string // Instance URL, without the /api/v1 suffix
+}
+
+// APIError is returned for HTTP error responses.
+type APIError struct {
+	StatusCode int
+	Message    string // "message" field of the JSON error body, if any
+	Body       string
+
+	// RetryAt is when a rate limited request may be retried, if known
+	RetryAt time.Time
+}
+
+func (e *APIError) Error() string {
+	return fmt.Sprintf("HTTP %d: %s", e.StatusCode, e.Body)
+}
+
+// newAPIError builds an APIError from an error response.
+func newAPIError(resp *http.Response, body []byte) *APIError {
+	apiErr := &APIError{
+		StatusCode: resp.StatusCode,
+		Body:       string(body),
+	}
+
+	var payload struct {
+		Message string `json:"message"`
+	}
+	if err := json.Unmarshal(body, &payload); err == nil {
+		apiErr.Message = payload.Message
+	}
+
+	if seconds, err := strconv.Atoi(resp.Header.Get("Retry-After")); err == nil {
+		apiErr.RetryAt = time.Now().Add(time.Duration(seconds) * time.Second)
+	}
+
+	return apiErr
+}
+
+// NewAPIClient creates a Gitea API client for the instance at baseURL,
+// e.g. "https://codeberg.org".
+func NewAPIClient(httpClient *http.Client, baseURL, token string) *APIClient {
+	return &APIClient{
+		httpClient: httpClient,
+		token:      token,
+		baseURL:    strings.TrimSuffix(strings.TrimSuffix(baseURL, "/"), "/api/v1"),
+	}
+}
+
+// BaseURL returns the instance URL the client talks to.
+func (c *APIClient) BaseURL() string {
+	return c.baseURL
+}
+
+// get performs a GET request against the v1 API and decodes the JSON response.
+func (c *APIClient) get(ctx context.Context, path string, result any) (*http.Response, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, c.baseURL+"/api/v1"+path, nil)
+	if err != nil {
+		return nil, fmt.Errorf("creating request: %w", err)
+	}
+	req.Header.Set("Accept", "application/json")
+	if c.token != "" {
+		req.Header.Set("Authorization", "token "+c.token)
+	}
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("executing request: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode >= 400 {
+		body, _ := io.ReadAll(resp.Body)
+		return resp, newAPIError(resp, body)
+	}
+
+	if result != nil {
+		if err := json.NewDecoder(resp.Body).Decode(result); err != nil {
+			return resp, fmt.Errorf("decoding response: %w", err)
+		}
+	}
+
+	return resp, nil
+}
+
+// GetAuthenticatedUser fetches the user the token belongs to.
+func (c *APIClient) GetAuthenticatedUser(ctx context.Context) (*User, *http.Response, error) {
+	var user User
+	resp, err := c.get(ctx, "/user", &user)
+	if err != nil {
+		return nil, resp, err
+	}
+	return &user, resp, nil
+}
+
+// GetUser fetches a user by username.
+func (c *APIClient) GetUser(ctx context.Context, username string) (*User, *http.Response, error) {
+	var user User
+	resp, err := c.get(ctx, "/users/"+url.PathEscape(username), &user)
+	if err != nil {
+		return nil, resp, err
+	}
+	return &user, resp, nil
+}
+
+// ListUserActivities lists one page of the activity feed of a user, limited
+// to actions the user performed. Activities in private repositories are
+// only included when the token can see them.
+func (c *APIClient) ListUserActivities(ctx context.Context, username string, page, limit int) ([]Activity, *http.Response, error) {
+	params := url.Values{}
+	params.Set("only-performed-by", "true")
+	params.Set("page", strconv.Itoa(page))
+	params.Set("limit", strconv.Itoa(limit))
+
+	var activities []Activity
+	resp, err := c.get(ctx, "/users/"+url.PathEscape(username)+"/activities/feeds?"+params.Encode(), &activities)
+	if err != nil {
+		return nil, resp, err
+	}
+	return activities, resp, nil
+}
+
+// SearchPullRequests lists one page of the closed pull requests created by
+// the authenticated user, across all repositories visible to the token.
+// The issue search can only filter by the authenticated user; use
+// ListUserActivities to find the pull requests of other users.
+func (c *APIClient) SearchPullRequests(ctx context.Context, page, limit int) ([]Issue, *http.Response, error) {
+	params := url.Values{}
+	params.Set("type", "pulls")
+	params.Set("state", "closed")
+	params.Set("created", "true")
+	params.Set("page", strconv.Itoa(page))
+	params.Set("limit", strconv.Itoa(limit))
+
+	var issues []Issue
+	resp, err := c.get(ctx, "/repos/issues/search?"+params.Encode(), &issues)
+	if err != nil {
+		return nil, resp, err
+	}
+	return issues, resp, nil
+}
+
+// GetRepository fetches a repository.
+func (c *APIClient) GetRepository(ctx context.Context, owner, repo string) (*Repository, *http.Response, error) {
+	var repository Repository
+	resp, err := c.get(ctx, fmt.Sprintf("/repos/%s/%s", url.PathEscape(owner), url.PathEscape(repo)), &repository)
+	if err != nil {
+		return nil, resp, err
+	}
+	return &repository, resp, nil
+}
+
+// GetPullRequest fetches a pull request, including its line counts.
+func (c *APIClient) GetPullRequest(ctx context.Context, owner, repo string, number int) (*PullRequest, *http.Response, error) {
+	var pr PullRequest
+	resp, err := c.get(ctx, fmt.Sprintf("/repos/%s/%s/pulls/%d", url.PathEscape(owner), url.PathEscape(repo), number), &pr)
+	if err != nil {
+		return nil, resp, err
+	}
+	return &pr, resp, nil
+}
+
+// ListPullRequestCommits lists one page of the commits of a pull request.
+func (c *APIClient) ListPullRequestCommits(ctx context.Context, owner, repo string, number, page, limit int) ([]Commit, *http.Response, error) {
+	var commits []Commit
+	path := fmt.Sprintf("/repos/%s/%s/pulls/%d/commits?page=%d&limit=%d&stat=false&verification=false&files=false",
+		url.PathEscape(owner), url.PathEscape(repo), number, page, limit)
+	resp, err := c.get(ctx, path, &commits)
+	if err != nil {
+		return nil, resp, err
+	}
+	return commits, resp, nil
+}
+
+// HasNextPage reports whether a paginated response has more pages, based on
+// its Link header, or on a full page when the header is missing.
+func HasNextPage(resp *http.Response, count, limit int) bool {
+	if resp == nil {
+		return false
+	}
+	if link := resp.Header.Get("Link"); link != "" {
+		return strings.Contains(link, `rel="next"`)
+	}
+	return count == limit
+}
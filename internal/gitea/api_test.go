@@ -0,0 +1,122 @@
+package gitea
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestSearchPullRequests(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/api/v1/repos/issues/search" {
+			http.NotFound(w, r)
+			return
+		}
+		if got := r.Header.Get("Authorization"); got != "token test-token" {
+			t.Errorf("Authorization = %q, want token test-token", got)
+		}
+		query := r.URL.Query()
+		if query.Get("type") != "pulls" || query.Get("state") != "closed" || query.Get("created") != "true" {
+			t.Errorf("unexpected query %s", r.URL.RawQuery)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`[{"number": 3, "pull_request": {"merged": true, "merged_at": "2025-03-01T12:00:00Z"}, "repository": {"owner": "forgejo", "name": "forgejo", "full_name": "forgejo/forgejo"}}]`))
+	}))
+	defer server.Close()
+
+	client := NewAPIClient(server.Client(), server.URL+"/api/v1/", "test-token")
+	if client.BaseURL() != server.URL {
+		t.Errorf("BaseURL() = %q, want %q", client.BaseURL(), server.URL)
+	}
+
+	issues, _, err := client.SearchPullRequests(context.Background(), 1, 50)
+	if err != nil {
+		t.Fatalf("SearchPullRequests() error = %v", err)
+	}
+	if len(issues) != 1 || issues[0].PullRequest == nil || !issues[0].PullRequest.Merged || issues[0].Repository.FullName != "forgejo/forgejo" {
+		t.Errorf("SearchPullRequests() = %+v, want the merged forgejo/forgejo PR", issues)
+	}
+}
+
+func TestListUserActivities(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/api/v1/users/alice/activities/feeds" {
+			http.NotFound(w, r)
+			return
+		}
+		if got := r.Header.Get("Authorization"); got != "" {
+			t.Errorf("Authorization = %q, want none without a token", got)
+		}
+		query := r.URL.Query()
+		if query.Get("only-performed-by") != "true" || query.Get("page") != "2" || query.Get("limit") != "50" {
+			t.Errorf("unexpected query %s", r.URL.RawQuery)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`[
+			{"op_type": "create_pull_request", "content": "7|Fix typo", "repo": {"name": "forgejo", "full_name": "forgejo/forgejo", "owner": {"login": "forgejo"}}},
+			{"op_type": "commit_repo", "content": "{}", "repo": {"full_name": "alice/dotfiles"}}
+		]`))
+	}))
+	defer server.Close()
+
+	activities, _, err := NewAPIClient(server.Client(), server.URL, "").ListUserActivities(context.Background(), "alice", 2, 50)
+	if err != nil {
+		t.Fatalf("ListUserActivities() error = %v", err)
+	}
+	if len(activities) != 2 || activities[0].Repo.Owner.Login != "forgejo" {
+		t.Fatalf("ListUserActivities() = %+v, want 2 activities", activities)
+	}
+	if number, ok := activities[0].PullRequestNumber(); !ok || number != 7 {
+		t.Errorf("PullRequestNumber() = %d, %v, want 7, true", number, ok)
+	}
+	if _, ok := activities[1].PullRequestNumber(); ok {
+		t.Error("PullRequestNumber() of a push = true, want false")
+	}
+}
+
+func TestAPIError(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Retry-After", "30")
+		w.WriteHeader(http.StatusTooManyRequests)
+		w.Write([]byte(`{"message": "too many requests"}`))
+	}))
+	defer server.Close()
+
+	_, _, err := NewAPIClient(server.Client(), server.URL, "").GetRepository(context.Background(), "a", "b")
+
+	var apiErr *APIError
+	if !errors.As(err, &apiErr) {
+		t.Fatalf("GetRepository() error = %v, want *APIError", err)
+	}
+	if apiErr.StatusCode != http.StatusTooManyRequests || apiErr.Message != "too many requests" || apiErr.RetryAt.IsZero() {
+		t.Errorf("APIError = %+v, want 429 with message and retry time", apiErr)
+	}
+}
+
+func TestHasNextPage(t *testing.T) {
+	tests := []struct {
+		name  string
+		link  string
+		count int
+		want  bool
+	}{
+		{"next link", `<https://codeberg.org/api/v1/repos/issues/search?page=2>; rel="next"`, 50, true},
+		{"last page link", `<https://codeberg.org/api/v1/repos/issues/search?page=1>; rel="first"`, 50, false},
+		{"no link, full page", "", 50, true},
+		{"no link, short page", "", 3, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			resp := &http.Response{Header: http.Header{}}
+			if tt.link != "" {
+				resp.Header.Set("Link", tt.link)
+			}
+			if got := HasNextPage(resp, tt.count, 50); got != tt.want {
+				t.Errorf("HasNextPage() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,95 @@
+package gitea
+
+import (
+	"strconv"
+	"strings"
+	"time"
+)
+
+// User represents a Gitea user.
+type User struct {
+	ID       int    `json:"id"`
+	Login    string `json:"login"`
+	FullName string `json:"full_name"`
+	HTMLURL  string `json:"html_url"`
+}
+
+// Issue represents an issue or pull request returned by the issue search.
+type Issue struct {
+	ID          int              `json:"id"`
+	Number      int              `json:"number"`
+	Title       string           `json:"title"`
+	State       string           `json:"state"`
+	HTMLURL     string           `json:"html_url"`
+	User        User             `json:"user"`
+	PullRequest *PullRequestMeta `json:"pull_request"` // Set for pull requests only
+	Repository  RepositoryMeta   `json:"repository"`
+}
+
+// PullRequestMeta is the pull request summary embedded in an Issue.
+type PullRequestMeta struct {
+	Merged   bool       `json:"merged"`
+	MergedAt *time.Time `json:"merged_at"`
+}
+
+// RepositoryMeta is the repository summary embedded in an Issue.
+type RepositoryMeta struct {
+	ID       int    `json:"id"`
+	Name     string `json:"name"`
+	Owner    string `json:"owner"`
+	FullName string `json:"full_name"`
+}
+
+// Repository represents a Gitea repository.
+type Repository struct {
+	ID          int    `json:"id"`
+	Name        string `json:"name"`
+	FullName    string `json:"full_name"`
+	Owner       User   `json:"owner"`
+	Description string `json:"description"`
+	HTMLURL     string `json:"html_url"`
+	StarsCount  int    `json:"stars_count"`
+	Language    string `json:"language"`
+	Fork        bool   `json:"fork"`
+}
+
+// PullRequest represents a Gitea pull request.
+type PullRequest struct {
+	Number       int        `json:"number"`
+	Title        string     `json:"title"`
+	HTMLURL      string     `json:"html_url"`
+	Merged       bool       `json:"merged"`
+	MergedAt     *time.Time `json:"merged_at"`
+	Additions    int        `json:"additions"`
+	Deletions    int        `json:"deletions"`
+	ChangedFiles int        `json:"changed_files"`
+}
+
+// Commit is a commit of a pull request.
+type Commit struct {
+	SHA string `json:"sha"`
+}
+
+// OpCreatePullRequest is the OpType of an Activity that opened a pull request.
+const OpCreatePullRequest = "create_pull_request"
+
+// Activity is an entry of a user's activity feed.
+type Activity struct {
+	ID      int        `json:"id"`
+	OpType  string     `json:"op_type"`
+	ActUser User       `json:"act_user"`
+	Repo    Repository `json:"repo"`
+	Content string     `json:"content"` // "<number>|<title>" for pull requests
+	Created time.Time  `json:"created"`
+}
+
+// PullRequestNumber returns the number of the pull request an activity
+// opened, and false for other activities.
+func (a Activity) PullRequestNumber() (int, bool) {
+	if a.OpType != OpCreatePullRequest {
+		return 0, false
+	}
+	number, _, _ := strings.Cut(a.Content, "|")
+	n, err := strconv.Atoi(number)
+	return n, err == nil
+}
@@ -20,9 +20,40 @@ const (
 
 // APIClient is a low-level GitHub API client.
 type APIClient struct {
-	httpClient *http.Client
-	token      string
-	baseURL    string
+	httpClient  *http.Client
+	token       string
+	tokenPool   *TokenPool
+	tokenSource TokenSource
+	baseURL     string
+}
+
+// APIError is returned for HTTP error responses.
+// It keeps the response body so callers can classify the failure.
+type APIError struct {
+	StatusCode int
+	Message    string // "message" field of the JSON error body, if any
+	Body       string
+}
+
+func (e *APIError) Error() string {
+	return fmt.Sprintf("HTTP %d: %s", e.StatusCode, e.Body)
+}
+
+// newAPIError builds an APIError from a response status and body.
+func newAPIError(statusCode int, body []byte) *APIError {
+	apiErr := &APIError{
+		StatusCode: statusCode,
+		Body:       string(body),
+	}
+
+	var payload struct {
+		Message string `json:"message"`
+	}
+	if err := json.Unmarshal(body, &payload); err == nil {
+		apiErr.Message = payload.Message
+	}
+
+	return apiErr
 }
 
 // NewAPIClient creates a new GitHub API client.
@@ -34,8 +65,68 @@ func NewAPIClient(httpClient *http.Client, token string) *APIClient {
 	}
 }
 
+// NewAPIClientWithTokenPool creates a GitHub API client that spreads
+// requests across the tokens in pool.
+func NewAPIClientWithTokenPool(httpClient *http.Client, pool *TokenPool) *APIClient {
+	return &APIClient{
+		httpClient: httpClient,
+		tokenPool:  pool,
+		baseURL:    GitHubAPIBaseURL,
+	}
+}
+
+// NewAPIClientWithTokenSource creates a GitHub API client that asks source
+// for the token of every request, e.g. a GitHub App installation token.
+func NewAPIClientWithTokenSource(httpClient *http.Client, source TokenSource) *APIClient {
+	return &APIClient{
+		httpClient:  httpClient,
+		tokenSource: source,
+		baseURL:     GitHubAPIBaseURL,
+	}
+}
+
+// SetBaseURL points the client at a different REST API endpoint, such as a
+// GitHub Enterprise Server instance ("https://ghe.example.com/api/v3").
+func (c *APIClient) SetBaseURL(baseURL string) {
+	c.baseURL = strings.TrimSuffix(baseURL, "/")
+}
+
 // doRequest performs an HTTP request with proper authentication and headers.
+// With a token pool, a request rejected by the primary rate limit is retried
+// with the next token that still has budget.
 func (c *APIClient) doRequest(ctx context.Context, method, path string, body io.Reader) (*http.Response, error) {
+	if c.tokenSource != nil {
+		token, err := c.tokenSource.Token(ctx)
+		if err != nil {
+			return nil, &TokenError{Err: err}
+		}
+		return c.doRequestWithToken(ctx, method, path, body, token)
+	}
+
+	if c.tokenPool == nil {
+		return c.doRequestWithToken(ctx, method, path, body, c.token)
+	}
+
+	resource := resourceForPath(path)
+	for attempt := 1; ; attempt++ {
+		token := c.tokenPool.Acquire(resource)
+		resp, err := c.doRequestWithToken(ctx, method, path, body, token)
+		if err != nil {
+			return nil, err
+		}
+		c.tokenPool.Update(token, resp.Header)
+
+		// Request bodies cannot be replayed, so only body-less requests fail over
+		canRetry := body == nil && attempt < c.tokenPool.Len()
+		if !canRetry || ClassifyResponse(resp, nil) != ClassPrimaryRateLimit || !c.tokenPool.Available(resource) {
+			return resp, nil
+		}
+		resp.Body.Close()
+	}
+}
+
+// doRequestWithToken performs a single HTTP request authenticated with token.
+func (c *APIClient) doRequestWithToken(ctx context.Context, method, path string, body io.Reader, token string) (*http.Response, error) {
 	url := c.baseURL + path
 
 	req, err := http.NewRequestWithContext(ctx, method, url, body)
@@ -48,8 +139,8 @@ func (c *APIClient) doRequest(ctx context.Context, method, path string, body io.
 	req.Header.Set("X-GitHub-Api-Version", APIVersion)
 
 	// Add authentication if token is provided
-	if c.token != "" {
-		req.Header.Set("Authorization", "Bearer "+c.token)
+	if token != "" {
+		req.Header.Set("Authorization", "Bearer "+token)
 	}
 
 	resp, err := c.httpClient.Do(req)
@@ -71,7 +162,7 @@ func (c *APIClient) get(ctx context.Context, path string, result interface{}) (*
 	// Check for HTTP errors
 	if resp.StatusCode >= 400 {
 		body, _ := io.ReadAll(resp.Body)
-		return resp, fmt.Errorf("HTTP %d: %s", resp.StatusCode, string(body))
+		return resp, newAPIError(resp.StatusCode, body)
 	}
 
 	// Decode JSON response
@@ -130,6 +221,21 @@ func (c *APIClient) GetRepository(ctx context.Context, owner, repo string) (*Rep
 	return &result, resp, nil
 }
 
+// ListOrgMembers lists one page of an organization's members. GitHub
+// returns every member when the token belongs to a member of the
+// organization, and only the public members otherwise.
+func (c *APIClient) ListOrgMembers(ctx context.Context, org string, page, perPage int) ([]User, *http.Response, error) {
+	path := fmt.Sprintf("/orgs/%s/members?page=%d&per_page=%d", url.PathEscape(org), page, perPage)
+
+	var result []User
+	resp, err := c.get(ctx, path, &result)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return result, resp, nil
+}
+
 // GetRateLimit fetches the current rate limit status.
 func (c *APIClient) GetRateLimit(ctx context.Context) (*RateLimitResponse, error) {
 	path := "/rate_limit"
@@ -0,0 +1,206 @@
+package github
+
+import (
+	"context"
+	"crypto"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+)
+
+const (
+	// appJWTLifetime is how long a GitHub App JWT is valid (GitHub allows up to 10 minutes)
+	appJWTLifetime = 9 * time.Minute
+
+	// appJWTClockSkew backdates the JWT issue time to tolerate clock drift
+	appJWTClockSkew = 60 * time.Second
+
+	// InstallationTokenRefreshMargin is how long before expiry an installation
+	// token is refreshed
+	InstallationTokenRefreshMargin = 5 * time.Minute
+)
+
+// TokenSource provides the token used to authenticate API requests.
+type TokenSource interface {
+	Token(ctx context.Context) (string, error)
+}
+
+// TokenError is returned when a TokenSource fails to provide a token.
+type TokenError struct {
+	Err error
+}
+
+func (e *TokenError) Error() string {
+	return fmt.Sprintf("obtaining token: %v", e.Err)
+}
+
+func (e *TokenError) Unwrap() error {
+	return e.Err
+}
+
+// AppTokenSource authenticates as a GitHub App installation.
+// It signs a JWT with the App's private key, exchanges it for an installation
+// access token and refreshes the token before it expires.
+// It is safe for concurrent use.
+type AppTokenSource struct {
+	httpClient     *http.Client
+	baseURL        string
+	appID          int64
+	installationID int64
+	privateKey     *rsa.PrivateKey
+
+	mu        sync.Mutex
+	token     string
+	expiresAt time.Time
+}
+
+// installationTokenResponse is the response of the installation access token endpoint.
+type installationTokenResponse struct {
+	Token     string    `json:"token"`
+	ExpiresAt time.Time `json:"expires_at"`
+}
+
+// NewAppTokenSource creates a token source for a GitHub App installation.
+// privateKeyPEM is the App's private key in PKCS#1 or PKCS#8 PEM format.
+// An empty baseURL defaults to GitHubAPIBaseURL.
+func NewAppTokenSource(httpClient *http.Client, baseURL string, appID, installationID int64, privateKeyPEM []byte) (*AppTokenSource, error) {
+	key, err := ParseRSAPrivateKey(privateKeyPEM)
+	if err != nil {
+		return nil, err
+	}
+
+	if baseURL == "" {
+		baseURL = GitHubAPIBaseURL
+	}
+
+	return &AppTokenSource{
+		httpClient:     httpClient,
+		baseURL:        baseURL,
+		appID:          appID,
+		installationID: installationID,
+		privateKey:     key,
+	}, nil
+}
+
+// Token returns a valid installation access token, exchanging a new JWT
+// when the cached token is missing or about to expire.
+func (s *AppTokenSource) Token(ctx context.Context) (string, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.token != "" && time.Until(s.expiresAt) > InstallationTokenRefreshMargin {
+		return s.token, nil
+	}
+
+	jwt, err := s.signJWT(time.Now())
+	if err != nil {
+		return "", err
+	}
+
+	token, expiresAt, err := s.exchange(ctx, jwt)
+	if err != nil {
+		return "", err
+	}
+
+	s.token = token
+	s.expiresAt = expiresAt
+	return s.token, nil
+}
+
+// signJWT creates an RS256-signed JWT identifying the App.
+func (s *AppTokenSource) signJWT(now time.Time) (string, error) {
+	header := map[string]string{"alg": "RS256", "typ": "JWT"}
+	claims := map[string]any{
+		"iat": now.Add(-appJWTClockSkew).Unix(),
+		"exp": now.Add(appJWTLifetime).Unix(),
+		"iss": strconv.FormatInt(s.appID, 10),
+	}
+
+	headerJSON, err := json.Marshal(header)
+	if err != nil {
+		return "", fmt.Errorf("encoding JWT header: %w", err)
+	}
+	claimsJSON, err := json.Marshal(claims)
+	if err != nil {
+		return "", fmt.Errorf("encoding JWT claims: %w", err)
+	}
+
+	encoding := base64.RawURLEncoding
+	signingInput := encoding.EncodeToString(headerJSON) + "." + encoding.EncodeToString(claimsJSON)
+
+	digest := sha256.Sum256([]byte(signingInput))
+	signature, err := rsa.SignPKCS1v15(rand.Reader, s.privateKey, crypto.SHA256, digest[:])
+	if err != nil {
+		return "", fmt.Errorf("signing JWT: %w", err)
+	}
+
+	return signingInput + "." + encoding.EncodeToString(signature), nil
+}
+
+// exchange trades a JWT for an installation access token.
+func (s *AppTokenSource) exchange(ctx context.Context, jwt string) (string, time.Time, error) {
+	url := fmt.Sprintf("%s/app/installations/%d/access_tokens", s.baseURL, s.installationID)
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, nil)
+	if err != nil {
+		return "", time.Time{}, fmt.Errorf("creating request: %w", err)
+	}
+	req.Header.Set("Accept", "application/vnd.github+json")
+	req.Header.Set("X-GitHub-Api-Version", APIVersion)
+	req.Header.Set("Authorization", "Bearer "+jwt)
+
+	resp, err := s.httpClient.Do(req)
+	if err != nil {
+		return "", time.Time{}, fmt.Errorf("executing request: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusCreated && resp.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(resp.Body)
+		return "", time.Time{}, fmt.Errorf("creating installation token: %w", newAPIError(resp.StatusCode, body))
+	}
+
+	var result installationTokenResponse
+	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+		return "", time.Time{}, fmt.Errorf("decoding installation token: %w", err)
+	}
+	if result.Token == "" {
+		return "", time.Time{}, errors.New("installation token response did not include a token")
+	}
+
+	return result.Token, result.ExpiresAt, nil
+}
+
+// ParseRSAPrivateKey parses an RSA private key in PKCS#1 or PKCS#8 PEM format.
+func ParseRSAPrivateKey(pemData []byte) (*rsa.PrivateKey, error) {
+	block, _ := pem.Decode(pemData)
+	if block == nil {
+		return nil, errors.New("private key is not PEM encoded")
+	}
+
+	if key, err := x509.ParsePKCS1PrivateKey(block.Bytes); err == nil {
+		return key, nil
+	}
+
+	parsed, err := x509.ParsePKCS8PrivateKey(block.Bytes)
+	if err != nil {
+		return nil, fmt.Errorf("parsing private key: %w", err)
+	}
+
+	key, ok := parsed.(*rsa.PrivateKey)
+	if !ok {
+		return nil, errors.New("private key is not an RSA key")
+	}
+	return key, nil
+}
@@ -0,0 +1,238 @@
+package github
+
+import (
+	"context"
+	"crypto"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+func generateTestKey(t *testing.T) (*rsa.PrivateKey, []byte) {
+	t.Helper()
+
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatalf("generating key: %v", err)
+	}
+
+	pemData := pem.EncodeToMemory(&pem.Block{
+		Type:  "RSA PRIVATE KEY",
+		Bytes: x509.MarshalPKCS1PrivateKey(key),
+	})
+	return key, pemData
+}
+
+// verifyTestJWT checks the JWT signature and returns its claims.
+func verifyTestJWT(t *testing.T, jwt string, key *rsa.PublicKey) map[string]any {
+	t.Helper()
+
+	parts := strings.Split(jwt, ".")
+	if len(parts) != 3 {
+		t.Fatalf("JWT has %d parts, want 3", len(parts))
+	}
+
+	signature, err := base64.RawURLEncoding.DecodeString(parts[2])
+	if err != nil {
+		t.Fatalf("decoding signature: %v", err)
+	}
+	digest := sha256.Sum256([]byte(parts[0] + "." + parts[1]))
+	if err := rsa.VerifyPKCS1v15(key, crypto.SHA256, digest[:], signature); err != nil {
+		t.Fatalf("JWT signature invalid: %v", err)
+	}
+
+	claimsJSON, err := base64.RawURLEncoding.DecodeString(parts[1])
+	if err != nil {
+		t.Fatalf("decoding claims: %v", err)
+	}
+	var claims map[string]any
+	if err := json.Unmarshal(claimsJSON, &claims); err != nil {
+		t.Fatalf("parsing claims: %v", err)
+	}
+	return claims
+}
+
+// newInstallationServer emulates the installation access token endpoint.
+func newInstallationServer(t *testing.T, key *rsa.PublicKey, tokenLifetime time.Duration) (*httptest.Server, *int) {
+	t.Helper()
+
+	var mu sync.Mutex
+	exchanges := 0
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost || r.URL.Path != "/app/installations/99/access_tokens" {
+			http.NotFound(w, r)
+			return
+		}
+
+		jwt := strings.TrimPrefix(r.Header.Get("Authorization"), "Bearer ")
+		claims := verifyTestJWT(t, jwt, key)
+		if claims["iss"] != "12345" {
+			t.Errorf("iss claim = %v, want 12345", claims["iss"])
+		}
+
+		mu.Lock()
+		exchanges++
+		n := exchanges
+		mu.Unlock()
+
+		w.WriteHeader(http.StatusCreated)
+		json.NewEncoder(w).Encode(map[string]any{
+			"token":      "ghs_installation_" + string(rune('0'+n)),
+			"expires_at": time.Now().Add(tokenLifetime).UTC().Format(time.RFC3339),
+		})
+	}))
+
+	return server, &exchanges
+}
+
+func TestAppTokenSourceExchangesAndCaches(t *testing.T) {
+	key, pemData := generateTestKey(t)
+	server, exchanges := newInstallationServer(t, &key.PublicKey, time.Hour)
+	defer server.Close()
+
+	source, err := NewAppTokenSource(&http.Client{}, server.URL, 12345, 99, pemData)
+	if err != nil {
+		t.Fatalf("NewAppTokenSource() error: %v", err)
+	}
+
+	for i := 0; i < 3; i++ {
+		token, err := source.Token(context.Background())
+		if err != nil {
+			t.Fatalf("Token() error: %v", err)
+		}
+		if token != "ghs_installation_1" {
+			t.Errorf("Token() = %s, want ghs_installation_1", token)
+		}
+	}
+
+	if *exchanges != 1 {
+		t.Errorf("exchanges = %d, want 1 (token cached)", *exchanges)
+	}
+}
+
+func TestAppTokenSourceRefreshesBeforeExpiry(t *testing.T) {
+	key, pemData := generateTestKey(t)
+	// Tokens expiring inside the refresh margin are refreshed on every call
+	server, exchanges := newInstallationServer(t, &key.PublicKey, time.Minute)
+	defer server.Close()
+
+	source, err := NewAppTokenSource(&http.Client{}, server.URL, 12345, 99, pemData)
+	if err != nil {
+		t.Fatalf("NewAppTokenSource() error: %v", err)
+	}
+
+	first, _ := source.Token(context.Background())
+	second, err := source.Token(context.Background())
+	if err != nil {
+		t.Fatalf("Token() error: %v", err)
+	}
+
+	if first == second {
+		t.Errorf("Token() returned %s twice, want a refreshed token", first)
+	}
+	if *exchanges != 2 {
+		t.Errorf("exchanges = %d, want 2", *exchanges)
+	}
+}
+
+func TestAppTokenSourceExchangeError(t *testing.T) {
+	_, pemData := generateTestKey(t)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusUnauthorized)
+		w.Write([]byte(`{"message":"A JSON web token could not be decoded"}`))
+	}))
+	defer server.Close()
+
+	source, err := NewAppTokenSource(&http.Client{}, server.URL, 12345, 99, pemData)
+	if err != nil {
+		t.Fatalf("NewAppTokenSource() error: %v", err)
+	}
+
+	if _, err := source.Token(context.Background()); err == nil || !strings.Contains(err.Error(), "HTTP 401") {
+		t.Errorf("Token() error = %v, want HTTP 401 error", err)
+	}
+}
+
+func TestAPIClientWithTokenSource(t *testing.T) {
+	key, pemData := generateTestKey(t)
+	tokenServer, _ := newInstallationServer(t, &key.PublicKey, time.Hour)
+	defer tokenServer.Close()
+
+	var gotAuth string
+	apiServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotAuth = r.Header.Get("Authorization")
+		w.Write([]byte(`{"name":"repo"}`))
+	}))
+	defer apiServer.Close()
+
+	source, err := NewAppTokenSource(&http.Client{}, tokenServer.URL, 12345, 99, pemData)
+	if err != nil {
+		t.Fatalf("NewAppTokenSource() error: %v", err)
+	}
+
+	client := NewAPIClientWithTokenSource(&http.Client{}, source)
+	client.baseURL = apiServer.URL
+
+	if _, _, err := client.GetRepository(context.Background(), "owner", "repo"); err != nil {
+		t.Fatalf("GetRepository() error: %v", err)
+	}
+	if gotAuth != "Bearer ghs_installation_1" {
+		t.Errorf("Authorization = %q, want installation token", gotAuth)
+	}
+}
+
+func TestAPIClientTokenSourceError(t *testing.T) {
+	_, pemData := generateTestKey(t)
+	source, err := NewAppTokenSource(&http.Client{}, "http://127.0.0.1:0", 12345, 99, pemData)
+	if err != nil {
+		t.Fatalf("NewAppTokenSource() error: %v", err)
+	}
+
+	client := NewAPIClientWithTokenSource(&http.Client{}, source)
+	_, _, err = client.GetRepository(context.Background(), "owner", "repo")
+
+	if _, ok := err.(*TokenError); !ok {
+		t.Errorf("error = %T (%v), want *TokenError", err, err)
+	}
+}
+
+func TestParseRSAPrivateKey(t *testing.T) {
+	key, pkcs1 := generateTestKey(t)
+
+	pkcs8Bytes, err := x509.MarshalPKCS8PrivateKey(key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	pkcs8 := pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: pkcs8Bytes})
+
+	tests := []struct {
+		name    string
+		pem     []byte
+		wantErr bool
+	}{
+		{"PKCS#1", pkcs1, false},
+		{"PKCS#8", pkcs8, false},
+		{"not PEM", []byte("not a key"), true},
+		{"invalid key bytes", pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: []byte("junk")}), true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := ParseRSAPrivateKey(tt.pem)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("ParseRSAPrivateKey() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
@@ -17,6 +17,9 @@ type GithubAPI interface {
 	// GetRepository fetches information about a repository.
 	GetRepository(ctx context.Context, owner, repo string) (*Repository, *http.Response, error)
 
+	// ListOrgMembers lists one page of an organization's members.
+	ListOrgMembers(ctx context.Context, org string, page, perPage int) ([]User, *http.Response, error)
+
 	// GetRateLimit fetches the current rate limit status.
 	GetRateLimit(ctx context.Context) (*RateLimitResponse, error)
 }
@@ -6,12 +6,16 @@ import (
 	"fmt"
 	"net/http"
 	"path/filepath"
+	"strconv"
 )
 
 // MockAPIClient is a mock GitHub API client that reads from local JSON files.
 // Used for testing and development without hitting the real GitHub API.
+// When created from a Scenario it serves the scenario's data and injected
+// failures instead of the built-in dataset.
 type MockAPIClient struct {
 	mockDataDir string
+	scenario    *scenarioState
 }
 
 // NewMockAPIClient creates a new mock API client.
@@ -25,8 +29,23 @@ func NewMockAPIClient() *MockAPIClient {
 	}
 }
 
+// NewScenarioMockAPIClient creates a mock API client that serves the given
+// scenario. It is safe for concurrent use.
+func NewScenarioMockAPIClient(scenario *Scenario) *MockAPIClient {
+	return &MockAPIClient{
+		scenario: newScenarioState(scenario),
+	}
+}
+
 // SearchIssues returns mock search results for merged PRs.
 func (c *MockAPIClient) SearchIssues(ctx context.Context, query string, page, perPage int) (*SearchIssuesResponse, *http.Response, error) {
+	if c.scenario != nil {
+		if resp, err := c.scenario.intercept(ctx, CallSearchIssues, strconv.Itoa(page)); err != nil {
+			return nil, resp, err
+		}
+		return c.scenario.searchIssues(query, page, perPage), mockResponse(), nil
+	}
+
 	var result SearchIssuesResponse
 
 	if err := json.Unmarshal([]byte(mergedPrs), &result); err != nil {
@@ -46,6 +65,18 @@ func (c *MockAPIClient) SearchIssues(ctx context.Context, query string, page, pe
 
 // GetPullRequest returns mock PR details.
 func (c *MockAPIClient) GetPullRequest(ctx context.Context, owner, repo string, number int) (*PullRequest, *http.Response, error) {
+	if c.scenario != nil {
+		if resp, err := c.scenario.intercept(ctx, CallGetPullRequest, fmt.Sprintf("%s/%s#%d", owner, repo, number)); err != nil {
+			return nil, resp, err
+		}
+		pr, ok := c.scenario.pullRequest(owner, repo, number)
+		if !ok {
+			resp, err := notFound()
+			return nil, resp, err
+		}
+		return pr, mockResponse(), nil
+	}
+
 	jsonData := `
 {
   "number": 20,
@@ -87,6 +118,18 @@ func (c *MockAPIClient) GetPullRequest(ctx context.Context, owner, repo string,
 
 // GetRepository returns mock repository information.
 func (c *MockAPIClient) GetRepository(ctx context.Context, owner, repo string) (*Repository, *http.Response, error) {
+	if c.scenario != nil {
+		if resp, err := c.scenario.intercept(ctx, CallGetRepository, owner+"/"+repo); err != nil {
+			return nil, resp, err
+		}
+		repository, ok := c.scenario.repository(owner, repo)
+		if !ok {
+			resp, err := notFound()
+			return nil, resp, err
+		}
+		return repository, mockResponse(), nil
+	}
+
 	jsonData := `
 {
   "name": "android-public",
@@ -126,8 +169,35 @@ func (c *MockAPIClient) GetRepository(ctx context.Context, owner, repo string) (
 	return &result, mockResp, nil
 }
 
+// ListOrgMembers returns mock organization members.
+func (c *MockAPIClient) ListOrgMembers(ctx context.Context, org string, page, perPage int) ([]User, *http.Response, error) {
+	if c.scenario != nil {
+		if resp, err := c.scenario.intercept(ctx, CallListOrgMembers, org); err != nil {
+			return nil, resp, err
+		}
+		members, ok := c.scenario.orgMembers(org, page, perPage)
+		if !ok {
+			resp, err := notFound()
+			return nil, resp, err
+		}
+		return members, mockResponse(), nil
+	}
+
+	members := []User{}
+	if page == 1 {
+		members = append(members, User{Login: "mabd-dev", ID: 133316956, Type: "User"})
+	}
+	return members, mockResponse(), nil
+}
+
 // GetRateLimit returns mock rate limit information.
 func (c *MockAPIClient) GetRateLimit(ctx context.Context) (*RateLimitResponse, error) {
+	if c.scenario != nil {
+		if _, err := c.scenario.intercept(ctx, CallGetRateLimit, ""); err != nil {
+			return nil, err
+		}
+	}
+
 	return &RateLimitResponse{
 		Resources: RateLimitResources{
 			Core: RateLimit{
@@ -2,10 +2,12 @@ package github
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"math"
 	"net/http"
 	"strconv"
+	"strings"
 	"time"
 )
 
@@ -16,6 +18,12 @@ const (
 	// RateLimitResetHeader is the header containing the rate limit reset time
 	RateLimitResetHeader = "X-RateLimit-Reset"
 
+	// RetryAfterHeader is the header containing the seconds to wait before retrying
+	RetryAfterHeader = "Retry-After"
+
+	// SSOHeader is set when a token must be authorized for a SAML SSO organization
+	SSOHeader = "X-GitHub-SSO"
+
 	// SearchAPIDelay is the delay between search API calls (30 requests/minute)
 	SearchAPIDelay = 2 * time.Second
 
@@ -57,10 +65,107 @@ func ParseRateLimitHeaders(headers http.Header) (*RateLimitInfo, error) {
 	}, nil
 }
 
+// ResponseClass describes why GitHub rejected a request.
+type ResponseClass int
+
+const (
+	// ClassNone means the response is not a rate limit or permission failure
+	ClassNone ResponseClass = iota
+	// ClassPrimaryRateLimit means the hourly request quota is exhausted
+	ClassPrimaryRateLimit
+	// ClassSecondaryRateLimit means too many requests were made in a short period
+	ClassSecondaryRateLimit
+	// ClassSSORequired means the token must be authorized for a SAML SSO organization
+	ClassSSORequired
+	// ClassForbidden means the token is not allowed to access the resource
+	ClassForbidden
+)
+
+func (rc ResponseClass) String() string {
+	switch rc {
+	case ClassPrimaryRateLimit:
+		return "primary rate limit"
+	case ClassSecondaryRateLimit:
+		return "secondary rate limit"
+	case ClassSSORequired:
+		return "SSO required"
+	case ClassForbidden:
+		return "forbidden"
+	}
+	return "none"
+}
+
+// ClassifyResponse classifies a 403 or 429 response using its headers and,
+// when err is an *APIError, the documented error messages in its body.
+func ClassifyResponse(resp *http.Response, err error) ResponseClass {
+	if resp == nil {
+		return ClassNone
+	}
+	if resp.StatusCode != http.StatusForbidden && resp.StatusCode != http.StatusTooManyRequests {
+		return ClassNone
+	}
+
+	var message string
+	var apiErr *APIError
+	if errors.As(err, &apiErr) {
+		message = strings.ToLower(apiErr.Message)
+	}
+
+	switch {
+	case resp.Header.Get(RetryAfterHeader) != "",
+		strings.Contains(message, "secondary rate limit"),
+		strings.Contains(message, "abuse"):
+		return ClassSecondaryRateLimit
+	case resp.Header.Get(RateLimitRemainingHeader) == "0",
+		strings.Contains(message, "rate limit"):
+		return ClassPrimaryRateLimit
+	case resp.StatusCode == http.StatusTooManyRequests:
+		// 429 without a depleted quota is a secondary rate limit
+		return ClassSecondaryRateLimit
+	case resp.Header.Get(SSOHeader) != "",
+		strings.Contains(message, "saml"):
+		return ClassSSORequired
+	}
+
+	return ClassForbidden
+}
+
 // IsRateLimited checks if a response indicates rate limiting.
+// A 403 is only treated as rate limiting when the headers say so; other 403s
+// are permission failures (see ClassifyResponse).
 func IsRateLimited(resp *http.Response) bool {
-	return resp.StatusCode == http.StatusTooManyRequests || // 429
-		resp.StatusCode == http.StatusForbidden // 403 can also indicate rate limiting
+	switch ClassifyResponse(resp, nil) {
+	case ClassPrimaryRateLimit, ClassSecondaryRateLimit:
+		return true
+	}
+	return false
+}
+
+// ParseRetryAfter returns the delay requested by the Retry-After header.
+func ParseRetryAfter(headers http.Header) (time.Duration, bool) {
+	value := headers.Get(RetryAfterHeader)
+	if value == "" {
+		return 0, false
+	}
+
+	seconds, err := strconv.Atoi(value)
+	if err != nil || seconds < 0 {
+		return 0, false
+	}
+
+	return time.Duration(seconds) * time.Second, true
+}
+
+// ParseSSOURL extracts the authorization URL from the X-GitHub-SSO header,
+// which looks like "required; url=https://github.com/orgs/...".
+func ParseSSOURL(headers http.Header) string {
+	for _, part := range strings.Split(headers.Get(SSOHeader), ";") {
+		part = strings.TrimSpace(part)
+		if url, ok := strings.CutPrefix(part, "url="); ok {
+			return url
+		}
+	}
+	return ""
 }
 
 // HandleRateLimit implements exponential backoff for rate-limited requests.
@@ -70,6 +175,16 @@ func HandleRateLimit(ctx context.Context, resp *http.Response, attempt int) erro
 		return fmt.Errorf("max retry attempts (%d) reached for rate limiting", MaxBackoffAttempts)
 	}
 
+	// Secondary rate limits tell us exactly how long to wait
+	if retryAfter, ok := ParseRetryAfter(resp.Header); ok {
+		select {
+		case <-time.After(retryAfter):
+			return nil
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+
 	// Try to get rate limit info from headers
 	info, err := ParseRateLimitHeaders(resp.Header)
 	if err == nil && info.Remaining == 0 {
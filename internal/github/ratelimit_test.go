@@ -115,6 +115,7 @@ func TestIsRateLimited(t *testing.T) {
 	tests := []struct {
 		name       string
 		statusCode int
+		headers    map[string]string
 		want       bool
 	}{
 		{
@@ -123,8 +124,20 @@ func TestIsRateLimited(t *testing.T) {
 			want:       true,
 		},
 		{
-			name:       "403 Forbidden",
+			name:       "403 Forbidden without rate limit headers",
 			statusCode: http.StatusForbidden,
+			want:       false,
+		},
+		{
+			name:       "403 Forbidden with exhausted quota",
+			statusCode: http.StatusForbidden,
+			headers:    map[string]string{RateLimitRemainingHeader: "0"},
+			want:       true,
+		},
+		{
+			name:       "403 Forbidden with Retry-After",
+			statusCode: http.StatusForbidden,
+			headers:    map[string]string{RetryAfterHeader: "60"},
 			want:       true,
 		},
 		{
@@ -148,6 +161,10 @@ func TestIsRateLimited(t *testing.T) {
 		t.Run(tt.name, func(t *testing.T) {
 			resp := &http.Response{
 				StatusCode: tt.statusCode,
+				Header:     make(http.Header),
+			}
+			for k, v := range tt.headers {
+				resp.Header.Set(k, v)
 			}
 
 			got := IsRateLimited(resp)
@@ -158,6 +175,160 @@ func TestIsRateLimited(t *testing.T) {
 	}
 }
 
+func TestIsRateLimitedNilResponse(t *testing.T) {
+	if IsRateLimited(nil) {
+		t.Error("IsRateLimited(nil) should return false")
+	}
+}
+
+func TestClassifyResponse(t *testing.T) {
+	tests := []struct {
+		name       string
+		statusCode int
+		headers    map[string]string
+		message    string
+		want       ResponseClass
+	}{
+		{
+			name:       "primary limit from headers",
+			statusCode: http.StatusForbidden,
+			headers:    map[string]string{RateLimitRemainingHeader: "0", RateLimitResetHeader: "1700000000"},
+			message:    "API rate limit exceeded for user ID 1.",
+			want:       ClassPrimaryRateLimit,
+		},
+		{
+			name:       "primary limit from message",
+			statusCode: http.StatusForbidden,
+			message:    "API rate limit exceeded for 1.2.3.4.",
+			want:       ClassPrimaryRateLimit,
+		},
+		{
+			name:       "secondary limit with Retry-After",
+			statusCode: http.StatusForbidden,
+			headers:    map[string]string{RetryAfterHeader: "60", RateLimitRemainingHeader: "4000"},
+			want:       ClassSecondaryRateLimit,
+		},
+		{
+			name:       "secondary limit from message",
+			statusCode: http.StatusForbidden,
+			headers:    map[string]string{RateLimitRemainingHeader: "4000"},
+			message:    "You have exceeded a secondary rate limit. Please wait a few minutes before you try again.",
+			want:       ClassSecondaryRateLimit,
+		},
+		{
+			name:       "429 without exhausted quota",
+			statusCode: http.StatusTooManyRequests,
+			want:       ClassSecondaryRateLimit,
+		},
+		{
+			name:       "429 with exhausted quota",
+			statusCode: http.StatusTooManyRequests,
+			headers:    map[string]string{RateLimitRemainingHeader: "0"},
+			want:       ClassPrimaryRateLimit,
+		},
+		{
+			name:       "SAML SSO from header",
+			statusCode: http.StatusForbidden,
+			headers:    map[string]string{SSOHeader: "required; url=https://github.com/orgs/acme/sso?authorization_request=abc"},
+			want:       ClassSSORequired,
+		},
+		{
+			name:       "SAML SSO from message",
+			statusCode: http.StatusForbidden,
+			message:    "Resource protected by organization SAML enforcement. You must grant your Personal Access token access to this organization.",
+			want:       ClassSSORequired,
+		},
+		{
+			name:       "plain forbidden",
+			statusCode: http.StatusForbidden,
+			headers:    map[string]string{RateLimitRemainingHeader: "4999"},
+			message:    "Resource not accessible by personal access token",
+			want:       ClassForbidden,
+		},
+		{
+			name:       "not found",
+			statusCode: http.StatusNotFound,
+			message:    "Not Found",
+			want:       ClassNone,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			resp := &http.Response{
+				StatusCode: tt.statusCode,
+				Header:     make(http.Header),
+			}
+			for k, v := range tt.headers {
+				resp.Header.Set(k, v)
+			}
+			err := &APIError{StatusCode: tt.statusCode, Message: tt.message}
+
+			if got := ClassifyResponse(resp, err); got != tt.want {
+				t.Errorf("ClassifyResponse() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseRetryAfter(t *testing.T) {
+	tests := []struct {
+		value  string
+		want   time.Duration
+		wantOK bool
+	}{
+		{"60", 60 * time.Second, true},
+		{"0", 0, true},
+		{"", 0, false},
+		{"soon", 0, false},
+		{"-1", 0, false},
+	}
+
+	for _, tt := range tests {
+		headers := make(http.Header)
+		if tt.value != "" {
+			headers.Set(RetryAfterHeader, tt.value)
+		}
+
+		got, ok := ParseRetryAfter(headers)
+		if got != tt.want || ok != tt.wantOK {
+			t.Errorf("ParseRetryAfter(%q) = (%v, %v), want (%v, %v)", tt.value, got, ok, tt.want, tt.wantOK)
+		}
+	}
+}
+
+func TestParseSSOURL(t *testing.T) {
+	headers := make(http.Header)
+	headers.Set(SSOHeader, "required; url=https://github.com/orgs/acme/sso?authorization_request=abc")
+
+	want := "https://github.com/orgs/acme/sso?authorization_request=abc"
+	if got := ParseSSOURL(headers); got != want {
+		t.Errorf("ParseSSOURL() = %q, want %q", got, want)
+	}
+
+	if got := ParseSSOURL(make(http.Header)); got != "" {
+		t.Errorf("ParseSSOURL() without header = %q, want empty", got)
+	}
+}
+
+func TestHandleRateLimitRetryAfter(t *testing.T) {
+	resp := &http.Response{
+		StatusCode: http.StatusForbidden,
+		Header:     make(http.Header),
+	}
+	resp.Header.Set(RetryAfterHeader, "0")
+
+	start := time.Now()
+	if err := HandleRateLimit(context.Background(), resp, 0); err != nil {
+		t.Fatalf("HandleRateLimit() error: %v", err)
+	}
+
+	// Retry-After of 0 should not fall back to the 1s exponential backoff
+	if elapsed := time.Since(start); elapsed > 500*time.Millisecond {
+		t.Errorf("HandleRateLimit() waited %v, want Retry-After delay", elapsed)
+	}
+}
+
 func TestCalculateBackoff(t *testing.T) {
 	tests := []struct {
 		name    string
@@ -421,9 +592,9 @@ func TestShouldRetry(t *testing.T) {
 			want:       true,
 		},
 		{
-			name:       "403 - forbidden (rate limit)",
+			name:       "403 - forbidden (permission)",
 			statusCode: http.StatusForbidden,
-			want:       true,
+			want:       false,
 		},
 		{
 			name:       "500 - internal server error",
@@ -471,6 +642,7 @@ func TestShouldRetry(t *testing.T) {
 		t.Run(tt.name, func(t *testing.T) {
 			resp := &http.Response{
 				StatusCode: tt.statusCode,
+				Header:     make(http.Header),
 			}
 
 			got := ShouldRetry(resp)
@@ -0,0 +1,256 @@
+package github
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+	"sync"
+)
+
+// redacted replaces secrets in recordings.
+const redacted = "REDACTED"
+
+// tokenPattern matches GitHub token formats (classic, OAuth, App and
+// fine-grained tokens) so they are scrubbed even when not passed explicitly.
+var tokenPattern = regexp.MustCompile(`\b(gh[pousr]_[A-Za-z0-9]{20,}|github_pat_[A-Za-z0-9_]{20,})\b`)
+
+// scrubbedHeaders are response headers never written to recordings.
+var scrubbedHeaders = []string{"Set-Cookie", "Authorization"}
+
+// Exchange is one recorded HTTP request and its response.
+// Request headers are not recorded, so the Authorization token never reaches disk.
+type Exchange struct {
+	Seq        int         `json:"seq"`
+	Method     string      `json:"method"`
+	URL        string      `json:"url"` // Request path and query, e.g. "/repos/owner/repo"
+	StatusCode int         `json:"statusCode"`
+	Header     http.Header `json:"header"`
+	Body       string      `json:"body"`
+}
+
+// RecordingTransport is an http.RoundTripper that writes every request and
+// response it handles to a directory, one JSON file per exchange.
+// Tokens are scrubbed from the recorded headers and bodies.
+// It is safe for concurrent use.
+type RecordingTransport struct {
+	base    http.RoundTripper
+	dir     string
+	secrets []string
+
+	mu  sync.Mutex
+	seq int
+}
+
+// NewRecordingTransport creates a transport that records the exchanges made
+// through base into dir, creating the directory if needed. A nil base uses
+// http.DefaultTransport. secrets are additional values to scrub from the
+// recordings, on top of anything that looks like a GitHub token.
+// Recording into a directory that already holds exchanges appends to them.
+func NewRecordingTransport(dir string, base http.RoundTripper, secrets ...string) (*RecordingTransport, error) {
+	if base == nil {
+		base = http.DefaultTransport
+	}
+
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return nil, fmt.Errorf("creating recording directory: %w", err)
+	}
+
+	existing, err := filepath.Glob(filepath.Join(dir, "*.json"))
+	if err != nil {
+		return nil, fmt.Errorf("listing recordings: %w", err)
+	}
+
+	var nonEmpty []string
+	for _, secret := range secrets {
+		if secret = strings.TrimSpace(secret); secret != "" {
+			nonEmpty = append(nonEmpty, secret)
+		}
+	}
+
+	return &RecordingTransport{
+		base:    base,
+		dir:     dir,
+		secrets: nonEmpty,
+		seq:     len(existing),
+	}, nil
+}
+
+// RoundTrip performs the request with the underlying transport and records
+// the exchange. Requests that fail without a response are not recorded.
+func (t *RecordingTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	resp, err := t.base.RoundTrip(req)
+	if err != nil {
+		return nil, err
+	}
+
+	body, err := io.ReadAll(resp.Body)
+	resp.Body.Close()
+	if err != nil {
+		return nil, fmt.Errorf("reading response for recording: %w", err)
+	}
+	resp.Body = io.NopCloser(bytes.NewReader(body))
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	t.seq++
+	exchange := Exchange{
+		Seq:        t.seq,
+		Method:     req.Method,
+		URL:        t.scrub(req.URL.RequestURI()),
+		StatusCode: resp.StatusCode,
+		Header:     t.scrubHeader(resp.Header),
+		Body:       t.scrub(string(body)),
+	}
+
+	data, err := json.MarshalIndent(exchange, "", "  ")
+	if err != nil {
+		return nil, fmt.Errorf("encoding recording: %w", err)
+	}
+
+	path := filepath.Join(t.dir, fmt.Sprintf("%04d.json", exchange.Seq))
+	if err := os.WriteFile(path, data, 0o644); err != nil {
+		return nil, fmt.Errorf("writing recording: %w", err)
+	}
+
+	return resp, nil
+}
+
+// scrub replaces configured secrets and token-like strings in s.
+func (t *RecordingTransport) scrub(s string) string {
+	for _, secret := range t.secrets {
+		s = strings.ReplaceAll(s, secret, redacted)
+	}
+	return tokenPattern.ReplaceAllString(s, redacted)
+}
+
+// scrubHeader returns a copy of header without sensitive headers and with
+// secrets scrubbed from the remaining values.
+func (t *RecordingTransport) scrubHeader(header http.Header) http.Header {
+	clean := header.Clone()
+	for _, name := range scrubbedHeaders {
+		clean.Del(name)
+	}
+	for name, values := range clean {
+		for i, value := range values {
+			clean[name][i] = t.scrub(value)
+		}
+	}
+	return clean
+}
+
+// ReplayTransport is an http.RoundTripper that serves responses recorded by
+// RecordingTransport without touching the network. Requests are matched by
+// method, path and query. When the same request was recorded several times,
+// e.g. a rate limited call followed by its retry, the responses are served
+// in recorded order and the last one is repeated.
+// It is safe for concurrent use.
+type ReplayTransport struct {
+	mu        sync.Mutex
+	exchanges map[string][]*Exchange
+	served    map[string]int
+}
+
+// NewReplayTransport loads the exchanges recorded in dir.
+func NewReplayTransport(dir string) (*ReplayTransport, error) {
+	files, err := filepath.Glob(filepath.Join(dir, "*.json"))
+	if err != nil {
+		return nil, fmt.Errorf("listing recordings: %w", err)
+	}
+	if len(files) == 0 {
+		return nil, fmt.Errorf("no recordings found in %s", dir)
+	}
+
+	var all []*Exchange
+	for _, file := range files {
+		data, err := os.ReadFile(file)
+		if err != nil {
+			return nil, fmt.Errorf("reading recording: %w", err)
+		}
+
+		var exchange Exchange
+		if err := json.Unmarshal(data, &exchange); err != nil {
+			return nil, fmt.Errorf("decoding recording %s: %w", filepath.Base(file), err)
+		}
+		all = append(all, &exchange)
+	}
+
+	sort.SliceStable(all, func(i, j int) bool {
+		return all[i].Seq < all[j].Seq
+	})
+
+	t := &ReplayTransport{
+		exchanges: make(map[string][]*Exchange),
+		served:    make(map[string]int),
+	}
+	for _, exchange := range all {
+		key := replayKey(exchange.Method, exchange.URL)
+		t.exchanges[key] = append(t.exchanges[key], exchange)
+	}
+
+	return t, nil
+}
+
+// RoundTrip returns the recorded response for the request.
+func (t *ReplayTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	key := replayKey(req.Method, req.URL.RequestURI())
+
+	t.mu.Lock()
+	recorded := t.exchanges[key]
+	if len(recorded) == 0 {
+		t.mu.Unlock()
+		return nil, fmt.Errorf("no recorded response for %s %s", req.Method, req.URL.RequestURI())
+	}
+	i := t.served[key]
+	if i >= len(recorded) {
+		i = len(recorded) - 1
+	}
+	t.served[key]++
+	exchange := recorded[i]
+	t.mu.Unlock()
+
+	return &http.Response{
+		Status:        fmt.Sprintf("%d %s", exchange.StatusCode, http.StatusText(exchange.StatusCode)),
+		StatusCode:    exchange.StatusCode,
+		Proto:         "HTTP/1.1",
+		ProtoMajor:    1,
+		ProtoMinor:    1,
+		Header:        exchange.Header.Clone(),
+		Body:          io.NopCloser(strings.NewReader(exchange.Body)),
+		ContentLength: int64(len(exchange.Body)),
+		Request:       req,
+	}, nil
+}
+
+// NewReplayAPIClient creates an API client that serves the responses
+// recorded in dir instead of calling GitHub.
+func NewReplayAPIClient(dir string) (*APIClient, error) {
+	transport, err := NewReplayTransport(dir)
+	if err != nil {
+		return nil, err
+	}
+	return NewAPIClient(&http.Client{Transport: transport}, ""), nil
+}
+
+// replayKey identifies a request by method, path and query. Query
+// parameters are sorted so equivalent URLs match.
+func replayKey(method, requestURI string) string {
+	path, rawQuery, _ := strings.Cut(requestURI, "?")
+	if rawQuery != "" {
+		if query, err := url.ParseQuery(rawQuery); err == nil {
+			rawQuery = query.Encode()
+		}
+	}
+	if rawQuery == "" {
+		return method + " " + path
+	}
+	return method + " " + path + "?" + rawQuery
+}
@@ -0,0 +1,405 @@
+package github
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"maps"
+	"net/http"
+	"os"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Mock API calls that scenario failures can target.
+const (
+	CallSearchIssues   = "SearchIssues"
+	CallGetPullRequest = "GetPullRequest"
+	CallGetRepository  = "GetRepository"
+	CallGetRateLimit   = "GetRateLimit"
+	CallListOrgMembers = "ListOrgMembers"
+)
+
+// Scenario describes the data and failures served by a MockAPIClient.
+// Scenarios are loaded from JSON fixture files with LoadScenario, e.g.:
+//
+//	{
+//	  "latency": "50ms",
+//	  "users": {
+//	    "octocat": [
+//	      {"repo": "golang/go", "number": 1, "title": "Fix typo",
+//	       "merged_at": "2025-01-02T03:04:05Z", "additions": 3, "deletions": 1}
+//	    ]
+//	  },
+//	  "repositories": [{"full_name": "golang/go", "stargazers_count": 120000}],
+//	  "failures": [{"call": "GetRepository", "target": "golang/go", "status": 500, "times": 1}]
+//	}
+type Scenario struct {
+	// Latency is added to every call
+	Latency Duration `json:"latency"`
+
+	// IncompleteResults marks every search response as incomplete
+	IncompleteResults bool `json:"incompleteResults"`
+
+	// Users maps a login to the merged PRs it authored
+	Users map[string][]ScenarioPullRequest `json:"users"`
+
+	// Repositories are returned by GetRepository, matched by full_name
+	Repositories []Repository `json:"repositories"`
+
+	// Orgs maps an organization login to its members' logins
+	Orgs map[string][]string `json:"orgs"`
+
+	// Failures are injected into matching calls, in order
+	Failures []ScenarioFailure `json:"failures"`
+}
+
+// ScenarioPullRequest is a merged PR in a scenario.
+// The PullRequest fields are inlined in JSON next to "repo".
+type ScenarioPullRequest struct {
+	Repo string `json:"repo"` // "owner/name"
+	PullRequest
+}
+
+// ScenarioFailure injects an error response or extra latency into matching
+// mock calls.
+type ScenarioFailure struct {
+	// Call is the API call to fail: SearchIssues, GetPullRequest,
+	// GetRepository, GetRateLimit or ListOrgMembers. Empty matches every call.
+	Call string `json:"call"`
+
+	// Target narrows the failure to one resource: "owner/repo" for
+	// GetRepository, "owner/repo#number" for GetPullRequest, the page
+	// number for SearchIssues or the organization for ListOrgMembers.
+	// Empty matches every target.
+	Target string `json:"target"`
+
+	// Status is the HTTP status to return, e.g. 403, 429 or 500.
+	// Zero only applies Latency and lets the call succeed.
+	Status int `json:"status"`
+
+	// Message is the "message" field of the JSON error body
+	Message string `json:"message"`
+
+	// Headers are added to the error response, e.g.
+	// {"X-RateLimit-Remaining": "0"} or {"Retry-After": "1"}
+	Headers map[string]string `json:"headers"`
+
+	// Latency is added to matching calls on top of the scenario latency
+	Latency Duration `json:"latency"`
+
+	// Times limits how many calls the failure applies to (0 = every call)
+	Times int `json:"times"`
+}
+
+// Duration is a time.Duration read from JSON as a string such as "250ms".
+type Duration time.Duration
+
+// UnmarshalJSON parses a duration string, or a number of milliseconds.
+func (d *Duration) UnmarshalJSON(data []byte) error {
+	var value any
+	if err := json.Unmarshal(data, &value); err != nil {
+		return err
+	}
+
+	switch v := value.(type) {
+	case string:
+		parsed, err := time.ParseDuration(v)
+		if err != nil {
+			return fmt.Errorf("invalid duration %q: %w", v, err)
+		}
+		*d = Duration(parsed)
+	case float64:
+		*d = Duration(time.Duration(v) * time.Millisecond)
+	case nil:
+		*d = 0
+	default:
+		return fmt.Errorf("invalid duration %s", string(data))
+	}
+	return nil
+}
+
+// MarshalJSON writes the duration as a string such as "250ms".
+func (d Duration) MarshalJSON() ([]byte, error) {
+	return json.Marshal(time.Duration(d).String())
+}
+
+// LoadScenario reads a scenario from a JSON fixture file.
+func LoadScenario(path string) (*Scenario, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("reading scenario: %w", err)
+	}
+
+	var scenario Scenario
+	if err := json.Unmarshal(data, &scenario); err != nil {
+		return nil, fmt.Errorf("decoding scenario %s: %w", path, err)
+	}
+
+	if err := scenario.validate(); err != nil {
+		return nil, fmt.Errorf("invalid scenario %s: %w", path, err)
+	}
+
+	return &scenario, nil
+}
+
+// validate checks the scenario for mistakes that would otherwise only show
+// up as confusing mock results.
+func (s *Scenario) validate() error {
+	for login, prs := range s.Users {
+		for _, pr := range prs {
+			if _, _, ok := strings.Cut(pr.Repo, "/"); !ok {
+				return fmt.Errorf("user %s: repo %q must be in owner/name form", login, pr.Repo)
+			}
+		}
+	}
+
+	for i, failure := range s.Failures {
+		switch failure.Call {
+		case "", CallSearchIssues, CallGetPullRequest, CallGetRepository, CallGetRateLimit, CallListOrgMembers:
+		default:
+			return fmt.Errorf("failure %d: unknown call %q", i, failure.Call)
+		}
+		if failure.Status != 0 && (failure.Status < 400 || failure.Status > 599) {
+			return fmt.Errorf("failure %d: status %d is not an HTTP error status", i, failure.Status)
+		}
+	}
+
+	return nil
+}
+
+// scenarioState is the per-client runtime state of a scenario.
+type scenarioState struct {
+	scenario *Scenario
+
+	mu    sync.Mutex
+	calls []int // Calls matched so far, per failure
+}
+
+// newScenarioState prepares a scenario for serving.
+func newScenarioState(scenario *Scenario) *scenarioState {
+	return &scenarioState{
+		scenario: scenario,
+		calls:    make([]int, len(scenario.Failures)),
+	}
+}
+
+// intercept applies the scenario latency and the first matching failure to a
+// call. It returns a non-nil response and error when the call must fail.
+func (s *scenarioState) intercept(ctx context.Context, call, target string) (*http.Response, error) {
+	delay := time.Duration(s.scenario.Latency)
+	var failure *ScenarioFailure
+
+	s.mu.Lock()
+	for i := range s.scenario.Failures {
+		f := &s.scenario.Failures[i]
+		if (f.Call != "" && f.Call != call) || (f.Target != "" && f.Target != target) {
+			continue
+		}
+		if f.Times > 0 && s.calls[i] >= f.Times {
+			continue
+		}
+		s.calls[i]++
+		delay += time.Duration(f.Latency)
+		if f.Status != 0 {
+			failure = f
+			break
+		}
+	}
+	s.mu.Unlock()
+
+	if delay > 0 {
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		case <-time.After(delay):
+		}
+	}
+
+	if failure == nil {
+		return nil, nil
+	}
+
+	message := failure.Message
+	if message == "" {
+		message = http.StatusText(failure.Status)
+	}
+	body, _ := json.Marshal(map[string]string{"message": message})
+
+	resp := mockResponse()
+	resp.StatusCode = failure.Status
+	for name, value := range failure.Headers {
+		resp.Header.Set(name, value)
+	}
+
+	return resp, newAPIError(failure.Status, body)
+}
+
+// searchIssues returns one page of the merged PRs authored by the user named
+// in an "author:<login>" query, honoring "-user:" and "-org:" exclusions.
+// A "repo:<owner>/<name>" query returns the merged PRs of every user to that
+// repository instead.
+func (s *scenarioState) searchIssues(query string, page, perPage int) *SearchIssuesResponse {
+	var author, repo string
+	excluded := make(map[string]bool)
+	for _, term := range strings.Fields(query) {
+		switch {
+		case strings.HasPrefix(term, "author:"):
+			author = strings.TrimPrefix(term, "author:")
+		case strings.HasPrefix(term, "repo:"):
+			repo = strings.TrimPrefix(term, "repo:")
+		case strings.HasPrefix(term, "-user:"):
+			excluded[strings.ToLower(strings.TrimPrefix(term, "-user:"))] = true
+		case strings.HasPrefix(term, "-org:"):
+			excluded[strings.ToLower(strings.TrimPrefix(term, "-org:"))] = true
+		}
+	}
+
+	var matches []Issue
+	if repo != "" {
+		logins := slices.Sorted(maps.Keys(s.scenario.Users))
+		for _, login := range logins {
+			for _, pr := range s.scenario.Users[login] {
+				if strings.EqualFold(pr.Repo, repo) {
+					matches = append(matches, pr.issue(login, s.authorAssociation(login, pr.Repo)))
+				}
+			}
+		}
+	}
+	for _, pr := range s.scenario.Users[author] {
+		owner, _, _ := strings.Cut(pr.Repo, "/")
+		if excluded[strings.ToLower(owner)] {
+			continue
+		}
+		matches = append(matches, pr.issue(author, s.authorAssociation(author, pr.Repo)))
+	}
+
+	result := &SearchIssuesResponse{
+		TotalCount:        len(matches),
+		IncompleteResults: s.scenario.IncompleteResults,
+		Items:             []Issue{},
+	}
+
+	start := (page - 1) * perPage
+	if start < 0 || start >= len(matches) {
+		return result
+	}
+	end := min(start+perPage, len(matches))
+	result.Items = matches[start:end]
+
+	return result
+}
+
+// pullRequest finds a PR by repository and number.
+func (s *scenarioState) pullRequest(owner, repo string, number int) (*PullRequest, bool) {
+	fullName := owner + "/" + repo
+	for login, prs := range s.scenario.Users {
+		for _, pr := range prs {
+			if strings.EqualFold(pr.Repo, fullName) && pr.Number == number {
+				result := pr.PullRequest
+				if result.User.Login == "" {
+					result.User.Login = login
+				}
+				if result.MergedAt != nil {
+					result.Merged = true
+				}
+				return &result, true
+			}
+		}
+	}
+	return nil, false
+}
+
+// repository finds a repository by full name.
+func (s *scenarioState) repository(owner, repo string) (*Repository, bool) {
+	fullName := owner + "/" + repo
+	for _, r := range s.scenario.Repositories {
+		if strings.EqualFold(r.FullName, fullName) {
+			result := r
+			if result.HTMLURL == "" {
+				result.HTMLURL = "https://github.com/" + r.FullName
+			}
+			return &result, true
+		}
+	}
+	return nil, false
+}
+
+// orgMembers returns one page of an organization's members, matched
+// case-insensitively.
+func (s *scenarioState) orgMembers(org string, page, perPage int) ([]User, bool) {
+	for name, logins := range s.scenario.Orgs {
+		if !strings.EqualFold(name, org) {
+			continue
+		}
+		members := []User{}
+		start := (page - 1) * perPage
+		for i := start; i < len(logins) && i < start+perPage; i++ {
+			members = append(members, User{Login: logins[i], Type: "User"})
+		}
+		return members, true
+	}
+	return nil, false
+}
+
+// authorAssociation returns the association of a login with the owner of a
+// repository: OWNER, MEMBER of the owning organization, or CONTRIBUTOR.
+func (s *scenarioState) authorAssociation(login, repo string) string {
+	owner, _, _ := strings.Cut(repo, "/")
+	if strings.EqualFold(login, owner) {
+		return "OWNER"
+	}
+	for name, members := range s.scenario.Orgs {
+		if strings.EqualFold(name, owner) && slices.ContainsFunc(members, func(m string) bool { return strings.EqualFold(m, login) }) {
+			return "MEMBER"
+		}
+	}
+	return "CONTRIBUTOR"
+}
+
+// issue converts the PR into the search API representation.
+func (pr ScenarioPullRequest) issue(author, association string) Issue {
+	htmlURL := pr.HTMLURL
+	if htmlURL == "" {
+		htmlURL = fmt.Sprintf("https://github.com/%s/pull/%d", pr.Repo, pr.Number)
+	}
+
+	return Issue{
+		Number:            pr.Number,
+		Title:             pr.Title,
+		State:             "closed",
+		CreatedAt:         pr.CreatedAt,
+		UpdatedAt:         pr.UpdatedAt,
+		ClosedAt:          pr.ClosedAt,
+		RepositoryURL:     "https://api.github.com/repos/" + pr.Repo,
+		HTMLURL:           htmlURL,
+		User:              User{Login: author, Type: "User"},
+		AuthorAssociation: association,
+		PullRequest: &PullRequestRef{
+			HTMLURL:  htmlURL,
+			MergedAt: pr.MergedAt,
+		},
+	}
+}
+
+// notFound builds the error returned for resources missing from a scenario.
+func notFound() (*http.Response, error) {
+	resp := mockResponse()
+	resp.StatusCode = http.StatusNotFound
+	return resp, newAPIError(http.StatusNotFound, []byte(`{"message":"Not Found"}`))
+}
+
+// mockResponse builds a successful mock response with a healthy rate limit.
+func mockResponse() *http.Response {
+	resp := &http.Response{
+		StatusCode: http.StatusOK,
+		Header:     make(http.Header),
+	}
+	resp.Header.Set("X-RateLimit-Remaining", "5000")
+	resp.Header.Set("X-RateLimit-Limit", "5000")
+	resp.Header.Set("X-RateLimit-Reset", strconv.FormatInt(time.Now().Add(time.Hour).Unix(), 10))
+	return resp
+}
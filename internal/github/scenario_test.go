@@ -0,0 +1,241 @@
+package github
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func scenarioPR(repo string, number int) ScenarioPullRequest {
+	mergedAt := time.Date(2025, 1, 2, 3, 4, 5, 0, time.UTC)
+	return ScenarioPullRequest{
+		Repo:        repo,
+		PullRequest: PullRequest{Number: number, MergedAt: &mergedAt, Additions: number},
+	}
+}
+
+func TestScenarioSearchIssues(t *testing.T) {
+	client := NewScenarioMockAPIClient(&Scenario{
+		IncompleteResults: true,
+		Users: map[string][]ScenarioPullRequest{
+			"octocat": {
+				scenarioPR("golang/go", 1),
+				scenarioPR("golang/tools", 2),
+				scenarioPR("acme/app", 3),
+				scenarioPR("octocat/dotfiles", 4),
+			},
+		},
+	})
+	ctx := context.Background()
+
+	result, resp, err := client.SearchIssues(ctx, "author:octocat type:pr is:merged -user:octocat -org:acme", 1, 100)
+	if err != nil {
+		t.Fatalf("SearchIssues() error: %v", err)
+	}
+	if resp.StatusCode != http.StatusOK {
+		t.Errorf("StatusCode = %d, want 200", resp.StatusCode)
+	}
+	if !result.IncompleteResults {
+		t.Error("IncompleteResults = false, want true")
+	}
+	if result.TotalCount != 2 || len(result.Items) != 2 {
+		t.Fatalf("Got %d items (total %d), want 2", len(result.Items), result.TotalCount)
+	}
+
+	item := result.Items[0]
+	if item.RepositoryURL != "https://api.github.com/repos/golang/go" {
+		t.Errorf("RepositoryURL = %s", item.RepositoryURL)
+	}
+	if item.PullRequest == nil || item.PullRequest.MergedAt == nil {
+		t.Error("PullRequest.MergedAt not set")
+	}
+
+	// Pagination
+	page2, _, err := client.SearchIssues(ctx, "author:octocat", 2, 3)
+	if err != nil {
+		t.Fatalf("SearchIssues() page 2 error: %v", err)
+	}
+	if len(page2.Items) != 1 || page2.Items[0].Number != 4 {
+		t.Errorf("Page 2 = %+v, want PR #4 only", page2.Items)
+	}
+
+	// Unknown user
+	empty, _, err := client.SearchIssues(ctx, "author:nobody", 1, 100)
+	if err != nil || empty.TotalCount != 0 {
+		t.Errorf("SearchIssues() for unknown user = %+v, %v, want no results", empty, err)
+	}
+}
+
+func TestScenarioSearchRepository(t *testing.T) {
+	client := NewScenarioMockAPIClient(&Scenario{
+		Orgs: map[string][]string{"golang": {"rsc"}},
+		Users: map[string][]ScenarioPullRequest{
+			"rsc":     {scenarioPR("golang/go", 1)},
+			"octocat": {scenarioPR("golang/go", 2), scenarioPR("acme/app", 3)},
+			"golang":  {scenarioPR("golang/go", 4)},
+		},
+	})
+
+	result, _, err := client.SearchIssues(context.Background(), "repo:golang/go type:pr is:merged", 1, 100)
+	if err != nil {
+		t.Fatalf("SearchIssues() error: %v", err)
+	}
+	if len(result.Items) != 3 {
+		t.Fatalf("Got %d items, want the 3 PRs to golang/go", len(result.Items))
+	}
+
+	want := map[string]string{"golang": "OWNER", "octocat": "CONTRIBUTOR", "rsc": "MEMBER"}
+	for _, item := range result.Items {
+		if got := item.AuthorAssociation; got != want[item.User.Login] {
+			t.Errorf("AuthorAssociation of %s = %s, want %s", item.User.Login, got, want[item.User.Login])
+		}
+	}
+}
+
+func TestScenarioLookups(t *testing.T) {
+	client := NewScenarioMockAPIClient(&Scenario{
+		Users: map[string][]ScenarioPullRequest{
+			"octocat": {scenarioPR("golang/go", 7)},
+		},
+		Repositories: []Repository{{FullName: "golang/go", StargazersCount: 100}},
+	})
+	ctx := context.Background()
+
+	pr, _, err := client.GetPullRequest(ctx, "golang", "go", 7)
+	if err != nil {
+		t.Fatalf("GetPullRequest() error: %v", err)
+	}
+	if pr.Additions != 7 || !pr.Merged || pr.User.Login != "octocat" {
+		t.Errorf("GetPullRequest() = %+v", pr)
+	}
+
+	repo, _, err := client.GetRepository(ctx, "golang", "go")
+	if err != nil {
+		t.Fatalf("GetRepository() error: %v", err)
+	}
+	if repo.StargazersCount != 100 || repo.HTMLURL != "https://github.com/golang/go" {
+		t.Errorf("GetRepository() = %+v", repo)
+	}
+
+	_, resp, err := client.GetRepository(ctx, "golang", "missing")
+	var apiErr *APIError
+	if !errors.As(err, &apiErr) || resp == nil || resp.StatusCode != http.StatusNotFound {
+		t.Errorf("GetRepository() for missing repo = %v, want HTTP 404", err)
+	}
+
+	if _, _, err := client.GetPullRequest(ctx, "golang", "go", 8); err == nil {
+		t.Error("Expected error for missing PR")
+	}
+}
+
+func TestScenarioFailures(t *testing.T) {
+	client := NewScenarioMockAPIClient(&Scenario{
+		Repositories: []Repository{
+			{FullName: "golang/go"},
+			{FullName: "acme/app"},
+		},
+		Failures: []ScenarioFailure{
+			{Call: CallGetRepository, Target: "golang/go", Status: 500, Times: 2},
+			{Call: CallGetRepository, Target: "acme/app", Status: 403, Headers: map[string]string{"X-RateLimit-Remaining": "0"}},
+		},
+	})
+	ctx := context.Background()
+
+	for i := 0; i < 2; i++ {
+		_, resp, err := client.GetRepository(ctx, "golang", "go")
+		if err == nil || resp.StatusCode != http.StatusInternalServerError {
+			t.Fatalf("Call %d: error = %v, want HTTP 500", i+1, err)
+		}
+	}
+	if _, _, err := client.GetRepository(ctx, "golang", "go"); err != nil {
+		t.Errorf("Call 3: error = %v, want failure exhausted after 2 calls", err)
+	}
+
+	_, resp, err := client.GetRepository(ctx, "acme", "app")
+	if err == nil {
+		t.Fatal("Expected injected 403")
+	}
+	if got := ClassifyResponse(resp, err); got != ClassPrimaryRateLimit {
+		t.Errorf("ClassifyResponse() = %v, want primary rate limit", got)
+	}
+}
+
+func TestScenarioLatencyRespectsContext(t *testing.T) {
+	client := NewScenarioMockAPIClient(&Scenario{Latency: Duration(time.Minute)})
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+
+	start := time.Now()
+	_, _, err := client.GetRepository(ctx, "golang", "go")
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("error = %v, want context.DeadlineExceeded", err)
+	}
+	if time.Since(start) > time.Second {
+		t.Error("Latency was not interrupted by the context")
+	}
+}
+
+func TestDurationUnmarshalJSON(t *testing.T) {
+	tests := []struct {
+		input   string
+		want    time.Duration
+		wantErr bool
+	}{
+		{`"250ms"`, 250 * time.Millisecond, false},
+		{`"2s"`, 2 * time.Second, false},
+		{`100`, 100 * time.Millisecond, false},
+		{`null`, 0, false},
+		{`"soon"`, 0, true},
+		{`true`, 0, true},
+	}
+
+	for _, tt := range tests {
+		var d Duration
+		err := json.Unmarshal([]byte(tt.input), &d)
+		if (err != nil) != tt.wantErr {
+			t.Errorf("Unmarshal(%s) error = %v, wantErr %v", tt.input, err, tt.wantErr)
+			continue
+		}
+		if time.Duration(d) != tt.want {
+			t.Errorf("Unmarshal(%s) = %v, want %v", tt.input, time.Duration(d), tt.want)
+		}
+	}
+}
+
+func TestLoadScenario(t *testing.T) {
+	tests := []struct {
+		name    string
+		content string
+		wantErr bool
+	}{
+		{"valid", `{"users": {"octocat": [{"repo": "golang/go", "number": 1}]}}`, false},
+		{"invalid JSON", `{"users":`, true},
+		{"repo without owner", `{"users": {"octocat": [{"repo": "go", "number": 1}]}}`, true},
+		{"unknown call", `{"failures": [{"call": "DeleteRepo", "status": 500}]}`, true},
+		{"non-error status", `{"failures": [{"call": "SearchIssues", "status": 200}]}`, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			path := filepath.Join(t.TempDir(), "scenario.json")
+			if err := os.WriteFile(path, []byte(tt.content), 0o644); err != nil {
+				t.Fatal(err)
+			}
+
+			_, err := LoadScenario(path)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("LoadScenario() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+
+	if _, err := LoadScenario(filepath.Join(t.TempDir(), "missing.json")); err == nil {
+		t.Error("Expected error for missing file")
+	}
+}
@@ -0,0 +1,211 @@
+package github
+
+import (
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	// RateLimitResourceHeader names the rate limit bucket a response counts against
+	RateLimitResourceHeader = "X-RateLimit-Resource"
+
+	// ResourceCore is the rate limit bucket for most REST API endpoints
+	ResourceCore = "core"
+
+	// ResourceSearch is the rate limit bucket for the search API
+	ResourceSearch = "search"
+)
+
+// TokenPool spreads requests across several tokens.
+// It tracks each token's remaining budget per rate limit resource from the
+// X-RateLimit-* response headers and prefers the token with the most
+// requests left. It is safe for concurrent use.
+type TokenPool struct {
+	mu     sync.Mutex
+	tokens []*pooledToken
+}
+
+// pooledToken holds the usage and rate limit state of a single token.
+type pooledToken struct {
+	value    string
+	requests int
+	budgets  map[string]*tokenBudget
+}
+
+// tokenBudget is the last known rate limit state of a token for a resource.
+type tokenBudget struct {
+	remaining int
+	reset     time.Time
+}
+
+// TokenUsage reports how a token from the pool was used.
+type TokenUsage struct {
+	Token     string    // Masked token, e.g. "ghp_…abcd"
+	Requests  int       // Requests made with the token
+	Remaining int       // Last known remaining core requests (-1 = unknown)
+	Reset     time.Time // Last known core rate limit reset time
+}
+
+// NewTokenPool creates a pool from the given tokens.
+// Empty and duplicate tokens are ignored.
+func NewTokenPool(tokens []string) *TokenPool {
+	pool := &TokenPool{}
+	seen := make(map[string]bool)
+
+	for _, token := range tokens {
+		token = strings.TrimSpace(token)
+		if token == "" || seen[token] {
+			continue
+		}
+		seen[token] = true
+		pool.tokens = append(pool.tokens, &pooledToken{
+			value:   token,
+			budgets: make(map[string]*tokenBudget),
+		})
+	}
+
+	return pool
+}
+
+// Len returns the number of tokens in the pool.
+func (p *TokenPool) Len() int {
+	return len(p.tokens)
+}
+
+// Acquire returns the token with the most remaining budget for the resource
+// and counts a request against it. Tokens whose budget is unknown are treated
+// as unused. When every token is exhausted, the one that resets first is
+// returned. Returns "" for an empty pool.
+func (p *TokenPool) Acquire(resource string) string {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	now := time.Now()
+	var best *pooledToken
+	bestRemaining := -1
+	var earliest *pooledToken
+	var earliestReset time.Time
+
+	for _, t := range p.tokens {
+		remaining := t.remaining(resource, now)
+		if remaining > bestRemaining {
+			best = t
+			bestRemaining = remaining
+		}
+
+		if b := t.budgets[resource]; b != nil && (earliest == nil || b.reset.Before(earliestReset)) {
+			earliest = t
+			earliestReset = b.reset
+		}
+	}
+
+	if best == nil {
+		return ""
+	}
+	if bestRemaining == 0 && earliest != nil {
+		best = earliest
+	}
+
+	best.requests++
+	if b := best.budgets[resource]; b != nil && b.remaining > 0 {
+		// Reserve the request so concurrent callers spread across tokens
+		b.remaining--
+	}
+
+	return best.value
+}
+
+// Update records the rate limit state reported by a response made with token.
+func (p *TokenPool) Update(token string, headers http.Header) {
+	info, err := ParseRateLimitHeaders(headers)
+	if err != nil {
+		return
+	}
+
+	resource := headers.Get(RateLimitResourceHeader)
+	if resource == "" {
+		resource = ResourceCore
+	}
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	for _, t := range p.tokens {
+		if t.value == token {
+			t.budgets[resource] = &tokenBudget{
+				remaining: info.Remaining,
+				reset:     info.Reset,
+			}
+			return
+		}
+	}
+}
+
+// Available reports whether any token still has budget for the resource.
+func (p *TokenPool) Available(resource string) bool {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	now := time.Now()
+	for _, t := range p.tokens {
+		if t.remaining(resource, now) > 0 {
+			return true
+		}
+	}
+	return false
+}
+
+// Usage returns the per-token usage, in the order the tokens were added.
+func (p *TokenPool) Usage() []TokenUsage {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	usage := make([]TokenUsage, 0, len(p.tokens))
+	for _, t := range p.tokens {
+		u := TokenUsage{
+			Token:     MaskToken(t.value),
+			Requests:  t.requests,
+			Remaining: -1,
+		}
+		if b := t.budgets[ResourceCore]; b != nil {
+			u.Remaining = b.remaining
+			u.Reset = b.reset
+		}
+		usage = append(usage, u)
+	}
+
+	return usage
+}
+
+// remaining returns the known remaining budget for the resource.
+// Unknown budgets and budgets past their reset time count as full.
+func (t *pooledToken) remaining(resource string, now time.Time) int {
+	b := t.budgets[resource]
+	if b == nil || now.After(b.reset) {
+		return int(^uint(0) >> 1)
+	}
+	return b.remaining
+}
+
+// MaskToken hides all but the prefix and last four characters of a token.
+func MaskToken(token string) string {
+	if len(token) <= 8 {
+		return strings.Repeat("*", len(token))
+	}
+
+	prefix := ""
+	if i := strings.Index(token, "_"); i > 0 && i < 12 {
+		prefix = token[:i+1]
+	}
+	return prefix + "…" + token[len(token)-4:]
+}
+
+// resourceForPath returns the rate limit resource a request path counts against.
+func resourceForPath(path string) string {
+	if strings.HasPrefix(path, "/search/") {
+		return ResourceSearch
+	}
+	return ResourceCore
+}
@@ -0,0 +1,175 @@
+package github
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+	"time"
+)
+
+func rateLimitHeaders(remaining int, reset time.Time, resource string) http.Header {
+	headers := make(http.Header)
+	headers.Set(RateLimitRemainingHeader, strconv.Itoa(remaining))
+	headers.Set(RateLimitResetHeader, strconv.FormatInt(reset.Unix(), 10))
+	if resource != "" {
+		headers.Set(RateLimitResourceHeader, resource)
+	}
+	return headers
+}
+
+func TestNewTokenPoolSkipsEmptyAndDuplicates(t *testing.T) {
+	pool := NewTokenPool([]string{"token-a", "", " token-b ", "token-a"})
+
+	if pool.Len() != 2 {
+		t.Errorf("Len() = %d, want 2", pool.Len())
+	}
+}
+
+func TestTokenPoolAcquirePrefersMostRemaining(t *testing.T) {
+	pool := NewTokenPool([]string{"token-a", "token-b"})
+	reset := time.Now().Add(time.Hour)
+
+	pool.Update("token-a", rateLimitHeaders(10, reset, ResourceCore))
+	pool.Update("token-b", rateLimitHeaders(4000, reset, ResourceCore))
+
+	if got := pool.Acquire(ResourceCore); got != "token-b" {
+		t.Errorf("Acquire() = %s, want token-b", got)
+	}
+}
+
+func TestTokenPoolAcquireTracksResourcesSeparately(t *testing.T) {
+	pool := NewTokenPool([]string{"token-a", "token-b"})
+	reset := time.Now().Add(time.Hour)
+
+	pool.Update("token-a", rateLimitHeaders(0, reset, ResourceSearch))
+	pool.Update("token-b", rateLimitHeaders(30, reset, ResourceSearch))
+	pool.Update("token-a", rateLimitHeaders(5000, reset, ResourceCore))
+	pool.Update("token-b", rateLimitHeaders(100, reset, ResourceCore))
+
+	if got := pool.Acquire(ResourceSearch); got != "token-b" {
+		t.Errorf("Acquire(search) = %s, want token-b", got)
+	}
+	if got := pool.Acquire(ResourceCore); got != "token-a" {
+		t.Errorf("Acquire(core) = %s, want token-a", got)
+	}
+}
+
+func TestTokenPoolAllExhausted(t *testing.T) {
+	pool := NewTokenPool([]string{"token-a", "token-b"})
+	now := time.Now()
+
+	pool.Update("token-a", rateLimitHeaders(0, now.Add(time.Hour), ResourceCore))
+	pool.Update("token-b", rateLimitHeaders(0, now.Add(time.Minute), ResourceCore))
+
+	if pool.Available(ResourceCore) {
+		t.Error("Available() = true, want false when every token is exhausted")
+	}
+	if got := pool.Acquire(ResourceCore); got != "token-b" {
+		t.Errorf("Acquire() = %s, want token-b (earliest reset)", got)
+	}
+}
+
+func TestTokenPoolExpiredBudgetCountsAsFull(t *testing.T) {
+	pool := NewTokenPool([]string{"token-a"})
+	pool.Update("token-a", rateLimitHeaders(0, time.Now().Add(-time.Minute), ResourceCore))
+
+	if !pool.Available(ResourceCore) {
+		t.Error("Available() = false, want true after reset time has passed")
+	}
+}
+
+func TestTokenPoolUsage(t *testing.T) {
+	pool := NewTokenPool([]string{"ghp_aaaaaaaaaaaa1111", "ghp_bbbbbbbbbbbb2222"})
+	reset := time.Now().Add(time.Hour).Truncate(time.Second)
+
+	pool.Acquire(ResourceCore)
+	pool.Update("ghp_aaaaaaaaaaaa1111", rateLimitHeaders(4999, reset, ResourceCore))
+	pool.Acquire(ResourceCore)
+
+	usage := pool.Usage()
+	if len(usage) != 2 {
+		t.Fatalf("Usage() length = %d, want 2", len(usage))
+	}
+
+	if usage[0].Token != "ghp_…1111" {
+		t.Errorf("Token = %q, want masked token", usage[0].Token)
+	}
+	if usage[0].Requests != 1 || usage[1].Requests != 1 {
+		t.Errorf("Requests = %d/%d, want 1/1", usage[0].Requests, usage[1].Requests)
+	}
+	if usage[0].Remaining != 4999 || !usage[0].Reset.Equal(reset) {
+		t.Errorf("Remaining/Reset = %d/%v, want 4999/%v", usage[0].Remaining, usage[0].Reset, reset)
+	}
+	if usage[1].Remaining != -1 {
+		t.Errorf("Remaining for unused token = %d, want -1", usage[1].Remaining)
+	}
+}
+
+func TestMaskToken(t *testing.T) {
+	tests := []struct {
+		token string
+		want  string
+	}{
+		{"ghp_abcdefghijklmnop1234", "ghp_…1234"},
+		{"github_pat_abcdefghijklmnop5678", "github_…5678"},
+		{"abcdefghijkl9012", "…9012"},
+		{"short", "*****"},
+	}
+
+	for _, tt := range tests {
+		if got := MaskToken(tt.token); got != tt.want {
+			t.Errorf("MaskToken(%q) = %q, want %q", tt.token, got, tt.want)
+		}
+	}
+}
+
+func TestAPIClientTokenPoolFailover(t *testing.T) {
+	reset := time.Now().Add(time.Hour)
+	var authHeaders []string
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		auth := r.Header.Get("Authorization")
+		authHeaders = append(authHeaders, auth)
+
+		if auth == "Bearer token-a" {
+			for k, v := range rateLimitHeaders(0, reset, ResourceCore) {
+				w.Header()[k] = v
+			}
+			w.WriteHeader(http.StatusForbidden)
+			w.Write([]byte(`{"message":"API rate limit exceeded"}`))
+			return
+		}
+
+		for k, v := range rateLimitHeaders(4999, reset, ResourceCore) {
+			w.Header()[k] = v
+		}
+		w.Write([]byte(`{"name":"repo","stargazers_count":7}`))
+	}))
+	defer server.Close()
+
+	pool := NewTokenPool([]string{"token-a", "token-b"})
+	client := NewAPIClientWithTokenPool(&http.Client{}, pool)
+	client.baseURL = server.URL
+
+	repo, _, err := client.GetRepository(context.Background(), "owner", "repo")
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if repo.StargazersCount != 7 {
+		t.Errorf("StargazersCount = %d, want 7", repo.StargazersCount)
+	}
+
+	if len(authHeaders) != 2 || authHeaders[0] != "Bearer token-a" || authHeaders[1] != "Bearer token-b" {
+		t.Errorf("Authorization headers = %v, want token-a then token-b", authHeaders)
+	}
+
+	// Exhausted token should no longer be picked
+	if _, _, err := client.GetRepository(context.Background(), "owner", "repo"); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if last := authHeaders[len(authHeaders)-1]; last != "Bearer token-b" {
+		t.Errorf("Authorization header = %s, want token-b", last)
+	}
+}
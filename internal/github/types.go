@@ -22,6 +22,10 @@ type Issue struct {
 	RepositoryURL string          `json:"repository_url"`
 	HTMLURL       string          `json:"html_url"`
 	User          User            `json:"user"`
+
+	// AuthorAssociation is the author's relation to the repository owner,
+	// e.g. OWNER, MEMBER, COLLABORATOR or CONTRIBUTOR
+	AuthorAssociation string `json:"author_association"`
 }
 
 // PullRequestRef contains references to a pull request's URLs.
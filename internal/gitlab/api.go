@@ -0,0 +1,214 @@
+// Package gitlab is a low-level client for the parts of the GitLab REST API
+// (v4) used to find a user's merged merge requests.
+package gitlab
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// GitLabBaseURL is the base URL of gitlab.com.
+const GitLabBaseURL = "https://gitlab.com"
+
+// APIClient is a low-level GitLab API client.
+type APIClient struct {
+	httpClient *http.Client
+	token      string
+	baseURL    string // Instance URL, without the /api/v4 suffix
+}
+
+// APIError is returned for HTTP error responses.
+type APIError struct {
+	StatusCode int
+	Message    string // "message" or "error" field of the JSON error body, if any
+	Body       string
+
+	// RetryAt is when a rate limited request may be retried, if known
+	RetryAt time.Time
+}
+
+func (e *APIError) Error() string {
+	return fmt.Sprintf("HTTP %d: %s", e.StatusCode, e.Body)
+}
+
+// newAPIError builds an APIError from an error response.
+func newAPIError(resp *http.Response, body []byte) *APIError {
+	apiErr := &APIError{
+		StatusCode: resp.StatusCode,
+		Body:       string(body),
+	}
+
+	var payload struct {
+		Message any    `json:"message"`
+		Error   string `json:"error"`
+	}
+	if err := json.Unmarshal(body, &payload); err == nil {
+		if message, ok := payload.Message.(string); ok {
+			apiErr.Message = message
+		} else if payload.Error != "" {
+			apiErr.Message = payload.Error
+		}
+	}
+
+	if reset, err := strconv.ParseInt(resp.Header.Get("RateLimit-Reset"), 10, 64); err == nil {
+		apiErr.RetryAt = time.Unix(reset, 0)
+	} else if seconds, err := strconv.Atoi(resp.Header.Get("Retry-After")); err == nil {
+		apiErr.RetryAt = time.Now().Add(time.Duration(seconds) * time.Second)
+	}
+
+	return apiErr
+}
+
+// NewAPIClient creates a GitLab API client for the instance at baseURL,
+// e.g. "https://gitlab.gnome.org". An empty baseURL means gitlab.com.
+func NewAPIClient(httpClient *http.Client, baseURL, token string) *APIClient {
+	if baseURL == "" {
+		baseURL = GitLabBaseURL
+	}
+	return &APIClient{
+		httpClient: httpClient,
+		token:      token,
+		baseURL:    strings.TrimSuffix(strings.TrimSuffix(baseURL, "/"), "/api/v4"),
+	}
+}
+
+// BaseURL returns the instance URL the client talks to.
+func (c *APIClient) BaseURL() string {
+	return c.baseURL
+}
+
+// get performs a GET request against the v4 API and decodes the JSON response.
+func (c *APIClient) get(ctx context.Context, path string, result any) (*http.Response, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, c.baseURL+"/api/v4"+path, nil)
+	if err != nil {
+		return nil, fmt.Errorf("creating request: %w", err)
+	}
+	req.Header.Set("Accept", "application/json")
+	if c.token != "" {
+		req.Header.Set("Authorization", "Bearer "+c.token)
+	}
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("executing request: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode >= 400 {
+		body, _ := io.ReadAll(resp.Body)
+		return resp, newAPIError(resp, body)
+	}
+
+	if result != nil {
+		if err := json.NewDecoder(resp.Body).Decode(result); err != nil {
+			return resp, fmt.Errorf("decoding response: %w", err)
+		}
+	}
+
+	return resp, nil
+}
+
+// FindUser looks up a user by username. Returns nil if no user has it.
+func (c *APIClient) FindUser(ctx context.Context, username string) (*User, *http.Response, error) {
+	var users []User
+	resp, err := c.get(ctx, "/users?username="+url.QueryEscape(username), &users)
+	if err != nil {
+		return nil, resp, err
+	}
+	if len(users) == 0 {
+		return nil, resp, nil
+	}
+	return &users[0], resp, nil
+}
+
+// ListMergedMergeRequests lists one page of the merged merge requests
+// authored by a user, across all projects visible to the token.
+func (c *APIClient) ListMergedMergeRequests(ctx context.Context, authorID, page, perPage int) ([]MergeRequest, *http.Response, error) {
+	params := url.Values{}
+	params.Set("author_id", strconv.Itoa(authorID))
+	params.Set("state", "merged")
+	params.Set("scope", "all")
+	params.Set("order_by", "created_at")
+	params.Set("sort", "desc")
+	params.Set("page", strconv.Itoa(page))
+	params.Set("per_page", strconv.Itoa(perPage))
+
+	var mrs []MergeRequest
+	resp, err := c.get(ctx, "/merge_requests?"+params.Encode(), &mrs)
+	if err != nil {
+		return nil, resp, err
+	}
+	return mrs, resp, nil
+}
+
+// GetProject fetches a project by ID.
+func (c *APIClient) GetProject(ctx context.Context, id int) (*Project, *http.Response, error) {
+	var project Project
+	resp, err := c.get(ctx, fmt.Sprintf("/projects/%d", id), &project)
+	if err != nil {
+		return nil, resp, err
+	}
+	return &project, resp, nil
+}
+
+// ListMergeRequestCommits lists one page of the commits of a merge request.
+func (c *APIClient) ListMergeRequestCommits(ctx context.Context, projectID, iid, page, perPage int) ([]Commit, *http.Response, error) {
+	var commits []Commit
+	path := fmt.Sprintf("/projects/%d/merge_requests/%d/commits?page=%d&per_page=%d", projectID, iid, page, perPage)
+	resp, err := c.get(ctx, path, &commits)
+	if err != nil {
+		return nil, resp, err
+	}
+	return commits, resp, nil
+}
+
+// ListMergeRequestDiffs lists one page of the changed files of a merge request.
+func (c *APIClient) ListMergeRequestDiffs(ctx context.Context, projectID, iid, page, perPage int) ([]Diff, *http.Response, error) {
+	var diffs []Diff
+	path := fmt.Sprintf("/projects/%d/merge_requests/%d/diffs?page=%d&per_page=%d", projectID, iid, page, perPage)
+	resp, err := c.get(ctx, path, &diffs)
+	if err != nil {
+		return nil, resp, err
+	}
+	return diffs, resp, nil
+}
+
+// CountLines counts the added and deleted lines of a unified diff.
+// Lines before the first hunk, such as file headers, are ignored.
+func CountLines(diff string) (additions, deletions int) {
+	inHunk := false
+	for _, line := range strings.Split(diff, "\n") {
+		switch {
+		case strings.HasPrefix(line, "@@"):
+			inHunk = true
+		case !inHunk:
+		case strings.HasPrefix(line, "+"):
+			additions++
+		case strings.HasPrefix(line, "-"):
+			deletions++
+		}
+	}
+	return additions, deletions
+}
+
+// HasNextPage reports whether a paginated response has more pages.
+// GitLab omits X-Next-Page on the last page, and for very large result sets.
+func HasNextPage(resp *http.Response, count, perPage int) bool {
+	if resp == nil {
+		return false
+	}
+	if next := resp.Header.Get("X-Next-Page"); next != "" {
+		return true
+	}
+	if resp.Header.Get("X-Page") != "" {
+		return false
+	}
+	return count == perPage
+}
@@ -0,0 +1,142 @@
+package gitlab
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+	"time"
+)
+
+func TestNewAPIClientBaseURL(t *testing.T) {
+	tests := []struct {
+		baseURL string
+		want    string
+	}{
+		{"", "https://gitlab.com"},
+		{"https://gitlab.gnome.org", "https://gitlab.gnome.org"},
+		{"https://gitlab.gnome.org/", "https://gitlab.gnome.org"},
+		{"https://gitlab.example.com/api/v4", "https://gitlab.example.com"},
+	}
+
+	for _, tt := range tests {
+		if got := NewAPIClient(http.DefaultClient, tt.baseURL, "").BaseURL(); got != tt.want {
+			t.Errorf("NewAPIClient(%q).BaseURL() = %q, want %q", tt.baseURL, got, tt.want)
+		}
+	}
+}
+
+func TestFindUser(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/api/v4/users" {
+			http.NotFound(w, r)
+			return
+		}
+		if got := r.Header.Get("Authorization"); got != "Bearer test-token" {
+			t.Errorf("Authorization = %q, want Bearer test-token", got)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		if r.URL.Query().Get("username") == "octocat" {
+			w.Write([]byte(`[{"id": 42, "username": "octocat", "web_url": "https://gitlab.com/octocat"}]`))
+			return
+		}
+		w.Write([]byte(`[]`))
+	}))
+	defer server.Close()
+
+	client := NewAPIClient(server.Client(), server.URL, "test-token")
+
+	user, _, err := client.FindUser(context.Background(), "octocat")
+	if err != nil {
+		t.Fatalf("FindUser() error = %v", err)
+	}
+	if user == nil || user.ID != 42 {
+		t.Errorf("FindUser() = %+v, want user 42", user)
+	}
+
+	user, _, err = client.FindUser(context.Background(), "nobody")
+	if err != nil {
+		t.Fatalf("FindUser() error = %v", err)
+	}
+	if user != nil {
+		t.Errorf("FindUser() = %+v, want nil for unknown user", user)
+	}
+}
+
+func TestAPIError(t *testing.T) {
+	reset := time.Now().Add(time.Hour).Truncate(time.Second)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("RateLimit-Reset", strconv.FormatInt(reset.Unix(), 10))
+		w.WriteHeader(http.StatusTooManyRequests)
+		w.Write([]byte(`{"message": "Retry later"}`))
+	}))
+	defer server.Close()
+
+	client := NewAPIClient(server.Client(), server.URL, "")
+	_, _, err := client.GetProject(context.Background(), 1)
+
+	var apiErr *APIError
+	if !errors.As(err, &apiErr) {
+		t.Fatalf("GetProject() error = %v, want *APIError", err)
+	}
+	if apiErr.StatusCode != http.StatusTooManyRequests {
+		t.Errorf("StatusCode = %d, want 429", apiErr.StatusCode)
+	}
+	if apiErr.Message != "Retry later" {
+		t.Errorf("Message = %q, want Retry later", apiErr.Message)
+	}
+	if !apiErr.RetryAt.Equal(reset) {
+		t.Errorf("RetryAt = %v, want %v", apiErr.RetryAt, reset)
+	}
+}
+
+func TestCountLines(t *testing.T) {
+	tests := []struct {
+		name          string
+		diff          string
+		wantAdditions int
+		wantDeletions int
+	}{
+		{"empty", "", 0, 0},
+		{"hunk", "@@ -1,2 +1,3 @@\n context\n-old\n+new\n+added\n", 2, 1},
+		{"headers ignored", "--- a/file\n+++ b/file\n@@ -1 +1 @@\n-old\n+new\n", 1, 1},
+		{"several hunks", "@@ -1 +1 @@\n-a\n+b\n@@ -10 +10,2 @@\n+c\n+d\n", 3, 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			additions, deletions := CountLines(tt.diff)
+			if additions != tt.wantAdditions || deletions != tt.wantDeletions {
+				t.Errorf("CountLines() = (%d, %d), want (%d, %d)", additions, deletions, tt.wantAdditions, tt.wantDeletions)
+			}
+		})
+	}
+}
+
+func TestHasNextPage(t *testing.T) {
+	tests := []struct {
+		name    string
+		headers map[string]string
+		count   int
+		want    bool
+	}{
+		{"next page header", map[string]string{"X-Page": "1", "X-Next-Page": "2"}, 100, true},
+		{"last page", map[string]string{"X-Page": "3", "X-Next-Page": ""}, 100, false},
+		{"no headers, full page", nil, 100, true},
+		{"no headers, short page", nil, 10, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			resp := &http.Response{Header: http.Header{}}
+			for key, value := range tt.headers {
+				resp.Header.Set(key, value)
+			}
+			if got := HasNextPage(resp, tt.count, 100); got != tt.want {
+				t.Errorf("HasNextPage() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
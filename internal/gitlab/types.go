@@ -0,0 +1,54 @@
+package gitlab
+
+import "time"
+
+// User represents a GitLab user.
+type User struct {
+	ID       int    `json:"id"`
+	Username string `json:"username"`
+	WebURL   string `json:"web_url"`
+}
+
+// MergeRequest represents a GitLab merge request.
+type MergeRequest struct {
+	ID        int        `json:"id"`
+	IID       int        `json:"iid"` // Project-scoped number, as in "!12"
+	ProjectID int        `json:"project_id"`
+	Title     string     `json:"title"`
+	State     string     `json:"state"`
+	MergedAt  *time.Time `json:"merged_at"`
+	WebURL    string     `json:"web_url"`
+	Author    User       `json:"author"`
+}
+
+// Project represents a GitLab project.
+type Project struct {
+	ID                int       `json:"id"`
+	Name              string    `json:"name"`
+	Path              string    `json:"path"`
+	PathWithNamespace string    `json:"path_with_namespace"` // e.g. "GNOME/gtk" or "group/subgroup/project"
+	Description       string    `json:"description"`
+	WebURL            string    `json:"web_url"`
+	StarCount         int       `json:"star_count"`
+	Namespace         Namespace `json:"namespace"`
+}
+
+// Namespace is the user or group a project belongs to.
+type Namespace struct {
+	ID       int    `json:"id"`
+	Kind     string `json:"kind"` // "user" or "group"
+	Path     string `json:"path"`
+	FullPath string `json:"full_path"`
+}
+
+// Diff is a changed file of a merge request.
+type Diff struct {
+	OldPath string `json:"old_path"`
+	NewPath string `json:"new_path"`
+	Diff    string `json:"diff"` // Unified diff hunks, without file headers
+}
+
+// Commit is a commit of a merge request.
+type Commit struct {
+	ID string `json:"id"`
+}
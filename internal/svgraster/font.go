@@ -0,0 +1,174 @@
+package svgraster
+
+import (
+	_ "embed"
+	"encoding/binary"
+	"fmt"
+	"sync"
+)
+
+// The glyphs are outlines of DejaVu Sans (see DEJAVU-LICENSE), extracted by
+// gen_font.go from the fonts-dejavu-core package.
+//go:generate go run gen_font.go -o dejavu-sans.bin /usr/share/fonts/truetype/dejavu/DejaVuSans.ttf
+//go:generate go run gen_font.go -o dejavu-sans-bold.bin /usr/share/fonts/truetype/dejavu/DejaVuSans-Bold.ttf
+
+//go:embed dejavu-sans.bin
+var dejavuSans []byte
+
+//go:embed dejavu-sans-bold.bin
+var dejavuSansBold []byte
+
+// face is a font read from the format written by gen_font.go.
+type face struct {
+	unitsPerEm float64
+	ascent     float64
+	descent    float64
+	glyphs     map[rune]*glyph
+}
+
+// glyph is a glyph outline in font units, y up. Points between two off-curve
+// points are implied on-curve points, as in TrueType.
+type glyph struct {
+	advance  float64
+	contours [][]glyphPoint
+}
+
+type glyphPoint struct {
+	x, y float64
+	on   bool
+}
+
+// substitutes are drawn for runes missing from the fonts.
+var substitutes = map[rune]rune{
+	'⭐': '★',
+}
+
+var (
+	facesOnce sync.Once
+	regular   *face
+	bold      *face
+	facesErr  error
+)
+
+// faces returns the regular and bold faces, loaded on first use.
+func faces() (*face, *face, error) {
+	facesOnce.Do(func() {
+		if regular, facesErr = loadFace(dejavuSans); facesErr != nil {
+			return
+		}
+		bold, facesErr = loadFace(dejavuSansBold)
+	})
+	return regular, bold, facesErr
+}
+
+// loadFace reads a face written by gen_font.go.
+func loadFace(data []byte) (f *face, err error) {
+	defer func() {
+		// Reads past the end of truncated data panic
+		if recover() != nil {
+			f, err = nil, fmt.Errorf("invalid font data")
+		}
+	}()
+
+	off := 0
+	u16 := func() uint16 {
+		v := binary.BigEndian.Uint16(data[off:])
+		off += 2
+		return v
+	}
+
+	f = &face{
+		unitsPerEm: float64(u16()),
+		ascent:     float64(int16(u16())),
+		descent:    float64(int16(u16())),
+		glyphs:     make(map[rune]*glyph),
+	}
+	count := int(u16())
+	for range count {
+		r := rune(binary.BigEndian.Uint32(data[off:]))
+		off += 4
+		g := &glyph{advance: float64(u16())}
+		g.contours = make([][]glyphPoint, u16())
+		for c := range g.contours {
+			g.contours[c] = make([]glyphPoint, u16())
+			for p := range g.contours[c] {
+				x, y := int16(u16()), int16(u16())
+				g.contours[c][p] = glyphPoint{x: float64(x), y: float64(y), on: data[off] != 0}
+				off++
+			}
+		}
+		f.glyphs[r] = g
+	}
+	if f.unitsPerEm == 0 || f.glyphs[0] == nil {
+		return nil, fmt.Errorf("invalid font data")
+	}
+	return f, nil
+}
+
+// glyph returns the glyph of r, or .notdef if the face has none.
+func (f *face) glyph(r rune) *glyph {
+	if g, ok := f.glyphs[r]; ok {
+		return g
+	}
+	if g, ok := f.glyphs[substitutes[r]]; ok {
+		return g
+	}
+	return f.glyphs[0]
+}
+
+// outline appends the contours of g, scaled to user units by scale and with
+// its origin at (x, y), to path. Points are mapped by transform, and the y
+// axis points down. skew slants the glyph, for synthesized italics.
+func (g *glyph) outline(path [][]point, scale, x, y, skew float64, transform func(point) point) [][]point {
+	for _, contour := range g.contours {
+		if len(contour) == 0 {
+			continue
+		}
+		at := func(p glyphPoint) point {
+			return transform(point{x + (p.x+skew*p.y)*scale, y - p.y*scale})
+		}
+		mid := func(a, b glyphPoint) glyphPoint {
+			return glyphPoint{(a.x + b.x) / 2, (a.y + b.y) / 2, true}
+		}
+
+		// Start on an on-curve point, implying one if there is none
+		start := 0
+		for start < len(contour) && !contour[start].on {
+			start++
+		}
+		first := mid(contour[len(contour)-1], contour[0])
+		if start < len(contour) {
+			first = contour[start]
+		} else {
+			start = len(contour) - 1
+		}
+
+		var poly []point
+		poly = append(poly, at(first))
+		prev := first
+		var control *glyphPoint
+		for i := 1; i <= len(contour); i++ {
+			p := contour[(start+i)%len(contour)]
+			if p.on {
+				if control != nil {
+					poly = appendQuad(poly, at(prev), at(*control), at(p))
+				} else {
+					poly = append(poly, at(p))
+				}
+				prev, control = p, nil
+				continue
+			}
+			if control != nil {
+				m := mid(*control, p)
+				poly = appendQuad(poly, at(prev), at(*control), at(m))
+				prev = m
+			}
+			control = &p
+		}
+		if control != nil {
+			poly = appendQuad(poly, at(prev), at(*control), at(first))
+		}
+		path = append(path, poly)
+	}
+	return path
+}
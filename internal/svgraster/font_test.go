@@ -0,0 +1,35 @@
+package svgraster
+
+import "testing"
+
+func TestFaces(t *testing.T) {
+	regular, bold, err := faces()
+	if err != nil {
+		t.Fatalf("faces() error = %v", err)
+	}
+
+	for _, f := range []*face{regular, bold} {
+		for _, r := range "Az09@·…★é" {
+			if g := f.glyph(r); g == f.glyphs[0] {
+				t.Errorf("glyph(%q) is .notdef", r)
+			}
+		}
+		if f.glyph('⭐') != f.glyph('★') {
+			t.Error("glyph('⭐') is not substituted by ★")
+		}
+		if f.glyph('中') != f.glyphs[0] {
+			t.Error("glyph('中') is not .notdef")
+		}
+	}
+	if bold.glyph('m').advance <= regular.glyph('m').advance {
+		t.Error("Bold glyphs are not wider than regular ones")
+	}
+}
+
+func TestLoadFaceInvalid(t *testing.T) {
+	for _, data := range [][]byte{nil, dejavuSans[:100]} {
+		if _, err := loadFace(data); err == nil {
+			t.Errorf("loadFace(%d bytes) error = nil, want error", len(data))
+		}
+	}
+}
@@ -0,0 +1,320 @@
+//go:build ignore
+
+// gen_font extracts the outlines of the glyphs used by badges from a
+// TrueType font into the compact format read by loadFace:
+//
+//	go run gen_font.go -o dejavu-sans.bin DejaVuSans.ttf
+//
+// All integers are big-endian. The header is unitsPerEm (uint16), ascent and
+// descent (int16) and the number of glyphs (uint16). Each glyph is its rune
+// (uint32, 0 for .notdef), advance (uint16) and number of contours (uint16),
+// followed by each contour's number of points (uint16) and points: x and y
+// (int16) and whether the point is on the curve (uint8).
+package main
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"flag"
+	"fmt"
+	"log"
+	"math"
+	"os"
+)
+
+// runes are the characters extracted besides .notdef: ASCII, Latin-1 and the
+// punctuation and symbols used by the badge templates.
+var runes = func() []rune {
+	var rs []rune
+	for r := rune(0x20); r <= 0x7e; r++ {
+		rs = append(rs, r)
+	}
+	for r := rune(0xa0); r <= 0xff; r++ {
+		rs = append(rs, r)
+	}
+	return append(rs, '–', '—', '‘', '’', '“', '”', '•', '…', '→', '★', '�')
+}()
+
+type point struct {
+	x, y int16
+	on   bool
+}
+
+type font struct {
+	data      []byte
+	tables    map[string][]byte
+	numGlyphs int
+	longLoca  bool
+}
+
+func main() {
+	out := flag.String("o", "", "Output file")
+	flag.Parse()
+	if *out == "" || flag.NArg() != 1 {
+		log.Fatal("usage: go run gen_font.go -o <out.bin> <font.ttf>")
+	}
+
+	data, err := os.ReadFile(flag.Arg(0))
+	if err != nil {
+		log.Fatal(err)
+	}
+	f, err := parseFont(data)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	head, hhea := f.tables["head"], f.tables["hhea"]
+	var buf bytes.Buffer
+	write := func(v any) { binary.Write(&buf, binary.BigEndian, v) }
+
+	type entry struct {
+		r     rune
+		glyph int
+	}
+	entries := []entry{{0, 0}}
+	for _, r := range runes {
+		if g := f.glyphIndex(r); g != 0 {
+			entries = append(entries, entry{r, g})
+		} else {
+			fmt.Fprintf(os.Stderr, "skipping %U: not in font\n", r)
+		}
+	}
+
+	write(u16(head, 18))
+	write(int16(u16(hhea, 4)))
+	write(int16(u16(hhea, 6)))
+	write(uint16(len(entries)))
+	for _, e := range entries {
+		contours, err := f.outline(e.glyph, 0)
+		if err != nil {
+			log.Fatalf("%U: %v", e.r, err)
+		}
+		write(uint32(e.r))
+		write(f.advance(e.glyph))
+		write(uint16(len(contours)))
+		for _, contour := range contours {
+			write(uint16(len(contour)))
+			for _, p := range contour {
+				on := uint8(0)
+				if p.on {
+					on = 1
+				}
+				write(p.x)
+				write(p.y)
+				write(on)
+			}
+		}
+	}
+
+	if err := os.WriteFile(*out, buf.Bytes(), 0644); err != nil {
+		log.Fatal(err)
+	}
+}
+
+func u16(b []byte, off int) uint16 { return binary.BigEndian.Uint16(b[off:]) }
+func u32(b []byte, off int) uint32 { return binary.BigEndian.Uint32(b[off:]) }
+
+func parseFont(data []byte) (*font, error) {
+	f := &font{data: data, tables: make(map[string][]byte)}
+	numTables := int(u16(data, 4))
+	for i := range numTables {
+		rec := data[12+16*i:]
+		off, length := u32(rec, 8), u32(rec, 12)
+		f.tables[string(rec[:4])] = data[off : off+length]
+	}
+	for _, tag := range []string{"head", "hhea", "hmtx", "maxp", "cmap", "loca", "glyf"} {
+		if f.tables[tag] == nil {
+			return nil, fmt.Errorf("missing %s table", tag)
+		}
+	}
+	f.numGlyphs = int(u16(f.tables["maxp"], 4))
+	f.longLoca = u16(f.tables["head"], 50) != 0
+	return f, nil
+}
+
+// glyphIndex looks r up in the Windows Unicode BMP (format 4) cmap.
+func (f *font) glyphIndex(r rune) int {
+	cmap := f.tables["cmap"]
+	for i := range int(u16(cmap, 2)) {
+		rec := cmap[4+8*i:]
+		if u16(rec, 0) != 3 || u16(rec, 2) != 1 {
+			continue
+		}
+		sub := cmap[u32(rec, 4):]
+		if u16(sub, 0) != 4 {
+			continue
+		}
+		segCount := int(u16(sub, 6)) / 2
+		ends, starts := 14, 16+2*segCount
+		deltas, rangeOffsets := starts+2*segCount, starts+4*segCount
+		for s := range segCount {
+			end, start := rune(u16(sub, ends+2*s)), rune(u16(sub, starts+2*s))
+			if r > end || r < start {
+				continue
+			}
+			delta := u16(sub, deltas+2*s)
+			ro := int(u16(sub, rangeOffsets+2*s))
+			if ro == 0 {
+				return int(uint16(r) + delta)
+			}
+			g := u16(sub, rangeOffsets+2*s+ro+2*int(r-start))
+			if g == 0 {
+				return 0
+			}
+			return int(g + delta)
+		}
+	}
+	return 0
+}
+
+func (f *font) advance(g int) uint16 {
+	numH := int(u16(f.tables["hhea"], 34))
+	return u16(f.tables["hmtx"], 4*min(g, numH-1))
+}
+
+func (f *font) glyphData(g int) []byte {
+	loca := f.tables["loca"]
+	var start, end uint32
+	if f.longLoca {
+		start, end = u32(loca, 4*g), u32(loca, 4*g+4)
+	} else {
+		start, end = 2*uint32(u16(loca, 2*g)), 2*uint32(u16(loca, 2*g+2))
+	}
+	return f.tables["glyf"][start:end]
+}
+
+// outline returns the contours of glyph g, resolving composite glyphs.
+func (f *font) outline(g, depth int) ([][]point, error) {
+	if depth > 8 {
+		return nil, errors.New("composite glyphs nested too deeply")
+	}
+	data := f.glyphData(g)
+	if len(data) == 0 {
+		return nil, nil
+	}
+
+	numContours := int16(u16(data, 0))
+	if numContours < 0 {
+		return f.composite(data, depth)
+	}
+
+	off := 10
+	ends := make([]int, numContours)
+	for i := range ends {
+		ends[i] = int(u16(data, off))
+		off += 2
+	}
+	numPoints := 0
+	if numContours > 0 {
+		numPoints = ends[numContours-1] + 1
+	}
+	off += 2 + int(u16(data, off)) // Skip the instructions
+
+	flags := make([]byte, 0, numPoints)
+	for len(flags) < numPoints {
+		flag := data[off]
+		off++
+		flags = append(flags, flag)
+		if flag&8 != 0 {
+			repeat := int(data[off])
+			off++
+			for range repeat {
+				flags = append(flags, flag)
+			}
+		}
+	}
+
+	coords := func(short, same byte) []int16 {
+		values := make([]int16, numPoints)
+		var v int16
+		for i, flag := range flags {
+			switch {
+			case flag&short != 0:
+				d := int16(data[off])
+				off++
+				if flag&same == 0 {
+					d = -d
+				}
+				v += d
+			case flag&same == 0:
+				v += int16(u16(data, off))
+				off += 2
+			}
+			values[i] = v
+		}
+		return values
+	}
+	xs := coords(2, 16)
+	ys := coords(4, 32)
+
+	contours := make([][]point, numContours)
+	start := 0
+	for i, end := range ends {
+		for p := start; p <= end; p++ {
+			contours[i] = append(contours[i], point{xs[p], ys[p], flags[p]&1 != 0})
+		}
+		start = end + 1
+	}
+	return contours, nil
+}
+
+// composite returns the contours of a composite glyph, transforming each
+// component by its offset and scale.
+func (f *font) composite(data []byte, depth int) ([][]point, error) {
+	var contours [][]point
+	off := 10
+	for {
+		flags, glyph := u16(data, off), int(u16(data, off+2))
+		off += 4
+
+		var dx, dy float64
+		if flags&1 != 0 {
+			dx, dy = float64(int16(u16(data, off))), float64(int16(u16(data, off+2)))
+			off += 4
+		} else {
+			dx, dy = float64(int8(data[off])), float64(int8(data[off+1]))
+			off += 2
+		}
+		if flags&2 == 0 {
+			return nil, errors.New("composite glyphs positioned by points are not supported")
+		}
+
+		f2dot14 := func() float64 {
+			v := float64(int16(u16(data, off))) / 16384
+			off += 2
+			return v
+		}
+		a, b, c, d := 1.0, 0.0, 0.0, 1.0
+		switch {
+		case flags&8 != 0:
+			a = f2dot14()
+			d = a
+		case flags&0x40 != 0:
+			a, d = f2dot14(), f2dot14()
+		case flags&0x80 != 0:
+			a, b, c, d = f2dot14(), f2dot14(), f2dot14(), f2dot14()
+		}
+
+		component, err := f.outline(glyph, depth+1)
+		if err != nil {
+			return nil, err
+		}
+		for _, contour := range component {
+			transformed := make([]point, len(contour))
+			for i, p := range contour {
+				x, y := float64(p.x), float64(p.y)
+				transformed[i] = point{
+					x:  int16(math.Round(a*x + c*y + dx)),
+					y:  int16(math.Round(b*x + d*y + dy)),
+					on: p.on,
+				}
+			}
+			contours = append(contours, transformed)
+		}
+
+		if flags&0x20 == 0 {
+			return contours, nil
+		}
+	}
+}
@@ -0,0 +1,176 @@
+package svgraster
+
+import (
+	"image"
+	"math"
+	"slices"
+)
+
+// point is a position in pixels.
+type point struct {
+	x, y float64
+}
+
+// subsamples is the number of scanlines sampled per row of pixels. Coverage
+// along a scanline is exact, so this sets the vertical anti-aliasing.
+const subsamples = 8
+
+// paint returns the non-premultiplied color of a pixel, with components and
+// alpha in [0, 1].
+type paint func(x, y int) (r, g, b, a float64)
+
+// crossing is where an edge of a path crosses a scanline.
+type crossing struct {
+	x   float64
+	dir int
+}
+
+// fill composites paint over dst inside path, a set of closed polygons, using
+// the nonzero winding rule.
+func fill(dst *image.RGBA, path [][]point, p paint) {
+	bounds := image.Rectangle{}
+	first := true
+	for _, poly := range path {
+		for _, pt := range poly {
+			r := image.Rect(int(math.Floor(pt.x)), int(math.Floor(pt.y)), int(math.Floor(pt.x))+1, int(math.Floor(pt.y))+1)
+			if first {
+				bounds, first = r, false
+			} else {
+				bounds = bounds.Union(r)
+			}
+		}
+	}
+	bounds = bounds.Intersect(dst.Bounds())
+	if bounds.Empty() {
+		return
+	}
+
+	coverage := make([]float64, bounds.Dx()+1)
+	var crossings []crossing
+	for y := bounds.Min.Y; y < bounds.Max.Y; y++ {
+		clear(coverage)
+		for s := range subsamples {
+			sy := float64(y) + (float64(s)+0.5)/subsamples
+
+			crossings = crossings[:0]
+			for _, poly := range path {
+				for i := range poly {
+					a, b := poly[i], poly[(i+1)%len(poly)]
+					dir := 1
+					if a.y > b.y {
+						a, b, dir = b, a, -1
+					}
+					if sy < a.y || sy >= b.y {
+						continue
+					}
+					x := a.x + (sy-a.y)*(b.x-a.x)/(b.y-a.y)
+					crossings = append(crossings, crossing{x, dir})
+				}
+			}
+			slices.SortFunc(crossings, func(a, b crossing) int {
+				switch {
+				case a.x < b.x:
+					return -1
+				case a.x > b.x:
+					return 1
+				}
+				return 0
+			})
+
+			winding := 0
+			for i, c := range crossings {
+				winding += c.dir
+				if winding != 0 && i+1 < len(crossings) {
+					addSpan(coverage, c.x-float64(bounds.Min.X), crossings[i+1].x-float64(bounds.Min.X), 1.0/subsamples)
+				}
+			}
+		}
+
+		for i, cov := range coverage[:bounds.Dx()] {
+			if cov <= 0 {
+				continue
+			}
+			x := bounds.Min.X + i
+			r, g, b, a := p(x, y)
+			blend(dst, x, y, r, g, b, a*min(cov, 1))
+		}
+	}
+}
+
+// addSpan adds weight to the coverage of the pixels between x0 and x1,
+// proportionally to the part of each pixel covered.
+func addSpan(coverage []float64, x0, x1, weight float64) {
+	x0 = max(x0, 0)
+	x1 = min(x1, float64(len(coverage)))
+	if x1 <= x0 {
+		return
+	}
+	i0, i1 := int(x0), int(x1)
+	if i0 == i1 {
+		coverage[i0] += (x1 - x0) * weight
+		return
+	}
+	coverage[i0] += (float64(i0+1) - x0) * weight
+	for i := i0 + 1; i < i1; i++ {
+		coverage[i] += weight
+	}
+	if i1 < len(coverage) {
+		coverage[i1] += (x1 - float64(i1)) * weight
+	}
+}
+
+// blend composites a non-premultiplied color with alpha a over a pixel.
+func blend(dst *image.RGBA, x, y int, r, g, b, a float64) {
+	if a <= 0 {
+		return
+	}
+	i := dst.PixOffset(x, y)
+	pix := dst.Pix[i : i+4 : i+4]
+	inv := 1 - a
+	pix[0] = uint8(math.Round(r*a*255 + float64(pix[0])*inv))
+	pix[1] = uint8(math.Round(g*a*255 + float64(pix[1])*inv))
+	pix[2] = uint8(math.Round(b*a*255 + float64(pix[2])*inv))
+	pix[3] = uint8(math.Round(a*255 + float64(pix[3])*inv))
+}
+
+// appendQuad appends the points of a quadratic Bézier curve from p0, which
+// is already in poly, to p2.
+func appendQuad(poly []point, p0, p1, p2 point) []point {
+	length := math.Hypot(p1.x-p0.x, p1.y-p0.y) + math.Hypot(p2.x-p1.x, p2.y-p1.y)
+	steps := max(int(length/2), 1)
+	for i := 1; i <= steps; i++ {
+		t := float64(i) / float64(steps)
+		u := 1 - t
+		poly = append(poly, point{
+			u*u*p0.x + 2*u*t*p1.x + t*t*p2.x,
+			u*u*p0.y + 2*u*t*p1.y + t*t*p2.y,
+		})
+	}
+	return poly
+}
+
+// roundedRect returns a rectangle with corners of radius rx and ry, clockwise
+// on screen, as a polygon in user units.
+func roundedRect(x, y, w, h, rx, ry float64) []point {
+	rx = min(max(rx, 0), w/2)
+	ry = min(max(ry, 0), h/2)
+	if rx == 0 || ry == 0 {
+		return []point{{x, y}, {x + w, y}, {x + w, y + h}, {x, y + h}}
+	}
+
+	const arcSteps = 12
+	var poly []point
+	corners := []struct{ cx, cy, start float64 }{
+		{x + w - rx, y + ry, -math.Pi / 2},
+		{x + w - rx, y + h - ry, 0},
+		{x + rx, y + h - ry, math.Pi / 2},
+		{x + rx, y + ry, math.Pi},
+	}
+	for _, c := range corners {
+		for i := 0; i <= arcSteps; i++ {
+			angle := c.start + float64(i)/arcSteps*math.Pi/2
+			poly = append(poly, point{c.cx + rx*math.Cos(angle), c.cy + ry*math.Sin(angle)})
+		}
+	}
+	return poly
+}
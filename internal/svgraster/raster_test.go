@@ -0,0 +1,54 @@
+package svgraster
+
+import (
+	"image"
+	"math"
+	"testing"
+)
+
+func TestAddSpan(t *testing.T) {
+	coverage := make([]float64, 4)
+	addSpan(coverage, 0.5, 2.25, 1)
+	addSpan(coverage, 3.5, 9, 0.5)
+	addSpan(coverage, -2, -1, 1)
+
+	want := []float64{0.5, 1, 0.25, 0.25}
+	for i := range want {
+		if math.Abs(coverage[i]-want[i]) > 1e-9 {
+			t.Errorf("coverage = %v, want %v", coverage, want)
+			break
+		}
+	}
+}
+
+func TestFillAntiAliasing(t *testing.T) {
+	dst := image.NewRGBA(image.Rect(0, 0, 4, 4))
+	white := func(int, int) (float64, float64, float64, float64) { return 1, 1, 1, 1 }
+
+	// A square covering pixel (1, 1) and half of pixel (2, 1)
+	fill(dst, [][]point{{{1, 1}, {2.5, 1}, {2.5, 2}, {1, 2}}}, white)
+
+	if got := dst.RGBAAt(1, 1).A; got != 255 {
+		t.Errorf("Covered pixel alpha = %d, want 255", got)
+	}
+	if got := dst.RGBAAt(2, 1).A; got < 126 || got > 129 {
+		t.Errorf("Half covered pixel alpha = %d, want 128", got)
+	}
+	if got := dst.RGBAAt(1, 2).A; got != 0 {
+		t.Errorf("Uncovered pixel alpha = %d, want 0", got)
+	}
+}
+
+func TestFillNonZero(t *testing.T) {
+	dst := image.NewRGBA(image.Rect(0, 0, 10, 10))
+	white := func(int, int) (float64, float64, float64, float64) { return 1, 1, 1, 1 }
+
+	// An inner contour in the opposite direction cuts a hole
+	outer := []point{{0, 0}, {10, 0}, {10, 10}, {0, 10}}
+	inner := []point{{3, 3}, {3, 7}, {7, 7}, {7, 3}}
+	fill(dst, [][]point{outer, inner}, white)
+
+	if dst.RGBAAt(1, 1).A != 255 || dst.RGBAAt(5, 5).A != 0 {
+		t.Errorf("Ring alpha = %d outside the hole and %d inside, want 255 and 0", dst.RGBAAt(1, 1).A, dst.RGBAAt(5, 5).A)
+	}
+}
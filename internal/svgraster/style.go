@@ -0,0 +1,239 @@
+package svgraster
+
+import (
+	"math"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// style holds the computed properties of an element, including geometry
+// such as x and rx, which CSS may also set.
+type style map[string]string
+
+func (s style) get(property string) string {
+	return s[property]
+}
+
+// inherited are the properties elements inherit from their parent.
+var inherited = []string{
+	"fill", "fill-opacity", "stroke", "stroke-width", "stroke-opacity",
+	"font-size", "font-weight", "font-style", "text-anchor", "letter-spacing",
+}
+
+// cssRule is a rule selecting elements by name, or by class when the
+// selector starts with a dot.
+type cssRule struct {
+	selector     string
+	declarations map[string]string
+}
+
+// computeStyle returns the properties of n: inherited from parent, then its
+// presentation attributes, CSS rules by element name, by class, and its
+// style attribute, each overriding the previous.
+func (r *renderer) computeStyle(n *node, parent style) style {
+	s := style{"fill": "black", "stroke-width": "1", "font-size": "16"}
+	for _, property := range inherited {
+		if v, ok := parent[property]; ok {
+			s[property] = v
+		}
+	}
+	for name, value := range n.attrs {
+		s[name] = value
+	}
+
+	classes := strings.Fields(n.attrs["class"])
+	for _, byClass := range []bool{false, true} {
+		for _, rule := range r.rules {
+			name, isClass := strings.CutPrefix(rule.selector, ".")
+			if isClass != byClass {
+				continue
+			}
+			if (isClass && slices.Contains(classes, name)) || (!isClass && (name == n.name || name == "*")) {
+				for property, value := range rule.declarations {
+					s[property] = value
+				}
+			}
+		}
+	}
+
+	for property, value := range parseDeclarations(n.attrs["style"]) {
+		s[property] = value
+	}
+	return s
+}
+
+// parseCSS parses a style sheet into rules. Selectors other than element
+// names and single classes, and at-rules, are ignored.
+func parseCSS(css string) []cssRule {
+	for {
+		start := strings.Index(css, "/*")
+		if start < 0 {
+			break
+		}
+		end := strings.Index(css[start+2:], "*/")
+		if end < 0 {
+			css = css[:start]
+			break
+		}
+		css = css[:start] + css[start+2+end+2:]
+	}
+
+	var rules []cssRule
+	for block := range strings.SplitSeq(css, "}") {
+		selectors, body, ok := strings.Cut(block, "{")
+		if !ok {
+			continue
+		}
+		declarations := parseDeclarations(body)
+		for selector := range strings.SplitSeq(selectors, ",") {
+			selector = strings.TrimSpace(selector)
+			if selector == "" || strings.ContainsAny(selector, " >+~:[@") {
+				continue
+			}
+			rules = append(rules, cssRule{selector: selector, declarations: declarations})
+		}
+	}
+	return rules
+}
+
+// parseDeclarations parses "property: value" pairs separated by semicolons.
+func parseDeclarations(s string) map[string]string {
+	declarations := make(map[string]string)
+	for declaration := range strings.SplitSeq(s, ";") {
+		property, value, ok := strings.Cut(declaration, ":")
+		if !ok {
+			continue
+		}
+		property = strings.ToLower(strings.TrimSpace(property))
+		value = strings.TrimSpace(strings.TrimSuffix(strings.TrimSpace(value), "!important"))
+		if property != "" && value != "" {
+			declarations[property] = value
+		}
+	}
+	return declarations
+}
+
+// rgba is a non-premultiplied color with components in [0, 1].
+type rgba struct {
+	r, g, b, a float64
+}
+
+var namedColors = map[string]rgba{
+	"black":       {0, 0, 0, 1},
+	"white":       {1, 1, 1, 1},
+	"transparent": {0, 0, 0, 0},
+}
+
+// parseColor parses a #rgb, #rrggbb or #rrggbbaa color, or a few names.
+func parseColor(s string) (rgba, bool) {
+	s = strings.ToLower(strings.TrimSpace(s))
+	if c, ok := namedColors[s]; ok {
+		return c, true
+	}
+	hex, ok := strings.CutPrefix(s, "#")
+	if !ok {
+		return rgba{}, false
+	}
+	if len(hex) == 3 || len(hex) == 4 {
+		var long strings.Builder
+		for _, c := range hex {
+			long.WriteString(strings.Repeat(string(c), 2))
+		}
+		hex = long.String()
+	}
+	if len(hex) == 6 {
+		hex += "ff"
+	}
+	v, err := strconv.ParseUint(hex, 16, 32)
+	if len(hex) != 8 || err != nil {
+		return rgba{}, false
+	}
+	return rgba{
+		r: float64(v>>24&0xff) / 255,
+		g: float64(v>>16&0xff) / 255,
+		b: float64(v>>8&0xff) / 255,
+		a: float64(v&0xff) / 255,
+	}, true
+}
+
+// gradient is a linear gradient in object bounding box units.
+type gradient struct {
+	x1, y1, x2, y2 float64
+	stops          []gradientStop
+}
+
+type gradientStop struct {
+	offset float64
+	color  rgba
+}
+
+func parseGradient(n *node) *gradient {
+	g := &gradient{
+		x1: number(n.attrs["x1"], 0),
+		y1: number(n.attrs["y1"], 0),
+		x2: number(n.attrs["x2"], 1),
+		y2: number(n.attrs["y2"], 0),
+	}
+	offset := 0.0
+	for _, child := range n.children {
+		if child.name != "stop" {
+			continue
+		}
+		s := style(child.attrs)
+		for property, value := range parseDeclarations(child.attrs["style"]) {
+			s[property] = value
+		}
+		c, ok := parseColor(s.get("stop-color"))
+		if !ok {
+			c = namedColors["black"]
+		}
+		c.a *= number(s.get("stop-opacity"), 1)
+		// Offsets never decrease
+		offset = max(offset, min(number(s.get("offset"), 0), 1))
+		g.stops = append(g.stops, gradientStop{offset: offset, color: c})
+	}
+	return g
+}
+
+// paint returns the gradient over the box with corners bounds, in pixels.
+func (g *gradient) paint(bounds []point, opacity float64) paint {
+	minX, minY := min(bounds[0].x, bounds[1].x), min(bounds[0].y, bounds[1].y)
+	w, h := math.Abs(bounds[1].x-bounds[0].x), math.Abs(bounds[1].y-bounds[0].y)
+	dx, dy := g.x2-g.x1, g.y2-g.y1
+	norm := dx*dx + dy*dy
+
+	return func(x, y int) (float64, float64, float64, float64) {
+		t := 0.0
+		if norm > 0 && w > 0 && h > 0 {
+			u, v := (float64(x)+0.5-minX)/w, (float64(y)+0.5-minY)/h
+			t = min(max(((u-g.x1)*dx+(v-g.y1)*dy)/norm, 0), 1)
+		}
+		c := g.at(t)
+		return c.r, c.g, c.b, c.a * opacity
+	}
+}
+
+// at returns the color at offset t.
+func (g *gradient) at(t float64) rgba {
+	if t <= g.stops[0].offset {
+		return g.stops[0].color
+	}
+	for i := 1; i < len(g.stops); i++ {
+		a, b := g.stops[i-1], g.stops[i]
+		if t > b.offset {
+			continue
+		}
+		f := 0.0
+		if b.offset > a.offset {
+			f = (t - a.offset) / (b.offset - a.offset)
+		}
+		return rgba{
+			r: a.color.r + (b.color.r-a.color.r)*f,
+			g: a.color.g + (b.color.g-a.color.g)*f,
+			b: a.color.b + (b.color.b-a.color.b)*f,
+			a: a.color.a + (b.color.a-a.color.a)*f,
+		}
+	}
+	return g.stops[len(g.stops)-1].color
+}
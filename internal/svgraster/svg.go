@@ -0,0 +1,323 @@
+// Package svgraster renders the SVG badges of gh-oss-stats as images without
+// a browser or external libraries. It supports the subset of SVG the badge
+// templates use: rect, line, text and tspan elements, g translations, linear
+// gradients and CSS rules selecting elements by name or class. Text is set
+// in DejaVu Sans whatever the font-family, without kerning.
+package svgraster
+
+import (
+	"bytes"
+	"encoding/xml"
+	"fmt"
+	"image"
+	"math"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// MaxSize is the largest width or height of a rasterized image, in pixels.
+const MaxSize = 16384
+
+// node is an element of an SVG document, or a piece of character data when
+// name is empty.
+type node struct {
+	name     string
+	attrs    map[string]string
+	text     string
+	children []*node
+}
+
+// Rasterize renders an SVG document with scale pixels per user unit, e.g. 2
+// for HiDPI screens. Drawing elements outside the supported subset are an
+// error.
+func Rasterize(svg []byte, scale float64) (*image.RGBA, error) {
+	if scale <= 0 || math.IsInf(scale, 0) || math.IsNaN(scale) {
+		return nil, fmt.Errorf("invalid scale: %v", scale)
+	}
+
+	root, err := parse(svg)
+	if err != nil {
+		return nil, err
+	}
+	if root.name != "svg" {
+		return nil, fmt.Errorf("root element is <%s>, want <svg>", root.name)
+	}
+
+	width, height := length(root.attrs["width"], 0), length(root.attrs["height"], 0)
+	vbX, vbY, vbW, vbH := 0.0, 0.0, width, height
+	if vb := strings.Fields(strings.ReplaceAll(root.attrs["viewBox"], ",", " ")); len(vb) == 4 {
+		vbX, vbY, vbW, vbH = length(vb[0], 0), length(vb[1], 0), length(vb[2], 0), length(vb[3], 0)
+		if width == 0 || height == 0 {
+			width, height = vbW, vbH
+		}
+	}
+	if width <= 0 || height <= 0 || vbW <= 0 || vbH <= 0 {
+		return nil, fmt.Errorf("svg has no size")
+	}
+
+	w, h := int(math.Ceil(width*scale)), int(math.Ceil(height*scale))
+	if w > MaxSize || h > MaxSize {
+		return nil, fmt.Errorf("image of %dx%d pixels is larger than %dx%d", w, h, MaxSize, MaxSize)
+	}
+
+	r := &renderer{
+		dst:       image.NewRGBA(image.Rect(0, 0, w, h)),
+		gradients: make(map[string]*gradient),
+	}
+	r.collect(root)
+	t := transform{sx: width * scale / vbW, sy: height * scale / vbH}
+	t.tx, t.ty = -vbX*t.sx, -vbY*t.sy
+
+	if err := r.render(root, r.computeStyle(root, nil), t); err != nil {
+		return nil, err
+	}
+	return r.dst, nil
+}
+
+// parse reads an SVG document into a tree. Parsing is lenient, as badge
+// templates do not escape all of their text.
+func parse(svg []byte) (*node, error) {
+	d := xml.NewDecoder(bytes.NewReader(svg))
+	d.Strict = false
+	d.AutoClose = xml.HTMLAutoClose
+	d.Entity = xml.HTMLEntity
+
+	var root *node
+	var stack []*node
+	for {
+		tok, err := d.Token()
+		if err != nil {
+			if root != nil && len(stack) == 0 {
+				return root, nil
+			}
+			return nil, fmt.Errorf("parsing svg: %w", err)
+		}
+
+		switch tok := tok.(type) {
+		case xml.StartElement:
+			n := &node{name: tok.Name.Local, attrs: make(map[string]string)}
+			for _, attr := range tok.Attr {
+				n.attrs[attr.Name.Local] = attr.Value
+			}
+			if len(stack) > 0 {
+				parent := stack[len(stack)-1]
+				parent.children = append(parent.children, n)
+			} else if root == nil {
+				root = n
+			}
+			stack = append(stack, n)
+		case xml.EndElement:
+			if len(stack) > 0 {
+				stack = stack[:len(stack)-1]
+			}
+			if len(stack) == 0 && root != nil {
+				return root, nil
+			}
+		case xml.CharData:
+			if len(stack) > 0 {
+				parent := stack[len(stack)-1]
+				parent.children = append(parent.children, &node{text: string(tok)})
+			}
+		}
+	}
+}
+
+// transform maps user units to pixels: x*sx + tx, y*sy + ty.
+type transform struct {
+	sx, sy, tx, ty float64
+}
+
+func (t transform) apply(p point) point {
+	return point{p.x*t.sx + t.tx, p.y*t.sy + t.ty}
+}
+
+func (t transform) translate(x, y float64) transform {
+	t.tx += x * t.sx
+	t.ty += y * t.sy
+	return t
+}
+
+func (t transform) path(polys ...[]point) [][]point {
+	path := make([][]point, len(polys))
+	for i, poly := range polys {
+		path[i] = make([]point, len(poly))
+		for j, p := range poly {
+			path[i][j] = t.apply(p)
+		}
+	}
+	return path
+}
+
+type renderer struct {
+	dst       *image.RGBA
+	rules     []cssRule
+	gradients map[string]*gradient
+}
+
+// collect gathers the CSS rules and gradients of the document.
+func (r *renderer) collect(n *node) {
+	switch n.name {
+	case "style":
+		var css strings.Builder
+		for _, child := range n.children {
+			css.WriteString(child.text)
+		}
+		r.rules = append(r.rules, parseCSS(css.String())...)
+	case "linearGradient":
+		if id := n.attrs["id"]; id != "" {
+			r.gradients[id] = parseGradient(n)
+		}
+	}
+	for _, child := range n.children {
+		if child.name != "" {
+			r.collect(child)
+		}
+	}
+}
+
+// render draws n and its children.
+func (r *renderer) render(n *node, s style, t transform) error {
+	switch n.name {
+	case "svg", "g":
+		if n.name == "g" {
+			t = translation(n.attrs["transform"], t)
+		}
+		for _, child := range n.children {
+			if child.name == "" {
+				continue
+			}
+			if err := r.render(child, r.computeStyle(child, s), t); err != nil {
+				return err
+			}
+		}
+	case "rect":
+		r.drawRect(s, t)
+	case "line":
+		r.drawLine(s, t)
+	case "text":
+		return r.drawText(n, s, t)
+	case "defs", "style", "title", "desc", "metadata", "linearGradient":
+	default:
+		return fmt.Errorf("unsupported element <%s>", n.name)
+	}
+	return nil
+}
+
+// translation applies a transform attribute of translations to t.
+func translation(attr string, t transform) transform {
+	for attr = strings.TrimSpace(attr); attr != ""; attr = strings.TrimSpace(attr) {
+		open, end := strings.Index(attr, "("), strings.Index(attr, ")")
+		if open < 0 || end < open {
+			break
+		}
+		if strings.TrimSpace(attr[:open]) == "translate" {
+			args := strings.Fields(strings.ReplaceAll(attr[open+1:end], ",", " "))
+			if len(args) > 0 {
+				y := 0.0
+				if len(args) > 1 {
+					y = length(args[1], 0)
+				}
+				t = t.translate(length(args[0], 0), y)
+			}
+		}
+		attr = attr[end+1:]
+	}
+	return t
+}
+
+func (r *renderer) drawRect(s style, t transform) {
+	x, y := length(s.get("x"), 0), length(s.get("y"), 0)
+	w, h := length(s.get("width"), 0), length(s.get("height"), 0)
+	if w <= 0 || h <= 0 {
+		return
+	}
+	rx, ry := s.get("rx"), s.get("ry")
+	if rx == "" {
+		rx = ry
+	}
+	if ry == "" {
+		ry = rx
+	}
+	radiusX, radiusY := length(rx, 0), length(ry, 0)
+
+	if p := r.paint(s, "fill", t.path([]point{{x, y}, {x + w, y + h}})[0]); p != nil {
+		fill(r.dst, t.path(roundedRect(x, y, w, h, radiusX, radiusY)), p)
+	}
+
+	strokeWidth := length(s.get("stroke-width"), 0)
+	if p := r.paint(s, "stroke", t.path([]point{{x, y}, {x + w, y + h}})[0]); p != nil && strokeWidth > 0 {
+		half := strokeWidth / 2
+		outer := roundedRect(x-half, y-half, w+strokeWidth, h+strokeWidth, radiusX+half, radiusY+half)
+		inner := roundedRect(x+half, y+half, w-strokeWidth, h-strokeWidth, radiusX-half, radiusY-half)
+		if w > strokeWidth && h > strokeWidth {
+			slices.Reverse(inner)
+			fill(r.dst, t.path(outer, inner), p)
+		} else {
+			fill(r.dst, t.path(outer), p)
+		}
+	}
+}
+
+func (r *renderer) drawLine(s style, t transform) {
+	a := point{length(s.get("x1"), 0), length(s.get("y1"), 0)}
+	b := point{length(s.get("x2"), 0), length(s.get("y2"), 0)}
+	strokeWidth := length(s.get("stroke-width"), 0)
+	d := math.Hypot(b.x-a.x, b.y-a.y)
+	if d == 0 || strokeWidth <= 0 {
+		return
+	}
+
+	// A line is a rectangle along its direction, with butt caps
+	nx, ny := -(b.y-a.y)/d*strokeWidth/2, (b.x-a.x)/d*strokeWidth/2
+	quad := []point{{a.x + nx, a.y + ny}, {b.x + nx, b.y + ny}, {b.x - nx, b.y - ny}, {a.x - nx, a.y - ny}}
+	path := t.path(quad)
+	if p := r.paint(s, "stroke", path[0]); p != nil {
+		fill(r.dst, path, p)
+	}
+}
+
+// paint returns the paint of the fill or stroke property, or nil for none.
+// bounds holds the corners of the painted box, for gradients.
+func (r *renderer) paint(s style, property string, bounds []point) paint {
+	value := strings.TrimSpace(s.get(property))
+	opacity := number(s.get(property+"-opacity"), 1) * number(s.get("opacity"), 1)
+	if value == "" || value == "none" || opacity <= 0 {
+		return nil
+	}
+
+	if id, ok := strings.CutPrefix(value, "url(#"); ok {
+		g := r.gradients[strings.TrimSuffix(strings.TrimSpace(id), ")")]
+		if g == nil || len(g.stops) == 0 {
+			return nil
+		}
+		return g.paint(bounds, opacity)
+	}
+
+	c, ok := parseColor(value)
+	if !ok {
+		return nil
+	}
+	return func(int, int) (float64, float64, float64, float64) {
+		return c.r, c.g, c.b, c.a * opacity
+	}
+}
+
+// length parses a length in user units, ignoring a px unit, or returns def.
+func length(s string, def float64) float64 {
+	s = strings.TrimSuffix(strings.TrimSpace(s), "px")
+	v, err := strconv.ParseFloat(s, 64)
+	if err != nil {
+		return def
+	}
+	return v
+}
+
+// number parses a number or percentage, or returns def.
+func number(s string, def float64) float64 {
+	s = strings.TrimSpace(s)
+	if p, ok := strings.CutSuffix(s, "%"); ok {
+		return length(p, def*100) / 100
+	}
+	return length(s, def)
+}
@@ -0,0 +1,164 @@
+package svgraster
+
+import (
+	"image"
+	"image/color"
+	"strings"
+	"testing"
+)
+
+func rasterize(t *testing.T, svg string, scale float64) *image.RGBA {
+	t.Helper()
+	img, err := Rasterize([]byte(svg), scale)
+	if err != nil {
+		t.Fatalf("Rasterize() error = %v", err)
+	}
+	return img
+}
+
+func TestRasterizeRect(t *testing.T) {
+	img := rasterize(t, `<svg width="40" height="20" xmlns="http://www.w3.org/2000/svg">
+  <rect width="40" height="20" rx="8" fill="#ff0000"/>
+</svg>`, 2)
+
+	if got := img.Bounds().Size(); got != image.Pt(80, 40) {
+		t.Fatalf("Size = %v, want 80x40", got)
+	}
+	if got := img.RGBAAt(40, 20); got != (color.RGBA{255, 0, 0, 255}) {
+		t.Errorf("Center = %v, want opaque red", got)
+	}
+	if got := img.RGBAAt(0, 0); got.A != 0 {
+		t.Errorf("Rounded corner = %v, want transparent", got)
+	}
+	if got := img.RGBAAt(40, 0); got.A != 255 {
+		t.Errorf("Top edge = %v, want opaque", got)
+	}
+}
+
+func TestRasterizeStyles(t *testing.T) {
+	img := rasterize(t, `<svg width="50" height="10" viewBox="0 0 50 10" fill="none">
+  <defs>
+    <style>
+      /* Classes override element rules, and both override attributes */
+      rect { fill: #0000ff; }
+      .green { fill: #00ff00; }
+    </style>
+    <linearGradient id="fade" x1="0" y1="0" x2="0" y2="1">
+      <stop offset="0%" stop-color="#ffffff"/>
+      <stop offset="100%" stop-color="#000000"/>
+    </linearGradient>
+  </defs>
+  <rect x="0" width="10" height="10" fill="#ff0000"/>
+  <rect x="10" width="10" height="10" class="green" fill="#ff0000"/>
+  <rect x="20" width="10" height="10" class="green" style="fill: #ff0000"/>
+  <g transform="translate(30, 0)">
+    <rect width="10" height="10" style="fill: url(#fade)"/>
+  </g>
+  <text x="45" y="10">inherits fill none</text>
+</svg>`, 1)
+
+	tests := []struct {
+		x, y int
+		want color.RGBA
+	}{
+		{5, 5, color.RGBA{0, 0, 255, 255}},
+		{15, 5, color.RGBA{0, 255, 0, 255}},
+		{25, 5, color.RGBA{255, 0, 0, 255}},
+		{35, 0, color.RGBA{242, 242, 242, 255}},
+		{35, 9, color.RGBA{13, 13, 13, 255}},
+		{47, 8, color.RGBA{}},
+	}
+	for _, tt := range tests {
+		if got := img.RGBAAt(tt.x, tt.y); got != tt.want {
+			t.Errorf("Pixel (%d, %d) = %v, want %v", tt.x, tt.y, got, tt.want)
+		}
+	}
+}
+
+func TestRasterizeText(t *testing.T) {
+	img := rasterize(t, `<svg width="200" height="40">
+  <text x="100" y="28" font-size="20" font-weight="bold" fill="#ffffff" text-anchor="middle">
+    OSS <tspan fill="#ff0000">42</tspan>
+  </text>
+</svg>`, 1)
+
+	// The inked pixels are centered on x, and the tspan keeps its color
+	minX, maxX, red := img.Bounds().Max.X, 0, false
+	for y := range 40 {
+		for x := range 200 {
+			c := img.RGBAAt(x, y)
+			if c.A == 0 {
+				continue
+			}
+			minX, maxX = min(minX, x), max(maxX, x)
+			red = red || (c.R == 255 && c.G == 0 && c.A == 255)
+		}
+	}
+	if minX >= maxX {
+		t.Fatal("Text was not drawn")
+	}
+	if center := (minX + maxX) / 2; center < 97 || center > 103 {
+		t.Errorf("Text is centered on x = %d, want 100", center)
+	}
+	if !red {
+		t.Error("The tspan was not drawn in its fill")
+	}
+}
+
+func TestRasterizeErrors(t *testing.T) {
+	tests := []struct {
+		name  string
+		svg   string
+		scale float64
+		want  string
+	}{
+		{"zero scale", `<svg width="10" height="10"/>`, 0, "invalid scale"},
+		{"not svg", `<html></html>`, 1, "want <svg>"},
+		{"no size", `<svg></svg>`, 1, "no size"},
+		{"too large", `<svg width="10000" height="10"/>`, 2, "larger than"},
+		{"unsupported", `<svg width="10" height="10"><circle r="5"/></svg>`, 1, "unsupported element <circle>"},
+		{"truncated", `<svg width="10" height="10"><rect`, 1, "parsing svg"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := Rasterize([]byte(tt.svg), tt.scale)
+			if err == nil || !strings.Contains(err.Error(), tt.want) {
+				t.Errorf("Rasterize() error = %v, want %q", err, tt.want)
+			}
+		})
+	}
+}
+
+func TestCollapseWhitespace(t *testing.T) {
+	runs := []textRun{{text: "\n    "}, {text: "OSS"}, {text: "\n    "}, {text: " · "}, {text: "42\t"}, {text: " \n"}}
+	collapseWhitespace(runs)
+
+	var got []string
+	for _, run := range runs {
+		got = append(got, run.text)
+	}
+	if want := []string{"", "OSS", " ", "· ", "42", ""}; strings.Join(got, "|") != strings.Join(want, "|") {
+		t.Errorf("collapseWhitespace() = %q, want %q", got, want)
+	}
+}
+
+func TestParseColor(t *testing.T) {
+	tests := []struct {
+		in   string
+		want rgba
+		ok   bool
+	}{
+		{"#ffffff", rgba{1, 1, 1, 1}, true},
+		{"#F00", rgba{1, 0, 0, 1}, true},
+		{"#00000000", rgba{0, 0, 0, 0}, true},
+		{"white", rgba{1, 1, 1, 1}, true},
+		{"#12345", rgba{}, false},
+		{"red-ish", rgba{}, false},
+	}
+	for _, tt := range tests {
+		got, ok := parseColor(tt.in)
+		if got != tt.want || ok != tt.ok {
+			t.Errorf("parseColor(%q) = %v, %v, want %v, %v", tt.in, got, ok, tt.want, tt.ok)
+		}
+	}
+}
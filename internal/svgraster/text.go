@@ -0,0 +1,145 @@
+package svgraster
+
+import (
+	"strconv"
+	"strings"
+)
+
+// textRun is a part of a text element set in one style.
+type textRun struct {
+	text  string
+	style style
+}
+
+// italicSkew slants glyphs for font-style italic or oblique.
+const italicSkew = 0.2
+
+// drawText lays out a text element and its tspans on one line from its x and
+// y, aligned by text-anchor.
+func (r *renderer) drawText(n *node, s style, t transform) error {
+	regular, bold, err := faces()
+	if err != nil {
+		return err
+	}
+
+	runs := r.textRuns(n, s, nil)
+	collapseWhitespace(runs)
+
+	type layout struct {
+		face    *face
+		size    float64
+		spacing float64
+		skew    float64
+		runes   []rune
+		width   float64
+		style   style
+	}
+	var layouts []layout
+	total := 0.0
+	for _, run := range runs {
+		if run.text == "" {
+			continue
+		}
+		l := layout{face: regular, size: length(run.style.get("font-size"), 16), style: run.style, runes: []rune(run.text)}
+		if isBold(run.style.get("font-weight")) {
+			l.face = bold
+		}
+		if fs := run.style.get("font-style"); fs == "italic" || fs == "oblique" {
+			l.skew = italicSkew
+		}
+		l.spacing = letterSpacing(run.style.get("letter-spacing"), l.size)
+		for _, c := range l.runes {
+			l.width += l.face.glyph(c).advance*l.size/l.face.unitsPerEm + l.spacing
+		}
+		total += l.width
+		layouts = append(layouts, l)
+	}
+
+	x, y := length(s.get("x"), 0), length(s.get("y"), 0)
+	switch s.get("text-anchor") {
+	case "middle":
+		x -= total / 2
+	case "end":
+		x -= total
+	}
+
+	for _, l := range layouts {
+		scale := l.size / l.face.unitsPerEm
+		var path [][]point
+		pen := x
+		for _, c := range l.runes {
+			g := l.face.glyph(c)
+			path = g.outline(path, scale, pen, y, l.skew, t.apply)
+			pen += g.advance*scale + l.spacing
+		}
+
+		top := y - l.face.ascent*scale
+		bottom := y - l.face.descent*scale
+		bounds := []point{t.apply(point{x, top}), t.apply(point{x + l.width, bottom})}
+		if p := r.paint(l.style, "fill", bounds); p != nil && len(path) > 0 {
+			fill(r.dst, path, p)
+		}
+		x += l.width
+	}
+	return nil
+}
+
+// textRuns returns the character data of a text element and its tspans.
+func (r *renderer) textRuns(n *node, s style, runs []textRun) []textRun {
+	for _, child := range n.children {
+		switch child.name {
+		case "":
+			runs = append(runs, textRun{text: child.text, style: s})
+		case "tspan":
+			runs = r.textRuns(child, r.computeStyle(child, s), runs)
+		}
+	}
+	return runs
+}
+
+// collapseWhitespace applies the default white space handling of SVG across
+// runs: line breaks and tabs become spaces, consecutive spaces collapse into
+// one and leading and trailing spaces are removed.
+func collapseWhitespace(runs []textRun) {
+	space := true // Drops leading spaces
+	last := -1    // Run holding the last character
+	for i := range runs {
+		var b strings.Builder
+		for _, c := range runs[i].text {
+			if c == '\n' || c == '\r' || c == '\t' || c == ' ' {
+				if space {
+					continue
+				}
+				c, space = ' ', true
+			} else {
+				space = false
+			}
+			b.WriteRune(c)
+		}
+		runs[i].text = b.String()
+		if runs[i].text != "" {
+			last = i
+		}
+	}
+	if last >= 0 {
+		runs[last].text = strings.TrimSuffix(runs[last].text, " ")
+	}
+}
+
+// isBold reports whether a font-weight selects the bold face.
+func isBold(weight string) bool {
+	switch weight = strings.TrimSpace(weight); weight {
+	case "bold", "bolder":
+		return true
+	}
+	w, err := strconv.Atoi(weight)
+	return err == nil && w >= 600
+}
+
+// letterSpacing parses a letter-spacing in user units or ems of size.
+func letterSpacing(s string, size float64) float64 {
+	if em, ok := strings.CutSuffix(strings.TrimSpace(s), "em"); ok {
+		return length(em, 0) * size
+	}
+	return length(s, 0)
+}
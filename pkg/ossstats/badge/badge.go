@@ -4,8 +4,8 @@ import (
 	"bytes"
 	"errors"
 	"fmt"
-	"sort"
 	"text/template"
+	"time"
 
 	"github.com/mabd-dev/gh-oss-stats/pkg/ossstats"
 	bt "github.com/mabd-dev/gh-oss-stats/pkg/ossstats/badge/badgeTemplates"
@@ -22,6 +22,7 @@ type templateData struct {
 	TotalCommits     string
 	TotalLines       string
 	CompactText      string // For compact badge: "n projects | m PRs"
+	DeltaText        string // PRs merged since BadgeOptions.Previous, e.g. "+4 PRs in the last 30 days"
 	TopContributions []contributionData
 }
 
@@ -48,37 +49,7 @@ func RenderSVG(stats *ossstats.Stats, opts BadgeOptions) (string, error) {
 	}
 
 	// Get theme colors
-	colors := GetThemeColors(opts.Theme)
-	if opts.CustomColors != nil {
-		c := opts.CustomColors
-		if c.Background != "" {
-			colors.Background = c.Background
-		}
-		if c.BackgroundAlt != "" {
-			colors.BackgroundAlt = c.BackgroundAlt
-		}
-		if c.Text != "" {
-			colors.Text = c.Text
-		}
-		if c.TextSecondary != "" {
-			colors.TextSecondary = c.TextSecondary
-		}
-		if c.Border != "" {
-			colors.Border = c.Border
-		}
-		if c.Accent != "" {
-			colors.Accent = c.Accent
-		}
-		if c.Positive != "" {
-			colors.Positive = c.Positive
-		}
-		if c.Negative != "" {
-			colors.Negative = c.Negative
-		}
-		if c.Star != "" {
-			colors.Star = c.Star
-		}
-	}
+	colors := opts.Colors()
 
 	// Prepare base template data
 	data := templateData{
@@ -91,6 +62,10 @@ func RenderSVG(stats *ossstats.Stats, opts BadgeOptions) (string, error) {
 		CompactText:   fmt.Sprintf("%s projects | %s PRs", formatNumber(stats.Summary.TotalProjects), formatNumber(stats.Summary.TotalPRsMerged)),
 	}
 
+	if opts.Previous != nil {
+		data.DeltaText = formatDelta(stats, opts.Previous)
+	}
+
 	// Add top contributions for detailed view
 	if opts.Style == StyleDetailed {
 		data.TopContributions = getTopContributions(stats, opts.SortBy, opts.Limit)
@@ -134,6 +109,37 @@ func formatNumber(n int) string {
 	return fmt.Sprintf("%d", n)
 }
 
+// formatDelta describes the PRs merged between previous and stats, e.g.
+// "+4 PRs in the last 30 days". Returns "" when no PRs were merged in between.
+func formatDelta(stats, previous *ossstats.Stats) string {
+	newPRs := stats.Summary.TotalPRsMerged - previous.Summary.TotalPRsMerged
+	if newPRs <= 0 {
+		return ""
+	}
+
+	unit := "PRs"
+	if newPRs == 1 {
+		unit = "PR"
+	}
+	return fmt.Sprintf("+%s %s %s", formatNumber(newPRs), unit, deltaPeriod(previous.GeneratedAt, stats.GeneratedAt))
+}
+
+// deltaPeriod describes the gap between two snapshots, e.g. "in the last 30
+// days", rather than a calendar period the gap may straddle.
+func deltaPeriod(from, to time.Time) string {
+	elapsed := to.Sub(from)
+	days := int((elapsed + 12*time.Hour) / (24 * time.Hour))
+	switch {
+	case from.IsZero() || elapsed < 0:
+		return "since last run"
+	case days <= 1:
+		return "in the last day"
+	case days <= 90:
+		return fmt.Sprintf("in the last %d days", days)
+	}
+	return "since " + from.UTC().Format("Jan 2, 2006")
+}
+
 // formatStars formats a star count with appropriate suffix
 func formatStars(n int) string {
 	return formatNumber(n)
@@ -146,18 +152,7 @@ func getTopContributions(stats *ossstats.Stats, sortBy SortBy, limit int) []cont
 	copy(contributions, stats.Contributions)
 
 	// Sort based on criteria
-	sort.Slice(contributions, func(i, j int) bool {
-		switch sortBy {
-		case SortByStars:
-			return contributions[i].Stars > contributions[j].Stars
-		case SortByCommits:
-			return contributions[i].Commits > contributions[j].Commits
-		case SortByPRs:
-			fallthrough
-		default:
-			return contributions[i].PRsMerged > contributions[j].PRsMerged
-		}
-	})
+	SortContributions(contributions, sortBy)
 
 	// Get top N
 	if limit > len(contributions) {
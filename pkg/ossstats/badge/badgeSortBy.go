@@ -1,8 +1,12 @@
 package badge
 
 import (
+	"cmp"
 	"fmt"
+	"slices"
 	"strings"
+
+	"github.com/mabd-dev/gh-oss-stats/pkg/ossstats"
 )
 
 var DefaultSortBy = SortByPRs
@@ -28,3 +32,18 @@ func SortByFromName(name string) (SortBy, error) {
 	err := fmt.Errorf("invalid badge sort: %s (must be: prs, stars, commits)", name)
 	return DefaultSortBy, err
 }
+
+// SortContributions sorts contributions in place by sortBy, highest first.
+// Contributions that tie keep their order.
+func SortContributions(contributions []ossstats.Contribution, sortBy SortBy) {
+	slices.SortStableFunc(contributions, func(a, b ossstats.Contribution) int {
+		switch sortBy {
+		case SortByStars:
+			return cmp.Compare(b.Stars, a.Stars)
+		case SortByCommits:
+			return cmp.Compare(b.Commits, a.Commits)
+		default:
+			return cmp.Compare(b.PRsMerged, a.PRsMerged)
+		}
+	})
+}
@@ -39,7 +39,12 @@ const DefaultSummary = `<svg
       .stat-label {
         font-size: 11px;
         fill: {{.Colors.TextSecondary}};
-      }
+      }{{if .DeltaText}}
+      .delta {
+        font-size: 11px;
+        font-weight: 600;
+        fill: {{.Colors.Positive}};
+      }{{end}}
     </style>
   </defs>
   <!-- Background -->
@@ -55,7 +60,8 @@ const DefaultSummary = `<svg
   <text class="stat-value" x="76" y="123" text-anchor="middle">{{.TotalProjects}}</text>
   <text class="stat-label" x="76" y="144" text-anchor="middle">PROJECTS</text>
   <text class="stat-value" x="200" y="123" text-anchor="middle">{{.TotalPRs}}</text>
-  <text class="stat-label" x="200" y="144" text-anchor="middle">PRS MERGED</text>
+  <text class="stat-label" x="200" y="144" text-anchor="middle">PRS MERGED</text>{{if .DeltaText}}
+  <text class="delta" x="200" y="182" text-anchor="middle">{{.DeltaText}}</text>{{end}}
   <text class="stat-value" x="324" y="123" text-anchor="middle">{{.TotalLines}}</text>
   <text class="stat-label" x="324" y="144" text-anchor="middle">LINES CHANGED</text>
 </svg>
@@ -193,7 +199,15 @@ const DefaultDetailed = `
       font-family="Inter, system-ui, -apple-system, sans-serif"
       font-size="28"
       font-weight="bold"
-      letter-spacing="0em">{{.TotalPRs}}</text>
+      letter-spacing="0em">{{.TotalPRs}}</text>{{if .DeltaText}}
+    <text
+      x="336"
+      y="184"
+      fill="{{.Colors.Positive}}"
+      font-family="Inter, system-ui, -apple-system, sans-serif"
+      font-size="11"
+      font-weight="600"
+      letter-spacing="0em">{{.DeltaText}}</text>{{end}}
   </g>
 
   <!-- Lines Changed Card -->
@@ -66,7 +66,12 @@ const TextBasedSummary = `<svg
       .stat-label {
         font-size: 11px;
         fill: {{.Colors.TextSecondary}};
-      }
+      }{{if .DeltaText}}
+      .delta {
+        font-size: 11px;
+        font-weight: 700;
+        fill: {{.Colors.Positive}};
+      }{{end}}
     </style>
   </defs>
   <!-- Background -->
@@ -78,7 +83,8 @@ const TextBasedSummary = `<svg
   <text class="stat-value" x="60" y="127" text-anchor="middle">{{.TotalProjects}}</text>
   <text class="stat-label" x="60" y="141" text-anchor="middle">PROJECTS</text>
   <text class="stat-value" x="180" y="127" text-anchor="middle">{{.TotalPRs}}</text>
-  <text class="stat-label" x="180" y="141" text-anchor="middle">PRs MERGED</text>
+  <text class="stat-label" x="180" y="141" text-anchor="middle">PRs MERGED</text>{{if .DeltaText}}
+  <text class="delta" x="180" y="160" text-anchor="middle">{{.DeltaText}}</text>{{end}}
   <text class="stat-value" x="300" y="127" text-anchor="middle">{{.TotalLines}}</text>
   <text class="stat-label" x="300" y="141" text-anchor="middle">LINES CHANGED</text>
 </svg>`
@@ -155,7 +161,12 @@ const TextBasedDetailed = `
       .repo-meta {
         font-size: 12px;
         fill: {{.Colors.TextSecondary}};
-      }
+      }{{if .DeltaText}}
+      .delta {
+        font-size: 12px;
+        font-weight: 700;
+        fill: {{.Colors.Positive}};
+      }{{end}}
     </style>
   </defs>
 
@@ -185,7 +196,8 @@ const TextBasedDetailed = `
 
   <g transform="translate(220, 132)">
     <text class="stat">{{.TotalPRs}}</text>
-    <text class="stat-label" y="22">PRs merged</text>
+    <text class="stat-label" y="22">PRs merged</text>{{if .DeltaText}}
+    <text class="delta" y="40">{{.DeltaText}}</text>{{end}}
   </g>
 
   <g transform="translate(390, 132)">
@@ -823,3 +823,64 @@ func TestRenderSVG_CompactBadgeContent(t *testing.T) {
 		t.Error("Compact badge missing '1.6K PRs'")
 	}
 }
+
+func TestRenderSVG_Delta(t *testing.T) {
+	now := time.Date(2025, 6, 30, 0, 0, 0, 0, time.UTC)
+	stats := &ossstats.Stats{
+		Username:    "testuser",
+		GeneratedAt: now,
+		Summary:     ossstats.Summary{TotalProjects: 5, TotalPRsMerged: 20},
+	}
+
+	for _, variant := range []BadgeVariant{VariantDefault, VariantTextBased} {
+		for _, style := range []BadgeStyle{StyleSummary, StyleDetailed} {
+			previous := &ossstats.Stats{GeneratedAt: now.AddDate(0, 0, -20), Summary: ossstats.Summary{TotalPRsMerged: 16}}
+			svg, err := RenderSVG(stats, BadgeOptions{Style: style, Variant: variant, Theme: ThemeGithubDark, Previous: previous})
+			if err != nil {
+				t.Fatalf("RenderSVG(%s/%s) error: %v", variant, style, err)
+			}
+			if !strings.Contains(svg, "+4 PRs in the last 20 days") {
+				t.Errorf("RenderSVG(%s/%s) missing delta", variant, style)
+			}
+
+			svg, err = RenderSVG(stats, BadgeOptions{Style: style, Variant: variant, Theme: ThemeGithubDark})
+			if err != nil {
+				t.Fatalf("RenderSVG(%s/%s) error: %v", variant, style, err)
+			}
+			if strings.Contains(svg, `class="delta"`) || strings.Contains(svg, "in the last") {
+				t.Errorf("RenderSVG(%s/%s) shows a delta without a previous snapshot", variant, style)
+			}
+		}
+	}
+}
+
+func TestFormatDelta(t *testing.T) {
+	now := time.Date(2025, 6, 30, 0, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name    string
+		prsThen int
+		then    time.Time
+		want    string
+	}{
+		{"hours", 9, now.Add(-2 * time.Hour), "+1 PR in the last day"},
+		{"day", 9, now.Add(-30 * time.Hour), "+1 PR in the last day"},
+		{"days", 7, now.AddDate(0, 0, -6), "+3 PRs in the last 6 days"},
+		{"month", 6, now.AddDate(0, 0, -25), "+4 PRs in the last 25 days"},
+		{"quarter", 6, now.AddDate(0, 0, -90), "+4 PRs in the last 90 days"},
+		{"older", 0, now.AddDate(0, 0, -300), "+10 PRs since Sep 3, 2024"},
+		{"unknown time", 5, time.Time{}, "+5 PRs since last run"},
+		{"no change", 10, now.AddDate(0, 0, -1), ""},
+		{"fewer PRs", 12, now.AddDate(0, 0, -1), ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			stats := &ossstats.Stats{GeneratedAt: now, Summary: ossstats.Summary{TotalPRsMerged: 10}}
+			previous := &ossstats.Stats{GeneratedAt: tt.then, Summary: ossstats.Summary{TotalPRsMerged: tt.prsThen}}
+			if got := formatDelta(stats, previous); got != tt.want {
+				t.Errorf("formatDelta() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,38 @@
+package badge
+
+import (
+	"bytes"
+	"fmt"
+	"image/png"
+
+	"github.com/mabd-dev/gh-oss-stats/internal/svgraster"
+	"github.com/mabd-dev/gh-oss-stats/pkg/ossstats"
+)
+
+var DefaultPNGScale = 2.0
+
+// RenderPNG generates a PNG badge from the given stats: the SVG badge of
+// RenderSVG, rasterized at opts.Scale pixels per SVG pixel (default: 2, for
+// HiDPI screens). Text is set in DejaVu Sans.
+func RenderPNG(stats *ossstats.Stats, opts BadgeOptions) ([]byte, error) {
+	scale := opts.Scale
+	if scale == 0 {
+		scale = DefaultPNGScale
+	}
+
+	svg, err := RenderSVG(stats, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	img, err := svgraster.Rasterize([]byte(svg), scale)
+	if err != nil {
+		return nil, fmt.Errorf("failed to rasterize badge: %w", err)
+	}
+
+	var buf bytes.Buffer
+	if err := png.Encode(&buf, img); err != nil {
+		return nil, fmt.Errorf("failed to encode PNG: %w", err)
+	}
+	return buf.Bytes(), nil
+}
@@ -0,0 +1,57 @@
+package badge
+
+import (
+	"bytes"
+	"image"
+	"image/png"
+	"testing"
+
+	"github.com/mabd-dev/gh-oss-stats/pkg/ossstats"
+)
+
+func TestRenderPNG(t *testing.T) {
+	stats := &ossstats.Stats{
+		Username: "testuser",
+		Summary:  ossstats.Summary{TotalProjects: 12, TotalPRsMerged: 48, TotalAdditions: 5000, TotalDeletions: 1000},
+		Contributions: []ossstats.Contribution{
+			{Repo: "golang/go", Owner: "golang", RepoName: "go", Stars: 120000, PRsMerged: 3},
+		},
+	}
+
+	tests := []struct {
+		name string
+		opts BadgeOptions
+		want image.Point
+	}{
+		{"summary default scale", BadgeOptions{Style: StyleSummary, Variant: VariantDefault}, image.Pt(800, 400)},
+		{"summary scale 1", BadgeOptions{Style: StyleSummary, Variant: VariantDefault, Scale: 1}, image.Pt(400, 200)},
+		{"compact scale 3", BadgeOptions{Style: StyleCompact, Variant: VariantDefault, Theme: ThemeGithubLight, Scale: 3}, image.Pt(840, 96)},
+		{"detailed", BadgeOptions{Style: StyleDetailed, Variant: VariantDefault, Scale: 1}, image.Pt(900, 398)},
+		{"text-based detailed", BadgeOptions{Style: StyleDetailed, Variant: VariantTextBased, Scale: 1.5}, image.Pt(1080, 534)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			data, err := RenderPNG(stats, tt.opts)
+			if err != nil {
+				t.Fatalf("RenderPNG() error = %v", err)
+			}
+			img, err := png.Decode(bytes.NewReader(data))
+			if err != nil {
+				t.Fatalf("png.Decode() error = %v", err)
+			}
+			if got := img.Bounds().Size(); got != tt.want {
+				t.Errorf("Size = %v, want %v", got, tt.want)
+			}
+
+			// The center of the badge is painted with the background
+			if _, _, _, a := img.At(tt.want.X/2, tt.want.Y/2).RGBA(); a == 0 {
+				t.Error("Center pixel is transparent")
+			}
+		})
+	}
+
+	if _, err := RenderPNG(nil, BadgeOptions{}); err == nil {
+		t.Error("RenderPNG(nil) error = nil, want error")
+	}
+}
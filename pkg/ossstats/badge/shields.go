@@ -0,0 +1,89 @@
+package badge
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/mabd-dev/gh-oss-stats/pkg/ossstats"
+)
+
+var DefaultShieldsMetric = ShieldsMetricPRs
+
+// ShieldsMetric represents the stat shown in a shields.io endpoint badge
+type ShieldsMetric string
+
+const (
+	ShieldsMetricProjects ShieldsMetric = "projects" // e.g. "12 projects"
+	ShieldsMetricPRs      ShieldsMetric = "prs"      // e.g. "48 merged PRs"
+	ShieldsMetricLines    ShieldsMetric = "lines"    // e.g. "15.2K lines"
+)
+
+func ShieldsMetricFromName(name string) (ShieldsMetric, error) {
+	switch strings.ToLower(name) {
+	case "projects":
+		return ShieldsMetricProjects, nil
+	case "prs":
+		return ShieldsMetricPRs, nil
+	case "lines":
+		return ShieldsMetricLines, nil
+	}
+	err := fmt.Errorf("invalid badge metric: %s (must be: projects, prs, lines)", name)
+	return DefaultShieldsMetric, err
+}
+
+// ShieldsEndpoint is a badge in the shields.io endpoint schema. Hosted as
+// JSON, it is rendered by https://img.shields.io/endpoint?url=<json url>.
+type ShieldsEndpoint struct {
+	SchemaVersion int    `json:"schemaVersion"`
+	Label         string `json:"label"`
+	Message       string `json:"message"`
+	Color         string `json:"color"`
+	LabelColor    string `json:"labelColor,omitempty"`
+	NamedLogo     string `json:"namedLogo,omitempty"`
+	LogoColor     string `json:"logoColor,omitempty"`
+}
+
+// RenderShieldsEndpoint returns the shields.io endpoint badge of stats,
+// showing opts.Metric (default: prs). The message is colored with the
+// accent color of the options' theme, and the label with its background.
+func RenderShieldsEndpoint(stats *ossstats.Stats, opts BadgeOptions) (ShieldsEndpoint, error) {
+	if stats == nil {
+		return ShieldsEndpoint{}, fmt.Errorf("stats cannot be nil")
+	}
+
+	metric := opts.Metric
+	if metric == "" {
+		metric = DefaultShieldsMetric
+	}
+
+	var message string
+	switch metric {
+	case ShieldsMetricProjects:
+		message = pluralize(stats.Summary.TotalProjects, "project", "projects")
+	case ShieldsMetricPRs:
+		message = pluralize(stats.Summary.TotalPRsMerged, "merged PR", "merged PRs")
+	case ShieldsMetricLines:
+		message = pluralize(stats.Summary.TotalAdditions+stats.Summary.TotalDeletions, "line", "lines")
+	default:
+		return ShieldsEndpoint{}, fmt.Errorf("invalid badge metric: %s", metric)
+	}
+
+	colors := opts.Colors()
+	return ShieldsEndpoint{
+		SchemaVersion: 1,
+		Label:         "OSS",
+		Message:       message,
+		Color:         colors.Accent,
+		LabelColor:    colors.BackgroundAlt,
+		NamedLogo:     "github",
+		LogoColor:     colors.Text,
+	}, nil
+}
+
+// pluralize formats n followed by the singular or plural unit.
+func pluralize(n int, singular, plural string) string {
+	if n == 1 {
+		return "1 " + singular
+	}
+	return formatNumber(n) + " " + plural
+}
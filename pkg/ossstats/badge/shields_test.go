@@ -0,0 +1,65 @@
+package badge
+
+import (
+	"testing"
+
+	"github.com/mabd-dev/gh-oss-stats/pkg/ossstats"
+)
+
+func TestRenderShieldsEndpoint(t *testing.T) {
+	stats := &ossstats.Stats{Summary: ossstats.Summary{
+		TotalProjects:  1,
+		TotalPRsMerged: 48,
+		TotalAdditions: 12000,
+		TotalDeletions: 3450,
+	}}
+
+	tests := []struct {
+		name        string
+		opts        BadgeOptions
+		wantMessage string
+		wantColor   string
+		wantLabel   string
+	}{
+		{"default metric", BadgeOptions{}, "48 merged PRs", "#58a6ff", "#161b22"},
+		{"projects", BadgeOptions{Metric: ShieldsMetricProjects, Theme: ThemeNord}, "1 project", "#88c0d0", GetThemeColors(ThemeNord).BackgroundAlt},
+		{"lines", BadgeOptions{Metric: ShieldsMetricLines, Theme: ThemeDracula}, "15.4K lines", "#bd93f9", GetThemeColors(ThemeDracula).BackgroundAlt},
+		{"custom accent", BadgeOptions{CustomColors: &ThemeColors{Accent: "#ff00ff"}}, "48 merged PRs", "#ff00ff", "#161b22"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := RenderShieldsEndpoint(stats, tt.opts)
+			if err != nil {
+				t.Fatalf("RenderShieldsEndpoint() error = %v", err)
+			}
+			if got.SchemaVersion != 1 || got.Label != "OSS" || got.NamedLogo != "github" {
+				t.Errorf("RenderShieldsEndpoint() = %+v", got)
+			}
+			if got.Message != tt.wantMessage {
+				t.Errorf("Message = %q, want %q", got.Message, tt.wantMessage)
+			}
+			if got.Color != tt.wantColor || got.LabelColor != tt.wantLabel {
+				t.Errorf("Color, LabelColor = %s, %s, want %s, %s", got.Color, got.LabelColor, tt.wantColor, tt.wantLabel)
+			}
+		})
+	}
+
+	if _, err := RenderShieldsEndpoint(nil, BadgeOptions{}); err == nil {
+		t.Error("RenderShieldsEndpoint(nil) error = nil, want error")
+	}
+	if _, err := RenderShieldsEndpoint(stats, BadgeOptions{Metric: "stars"}); err == nil {
+		t.Error("RenderShieldsEndpoint() with an invalid metric error = nil, want error")
+	}
+}
+
+func TestShieldsMetricFromName(t *testing.T) {
+	for name, want := range map[string]ShieldsMetric{"projects": ShieldsMetricProjects, "PRs": ShieldsMetricPRs, "lines": ShieldsMetricLines} {
+		if got, err := ShieldsMetricFromName(name); err != nil || got != want {
+			t.Errorf("ShieldsMetricFromName(%q) = %s, %v, want %s", name, got, err, want)
+		}
+	}
+	if _, err := ShieldsMetricFromName("stars"); err == nil {
+		t.Error("ShieldsMetricFromName(\"stars\") error = nil, want error")
+	}
+}
@@ -1,11 +1,53 @@
 package badge
 
+import "github.com/mabd-dev/gh-oss-stats/pkg/ossstats"
+
 // BadgeOptions contains all configuration for badge generation
 type BadgeOptions struct {
 	Style        BadgeStyle
 	Variant      BadgeVariant
 	Theme        BadgeTheme
-	SortBy       SortBy        // For detailed badge - how to sort contributions (default: prs)
-	Limit        int           // For detailed badge - max contributions to show (default: 5)
-	CustomColors *ThemeColors  // Optional per-color overrides (applied on top of Theme)
+	SortBy       SortBy          // For detailed badge - how to sort contributions (default: prs)
+	Limit        int             // For detailed badge - max contributions to show (default: 5)
+	Metric       ShieldsMetric   // For shields endpoint - stat shown (default: prs)
+	Scale        float64         // For PNG - pixels per SVG pixel (default: 2)
+	CustomColors *ThemeColors    // Optional per-color overrides (applied on top of Theme)
+	Previous     *ossstats.Stats // Optional earlier snapshot, shown as a delta such as "+4 PRs in the last 30 days"
+}
+
+// Colors returns the palette of the options' theme with CustomColors applied.
+func (o BadgeOptions) Colors() ThemeColors {
+	colors := GetThemeColors(o.Theme)
+	if o.CustomColors != nil {
+		c := o.CustomColors
+		if c.Background != "" {
+			colors.Background = c.Background
+		}
+		if c.BackgroundAlt != "" {
+			colors.BackgroundAlt = c.BackgroundAlt
+		}
+		if c.Text != "" {
+			colors.Text = c.Text
+		}
+		if c.TextSecondary != "" {
+			colors.TextSecondary = c.TextSecondary
+		}
+		if c.Border != "" {
+			colors.Border = c.Border
+		}
+		if c.Accent != "" {
+			colors.Accent = c.Accent
+		}
+		if c.Positive != "" {
+			colors.Positive = c.Positive
+		}
+		if c.Negative != "" {
+			colors.Negative = c.Negative
+		}
+		if c.Star != "" {
+			colors.Star = c.Star
+		}
+	}
+
+	return colors
 }
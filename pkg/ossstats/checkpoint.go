@@ -0,0 +1,275 @@
+package ossstats
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"github.com/mabd-dev/gh-oss-stats/internal/github"
+)
+
+// checkpointVersion is bumped whenever the on-disk checkpoint layout changes.
+// Checkpoints written with a different version are discarded.
+const checkpointVersion = 1
+
+// Completed lookups are saved in batches, since every save rewrites the
+// whole checkpoint, search results included. A batch is saved once it
+// holds checkpointSaveEvery lookups or checkpointSaveInterval has passed
+// since the last save; flush saves the rest when the run ends.
+const (
+	checkpointSaveEvery    = 25
+	checkpointSaveInterval = 5 * time.Second
+)
+
+// checkpoint records the work completed by GetContributions so that a run
+// interrupted by rate limiting or a timeout can be resumed later without
+// repeating API calls.
+//
+// All methods are safe to call on a nil *checkpoint, in which case they are
+// no-ops. This keeps the fetch pipeline free of "is checkpointing enabled"
+// checks.
+type checkpoint struct {
+	Version  int    `json:"version"`
+	Username string `json:"username"`
+	Query    string `json:"query"`
+
+	// Search progress
+	SearchComplete bool           `json:"searchComplete"`
+	NextPage       int            `json:"nextPage"`
+	Issues         []github.Issue `json:"issues"`
+	SearchTotal    int            `json:"searchTotal,omitempty"` // PRs the search matched
+
+	// Completed lookups, keyed by "owner/repo#number" and "owner/repo"
+	PullRequests map[string]checkpointPR   `json:"pullRequests"`
+	Repositories map[string]checkpointRepo `json:"repositories"`
+
+	path     string
+	mu       sync.Mutex // Guards the fields above, pending and lastSave
+	saveMu   sync.Mutex // Serializes writes, so a later save is never overwritten
+	pending  int        // Lookups recorded since the last save
+	lastSave time.Time
+}
+
+// checkpointPR holds the PR fields used for aggregation.
+type checkpointPR struct {
+	Commits   int `json:"commits"`
+	Additions int `json:"additions"`
+	Deletions int `json:"deletions"`
+}
+
+// checkpointRepo holds the repository fields used for enrichment.
+type checkpointRepo struct {
+	Description string `json:"description"`
+	HTMLURL     string `json:"htmlURL"`
+	Stars       int    `json:"stars"`
+	Language    string `json:"language,omitempty"`
+}
+
+// newCheckpoint creates an empty checkpoint that will be saved to path.
+func newCheckpoint(path, username, query string) *checkpoint {
+	return &checkpoint{
+		Version:      checkpointVersion,
+		Username:     username,
+		Query:        query,
+		NextPage:     1,
+		PullRequests: make(map[string]checkpointPR),
+		Repositories: make(map[string]checkpointRepo),
+		path:         path,
+	}
+}
+
+// loadCheckpoint reads the checkpoint stored at path.
+// A fresh checkpoint is returned if the file does not exist, or if it was
+// written for a different username, search query or checkpoint version.
+// The second return value reports whether previous progress was resumed.
+func loadCheckpoint(path, username, query string) (*checkpoint, bool, error) {
+	data, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		return newCheckpoint(path, username, query), false, nil
+	}
+	if err != nil {
+		return nil, false, fmt.Errorf("reading checkpoint: %w", err)
+	}
+
+	var cp checkpoint
+	if err := json.Unmarshal(data, &cp); err != nil {
+		return nil, false, fmt.Errorf("parsing checkpoint %s: %w", path, err)
+	}
+
+	if cp.Version != checkpointVersion || cp.Username != username || cp.Query != query {
+		return newCheckpoint(path, username, query), false, nil
+	}
+
+	if cp.NextPage < 1 {
+		cp.NextPage = 1
+	}
+	if cp.PullRequests == nil {
+		cp.PullRequests = make(map[string]checkpointPR)
+	}
+	if cp.Repositories == nil {
+		cp.Repositories = make(map[string]checkpointRepo)
+	}
+	cp.path = path
+
+	return &cp, true, nil
+}
+
+// searchProgress returns the issues collected so far, the number of PRs the
+// search matched, the next page to fetch and whether the search already
+// finished.
+func (cp *checkpoint) searchProgress() ([]github.Issue, int, int, bool) {
+	if cp == nil {
+		return nil, 0, 1, false
+	}
+	cp.mu.Lock()
+	defer cp.mu.Unlock()
+
+	issues := make([]github.Issue, len(cp.Issues))
+	copy(issues, cp.Issues)
+	return issues, cp.SearchTotal, cp.NextPage, cp.SearchComplete
+}
+
+// recordSearchPage stores the search results collected so far.
+func (cp *checkpoint) recordSearchPage(issues []github.Issue, total, nextPage int, complete bool) error {
+	if cp == nil {
+		return nil
+	}
+	cp.mu.Lock()
+	cp.Issues = issues
+	cp.SearchTotal = total
+	cp.NextPage = nextPage
+	cp.SearchComplete = complete
+	cp.mu.Unlock()
+
+	return cp.save()
+}
+
+// pullRequest returns the stored details for a PR, if present.
+func (cp *checkpoint) pullRequest(key string) (checkpointPR, bool) {
+	if cp == nil {
+		return checkpointPR{}, false
+	}
+	cp.mu.Lock()
+	defer cp.mu.Unlock()
+
+	pr, ok := cp.PullRequests[key]
+	return pr, ok
+}
+
+// recordPullRequest stores the details of a completed PR lookup.
+func (cp *checkpoint) recordPullRequest(key string, pr checkpointPR) error {
+	if cp == nil {
+		return nil
+	}
+	cp.mu.Lock()
+	cp.PullRequests[key] = pr
+	due := cp.recorded()
+	cp.mu.Unlock()
+
+	if !due {
+		return nil
+	}
+	return cp.save()
+}
+
+// repository returns the stored metadata for a repository, if present.
+func (cp *checkpoint) repository(key string) (checkpointRepo, bool) {
+	if cp == nil {
+		return checkpointRepo{}, false
+	}
+	cp.mu.Lock()
+	defer cp.mu.Unlock()
+
+	repo, ok := cp.Repositories[key]
+	return repo, ok
+}
+
+// recordRepository stores the metadata of a completed repository lookup.
+func (cp *checkpoint) recordRepository(key string, repo checkpointRepo) error {
+	if cp == nil {
+		return nil
+	}
+	cp.mu.Lock()
+	cp.Repositories[key] = repo
+	due := cp.recorded()
+	cp.mu.Unlock()
+
+	if !due {
+		return nil
+	}
+	return cp.save()
+}
+
+// recorded counts a completed lookup and reports whether the pending
+// lookups are due to be saved. cp.mu must be held.
+func (cp *checkpoint) recorded() bool {
+	cp.pending++
+	return cp.pending >= checkpointSaveEvery || time.Since(cp.lastSave) >= checkpointSaveInterval
+}
+
+// flush saves the lookups recorded since the last save, if any.
+func (cp *checkpoint) flush() error {
+	if cp == nil {
+		return nil
+	}
+	cp.mu.Lock()
+	pending := cp.pending
+	cp.mu.Unlock()
+
+	if pending == 0 {
+		return nil
+	}
+	return cp.save()
+}
+
+// save writes the checkpoint to disk. The file is written to a temporary
+// path first and renamed, so an interrupted write never corrupts it.
+func (cp *checkpoint) save() error {
+	if cp == nil {
+		return nil
+	}
+	cp.saveMu.Lock()
+	defer cp.saveMu.Unlock()
+
+	cp.mu.Lock()
+	data, err := json.Marshal(cp)
+	cp.pending = 0
+	cp.lastSave = time.Now()
+	cp.mu.Unlock()
+	if err != nil {
+		return fmt.Errorf("encoding checkpoint: %w", err)
+	}
+
+	if err := os.MkdirAll(filepath.Dir(cp.path), 0o755); err != nil {
+		return fmt.Errorf("creating checkpoint directory: %w", err)
+	}
+
+	tmp := cp.path + ".tmp"
+	if err := os.WriteFile(tmp, data, 0o644); err != nil {
+		return fmt.Errorf("writing checkpoint: %w", err)
+	}
+	if err := os.Rename(tmp, cp.path); err != nil {
+		return fmt.Errorf("writing checkpoint: %w", err)
+	}
+
+	return nil
+}
+
+// remove deletes the checkpoint file once a run has completed.
+func (cp *checkpoint) remove() error {
+	if cp == nil {
+		return nil
+	}
+	if err := os.Remove(cp.path); err != nil && !os.IsNotExist(err) {
+		return fmt.Errorf("removing checkpoint: %w", err)
+	}
+	return nil
+}
+
+// prCheckpointKey returns the checkpoint key for a pull request.
+func prCheckpointKey(owner, repo string, number int) string {
+	return fmt.Sprintf("%s/%s#%d", owner, repo, number)
+}
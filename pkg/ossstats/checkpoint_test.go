@@ -0,0 +1,284 @@
+package ossstats
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/mabd-dev/gh-oss-stats/internal/github"
+)
+
+func TestGetContributionsResumesFromCheckpoint(t *testing.T) {
+	mergedAt := time.Date(2025, 6, 1, 12, 0, 0, 0, time.UTC)
+
+	var mu sync.Mutex
+	requests := make(map[string]int)
+	failPR := true
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		requests[r.URL.Path]++
+		fail := failPR
+		mu.Unlock()
+
+		w.Header().Set("Content-Type", "application/json")
+		switch {
+		case strings.HasPrefix(r.URL.Path, "/search/issues"):
+			json.NewEncoder(w).Encode(github.SearchIssuesResponse{
+				TotalCount: 2,
+				Items: []github.Issue{
+					{
+						Number:        1,
+						RepositoryURL: "https://api.github.com/repos/alpha/one",
+						PullRequest:   &github.PullRequestRef{MergedAt: &mergedAt},
+					},
+					{
+						Number:        2,
+						RepositoryURL: "https://api.github.com/repos/beta/two",
+						PullRequest:   &github.PullRequestRef{MergedAt: &mergedAt},
+					},
+				},
+			})
+		case r.URL.Path == "/repos/alpha/one/pulls/1":
+			json.NewEncoder(w).Encode(github.PullRequest{Number: 1, Commits: 3, Additions: 30, Deletions: 3})
+		case r.URL.Path == "/repos/beta/two/pulls/2":
+			if fail {
+				w.WriteHeader(http.StatusInternalServerError)
+				w.Write([]byte(`{"message":"Server Error"}`))
+				return
+			}
+			json.NewEncoder(w).Encode(github.PullRequest{Number: 2, Commits: 2, Additions: 20, Deletions: 2})
+		case r.URL.Path == "/repos/alpha/one":
+			json.NewEncoder(w).Encode(github.Repository{FullName: "alpha/one", StargazersCount: 10, HTMLURL: "https://github.com/alpha/one"})
+		case r.URL.Path == "/repos/beta/two":
+			json.NewEncoder(w).Encode(github.Repository{FullName: "beta/two", StargazersCount: 20, HTMLURL: "https://github.com/beta/two"})
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer server.Close()
+
+	checkpointPath := filepath.Join(t.TempDir(), "checkpoint.json")
+	newClient := func() *Client {
+		client := New(
+			WithToken("test-token"),
+			WithLOC(true),
+			WithCheckpoint(checkpointPath),
+		)
+		client.httpClient.Transport = &mockTransport{server: server}
+		return client
+	}
+
+	// First run fails one PR lookup and keeps the checkpoint
+	_, err := newClient().GetContributions(context.Background(), "testuser")
+	if _, ok := err.(*ErrPartialResults); !ok {
+		t.Fatalf("Expected *ErrPartialResults on first run, got %T (%v)", err, err)
+	}
+	if _, err := os.Stat(checkpointPath); err != nil {
+		t.Fatalf("Checkpoint should exist after partial run: %v", err)
+	}
+
+	// Second run only fetches the remaining work
+	mu.Lock()
+	requests = make(map[string]int)
+	failPR = false
+	mu.Unlock()
+
+	stats, err := newClient().GetContributions(context.Background(), "testuser")
+	if err != nil {
+		t.Fatalf("Unexpected error on resumed run: %v", err)
+	}
+
+	wantRequests := map[string]int{
+		"/search/issues":           0,
+		"/repos/alpha/one/pulls/1": 0,
+		"/repos/alpha/one":         0,
+		"/repos/beta/two/pulls/2":  1,
+		"/repos/beta/two":          1,
+	}
+	for path, want := range wantRequests {
+		if got := requests[path]; got != want {
+			t.Errorf("requests[%s] = %d, want %d", path, got, want)
+		}
+	}
+
+	if stats.Summary.TotalProjects != 2 {
+		t.Errorf("TotalProjects = %d, want 2", stats.Summary.TotalProjects)
+	}
+	if stats.Summary.TotalAdditions != 50 {
+		t.Errorf("TotalAdditions = %d, want 50", stats.Summary.TotalAdditions)
+	}
+	for _, contrib := range stats.Contributions {
+		if contrib.Stars == 0 {
+			t.Errorf("Stars for %s = 0, want repository data from checkpoint or API", contrib.Repo)
+		}
+	}
+
+	if _, err := os.Stat(checkpointPath); !os.IsNotExist(err) {
+		t.Errorf("Checkpoint should be removed after a complete run, stat err = %v", err)
+	}
+}
+
+func TestGetContributionsKeepsCheckpointWhenCancelled(t *testing.T) {
+	mergedAt := time.Date(2025, 6, 1, 12, 0, 0, 0, time.UTC)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if strings.HasPrefix(r.URL.Path, "/search/issues") {
+			w.Header().Set("Content-Type", "application/json")
+			json.NewEncoder(w).Encode(github.SearchIssuesResponse{
+				TotalCount: 1,
+				Items: []github.Issue{{
+					Number:        1,
+					RepositoryURL: "https://api.github.com/repos/alpha/one",
+					PullRequest:   &github.PullRequestRef{MergedAt: &mergedAt},
+				}},
+			})
+			return
+		}
+		// The run is cancelled while the only PR is being looked up
+		cancel()
+		<-r.Context().Done()
+	}))
+	defer server.Close()
+
+	checkpointPath := filepath.Join(t.TempDir(), "checkpoint.json")
+	client := New(WithToken("test-token"), WithLOC(true), WithCheckpoint(checkpointPath))
+	client.httpClient.Transport = &mockTransport{server: server}
+
+	if _, err := client.GetContributions(ctx, "testuser"); err == nil {
+		t.Fatal("GetContributions() error = nil, want the cancelled run reported")
+	}
+	if _, err := os.Stat(checkpointPath); err != nil {
+		t.Errorf("Checkpoint should be kept after a cancelled run: %v", err)
+	}
+}
+
+func TestLoadCheckpoint(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "checkpoint.json")
+
+	cp, resumed, err := loadCheckpoint(path, "testuser", "query")
+	if err != nil {
+		t.Fatalf("Unexpected error for missing file: %v", err)
+	}
+	if resumed {
+		t.Error("resumed = true for missing file, want false")
+	}
+
+	if err := cp.recordRepository("owner/repo", checkpointRepo{Stars: 42}); err != nil {
+		t.Fatalf("recordRepository() error: %v", err)
+	}
+
+	tests := []struct {
+		name        string
+		username    string
+		query       string
+		wantResumed bool
+	}{
+		{"same run", "testuser", "query", true},
+		{"different username", "otheruser", "query", false},
+		{"different query", "testuser", "query -org:acme", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			loaded, resumed, err := loadCheckpoint(path, tt.username, tt.query)
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+			if resumed != tt.wantResumed {
+				t.Errorf("resumed = %v, want %v", resumed, tt.wantResumed)
+			}
+
+			_, ok := loaded.repository("owner/repo")
+			if ok != tt.wantResumed {
+				t.Errorf("repository found = %v, want %v", ok, tt.wantResumed)
+			}
+		})
+	}
+}
+
+func TestCheckpointBatchesLookups(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "checkpoint.json")
+	cp := newCheckpoint(path, "testuser", "query")
+	if err := cp.recordSearchPage(nil, 0, 2, true); err != nil {
+		t.Fatalf("recordSearchPage() error: %v", err)
+	}
+
+	saved := func() int {
+		t.Helper()
+		loaded, _, err := loadCheckpoint(path, "testuser", "query")
+		if err != nil {
+			t.Fatalf("loadCheckpoint() error: %v", err)
+		}
+		return len(loaded.PullRequests)
+	}
+
+	// Lookups right after a save wait for a full batch
+	for i := range checkpointSaveEvery - 1 {
+		if err := cp.recordPullRequest(prCheckpointKey("owner", "repo", i), checkpointPR{}); err != nil {
+			t.Fatalf("recordPullRequest() error: %v", err)
+		}
+	}
+	if got := saved(); got != 0 {
+		t.Errorf("Saved %d PRs before the batch was full, want 0", got)
+	}
+
+	if err := cp.recordPullRequest(prCheckpointKey("owner", "repo", checkpointSaveEvery), checkpointPR{}); err != nil {
+		t.Fatalf("recordPullRequest() error: %v", err)
+	}
+	if got := saved(); got != checkpointSaveEvery {
+		t.Errorf("Saved %d PRs after a full batch, want %d", got, checkpointSaveEvery)
+	}
+
+	// flush saves what is left of a batch
+	if err := cp.recordRepository("owner/repo", checkpointRepo{Stars: 1}); err != nil {
+		t.Fatalf("recordRepository() error: %v", err)
+	}
+	if err := cp.flush(); err != nil {
+		t.Fatalf("flush() error: %v", err)
+	}
+	loaded, _, _ := loadCheckpoint(path, "testuser", "query")
+	if _, ok := loaded.repository("owner/repo"); !ok {
+		t.Error("Repository not saved by flush()")
+	}
+}
+
+func TestLoadCheckpointInvalidJSON(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "checkpoint.json")
+	if err := os.WriteFile(path, []byte("{not json"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, _, err := loadCheckpoint(path, "testuser", "query"); err == nil {
+		t.Error("Expected error for invalid checkpoint, got nil")
+	}
+}
+
+func TestNilCheckpointIsNoop(t *testing.T) {
+	var cp *checkpoint
+
+	if err := cp.recordPullRequest("owner/repo#1", checkpointPR{}); err != nil {
+		t.Errorf("recordPullRequest() on nil checkpoint error: %v", err)
+	}
+	if _, ok := cp.pullRequest("owner/repo#1"); ok {
+		t.Error("pullRequest() on nil checkpoint should report not found")
+	}
+	if _, _, page, complete := cp.searchProgress(); page != 1 || complete {
+		t.Errorf("searchProgress() = page %d complete %v, want page 1 incomplete", page, complete)
+	}
+	if err := cp.flush(); err != nil {
+		t.Errorf("flush() on nil checkpoint error: %v", err)
+	}
+	if err := cp.remove(); err != nil {
+		t.Errorf("remove() on nil checkpoint error: %v", err)
+	}
+}
@@ -1,8 +1,12 @@
 package ossstats
 
 import (
+	"fmt"
 	"net/http"
+	"sync"
 	"time"
+
+	"github.com/mabd-dev/gh-oss-stats/internal/github"
 )
 
 var (
@@ -17,23 +21,43 @@ var (
 // It is safe for concurrent use by multiple goroutines.
 type Client struct {
 	// Authentication
-	token string
+	token     string
+	tokens    []string
+	tokenPool *github.TokenPool
+
+	// GitHub App installation authentication
+	app       *appConfig
+	appMu     sync.Mutex
+	appSource *github.AppTokenSource
 
 	// Configuration options
 	includeLOC       bool
 	includePRDetails bool
 	minStars         int
+	unknownStars     UnknownStarsPolicy
 	maxPRs           int
 	timeout          time.Duration
 	excludeOrgs      []string
 
 	// HTTP client
 	httpClient *http.Client
+	baseURL    string
 
 	// Logger
 	logger Logger
 
-	debug bool
+	// Other forges queried after GitHub
+	providers []Provider
+
+	// Checkpoint file used to resume interrupted runs (empty = disabled)
+	checkpointPath string
+
+	// Directories to record API exchanges to or replay them from (empty = disabled)
+	recordDir string
+	replayDir string
+
+	debug         bool
+	debugScenario string
 }
 
 // New creates a new Client with the provided options.
@@ -64,6 +88,11 @@ func New(opts ...Option) *Client {
 		opt(client)
 	}
 
+	// Spread requests across all tokens when more than one is configured
+	if len(client.tokens) > 0 {
+		client.tokenPool = github.NewTokenPool(append([]string{client.token}, client.tokens...))
+	}
+
 	// Configure HTTP client timeout if not already set
 	if client.httpClient.Timeout == 0 {
 		client.httpClient.Timeout = client.timeout
@@ -71,3 +100,118 @@ func New(opts ...Option) *Client {
 
 	return client
 }
+
+// appConfig holds the GitHub App credentials set with WithGitHubApp.
+type appConfig struct {
+	appID          int64
+	installationID int64
+	privateKey     []byte
+}
+
+// appTokenSource returns the GitHub App token source, creating it on first
+// use so the installation token is cached across calls.
+func (c *Client) appTokenSource() (*github.AppTokenSource, error) {
+	c.appMu.Lock()
+	defer c.appMu.Unlock()
+
+	if c.appSource != nil {
+		return c.appSource, nil
+	}
+
+	source, err := github.NewAppTokenSource(c.httpClient, c.baseURL, c.app.appID, c.app.installationID, c.app.privateKey)
+	if err != nil {
+		return nil, &ErrAuthentication{Message: fmt.Sprintf("invalid GitHub App private key: %v", err)}
+	}
+
+	c.appSource = source
+	return source, nil
+}
+
+// newAPIClient creates the GitHub API client for a run, based on the
+// configured authentication mode.
+func (c *Client) newAPIClient() (github.GithubAPI, error) {
+	if c.debugScenario != "" {
+		scenario, err := github.LoadScenario(c.debugScenario)
+		if err != nil {
+			return nil, err
+		}
+		c.logger.Printf("DEBUG MODE: Using mock API client with scenario %s", c.debugScenario)
+		return github.NewScenarioMockAPIClient(scenario), nil
+	}
+
+	if c.debug {
+		c.logger.Printf("DEBUG MODE: Using mock API client")
+		return github.NewMockAPIClient(), nil
+	}
+
+	if c.replayDir != "" {
+		c.logger.Printf("Replaying recorded API responses from %s", c.replayDir)
+		apiClient, err := github.NewReplayAPIClient(c.replayDir)
+		if err != nil {
+			return nil, fmt.Errorf("loading recordings: %w", err)
+		}
+		if c.baseURL != "" {
+			apiClient.SetBaseURL(c.baseURL)
+		}
+		return apiClient, nil
+	}
+
+	httpClient := c.httpClient
+	if c.recordDir != "" {
+		recorder, err := github.NewRecordingTransport(c.recordDir, c.httpClient.Transport, append([]string{c.token}, c.tokens...)...)
+		if err != nil {
+			return nil, err
+		}
+		recording := *c.httpClient
+		recording.Transport = recorder
+		httpClient = &recording
+	}
+
+	var apiClient *github.APIClient
+	switch {
+	case c.app != nil:
+		source, err := c.appTokenSource()
+		if err != nil {
+			return nil, err
+		}
+		apiClient = github.NewAPIClientWithTokenSource(httpClient, source)
+	case c.tokenPool != nil:
+		apiClient = github.NewAPIClientWithTokenPool(httpClient, c.tokenPool)
+	default:
+		apiClient = github.NewAPIClient(httpClient, c.token)
+	}
+
+	if c.baseURL != "" {
+		apiClient.SetBaseURL(c.baseURL)
+	}
+	return apiClient, nil
+}
+
+// TokenUsage reports how a token from the pool configured with WithTokens
+// was used. Tokens are masked so the report is safe to print.
+type TokenUsage struct {
+	Token     string    // Masked token, e.g. "ghp_…abcd"
+	Requests  int       // Requests made with the token
+	Remaining int       // Last known remaining core requests (-1 = unknown)
+	Reset     time.Time // Last known core rate limit reset time
+}
+
+// TokenUsage returns the per-token usage of the pool configured with
+// WithTokens, accumulated across all calls made with this client.
+// Returns nil when no token pool is configured.
+func (c *Client) TokenUsage() []TokenUsage {
+	if c.tokenPool == nil {
+		return nil
+	}
+
+	var usage []TokenUsage
+	for _, u := range c.tokenPool.Usage() {
+		usage = append(usage, TokenUsage{
+			Token:     u.Token,
+			Requests:  u.Requests,
+			Remaining: u.Remaining,
+			Reset:     u.Reset,
+		})
+	}
+	return usage
+}
@@ -1,11 +1,14 @@
 package ossstats
 
 import (
+	"cmp"
 	"context"
+	"errors"
 	"fmt"
 	"net/http"
 	"slices"
 	"sync"
+	"sync/atomic"
 	"time"
 
 	"github.com/mabd-dev/gh-oss-stats/internal/github"
@@ -15,34 +18,72 @@ import (
 // to external repositories (repos they don't own).
 //
 // Returns Stats containing the aggregated contribution data, or an error.
-// If rate limiting occurs mid-fetch, returns ErrPartialResults with whatever
-// data was collected before the rate limit.
+// If rate limiting occurs mid-fetch, or the timeout or ctx cuts the run
+// short, returns ErrPartialResults with whatever data was collected.
 func (c *Client) GetContributions(ctx context.Context, username string) (*Stats, error) {
+	return c.getContributions(ctx, username, runOptions{checkpointPath: c.checkpointPath})
+}
+
+// runOptions adjusts a single contribution run.
+type runOptions struct {
+	checkpointPath string   // Checkpoint file (empty = disabled)
+	excludeOrgs    []string // Organizations excluded on top of WithExcludeOrgs
+	excludeUsers   []string // Other accounts of the user, excluded like their own repos
+	githubOnly     bool     // Skip the providers of other forges
+}
+
+// getContributions implements GetContributions.
+func (c *Client) getContributions(ctx context.Context, username string, run runOptions) (*Stats, error) {
 	// Create context with timeout
 	ctx, cancel := context.WithTimeout(ctx, c.timeout)
 	defer cancel()
 
 	c.logger.Printf("Fetching contributions for user: %s", username)
 
-	// Initialize GitHub API client
-	var apiClient github.GithubAPI
-	if c.debug {
-		c.logger.Printf("DEBUG MODE: Using mock API client")
-		apiClient = github.NewMockAPIClient()
-	} else {
-		apiClient = github.NewAPIClient(c.httpClient, c.token)
-	}
-
-	// Step 1: Search for merged PRs to external repos
-	c.logger.Printf("Searching for merged PRs...")
-	issues, err := c.searchMergedPRs(ctx, apiClient, username)
+	// GitHub failures are fatal, as before providers existed
+	opts := c.providerOptions(run)
+	gh := &githubProvider{client: c, checkpointPath: run.checkpointPath}
+	result, err := gh.Contributions(ctx, username, opts)
 	if err != nil {
 		return nil, err
 	}
+	for i := range result.Contributions {
+		result.Contributions[i].Forge = ForgeGitHub
+		result.Contributions[i].Host = c.githubHost()
+	}
+
+	contributions := result.Contributions
+	errors := result.Errors
+	unprocessedPRs := result.UnprocessedPRs
+	unprocessedRepos := result.UnprocessedRepos
 
-	if len(issues) == 0 {
-		c.logger.Printf("No contributions found")
+	// Other forges only add to the GitHub results, so their failures are
+	// reported as partial results
+	var providers []Provider
+	if !run.githubOnly {
+		providers = c.providers
+	}
+	for _, p := range providers {
+		c.logger.Printf("Fetching contributions from %s...", p.Name())
+		result, err := p.Contributions(ctx, username, opts)
+		if err != nil {
+			errors = append(errors, fmt.Errorf("%s: %w", p.Name(), err))
+			continue
+		}
+		contributions = append(contributions, result.Contributions...)
+		for _, err := range result.Errors {
+			errors = append(errors, fmt.Errorf("%s: %w", p.Name(), err))
+		}
+		unprocessedPRs += result.UnprocessedPRs
+		unprocessedRepos += result.UnprocessedRepos
+	}
+
+	if len(contributions) == 0 && len(errors) == 0 && unprocessedPRs == 0 && unprocessedRepos == 0 {
+		if ctx.Err() == nil {
+			c.removeCheckpoint(gh.cp)
+		}
 		return &Stats{
+			SchemaVersion: StatsSchemaVersion,
 			Username:      username,
 			GeneratedAt:   time.Now().UTC(),
 			Summary:       Summary{},
@@ -50,15 +91,13 @@ func (c *Client) GetContributions(ctx context.Context, username string) (*Stats,
 		}, nil
 	}
 
-	c.logger.Printf("Found %d merged PRs", len(issues))
-
-	// Step 2: Fetch PR details and aggregate by repository
-	c.logger.Printf("Fetching PR details...")
-	contributions, errors := c.fetchPRDetails(ctx, apiClient, issues)
-
-	// Step 3: Fetch repository metadata
-	c.logger.Printf("Fetching repository metadata...")
-	contributions = c.enrichWithRepoData(ctx, apiClient, contributions)
+	message := fmt.Sprintf("collected %d contributions with errors", len(contributions))
+	if unprocessedPRs > 0 || unprocessedRepos > 0 {
+		c.logger.Printf("Stopped early: %d PRs and %d repositories unprocessed", unprocessedPRs, unprocessedRepos)
+		cause := stopCause(ctx)
+		errors = append(errors, fmt.Errorf("stopped early: %w", cause))
+		message = fmt.Sprintf("stopped early (%v) after collecting %d contributions", cause, len(contributions))
+	}
 
 	// Step 4: Apply filters
 	contributions = c.applyFilters(contributions)
@@ -74,9 +113,10 @@ func (c *Client) GetContributions(ctx context.Context, username string) (*Stats,
 	})
 
 	// Step 5: Calculate summary
-	summary := c.calculateSummary(contributions)
+	summary := calculateSummary(contributions)
 
 	stats := &Stats{
+		SchemaVersion: StatsSchemaVersion,
 		Username:      username,
 		GeneratedAt:   time.Now().UTC(),
 		Summary:       summary,
@@ -87,62 +127,103 @@ func (c *Client) GetContributions(ctx context.Context, username string) (*Stats,
 	if len(errors) > 0 {
 		c.logger.Printf("Completed with %d errors", len(errors))
 		return stats, &ErrPartialResults{
-			Stats:   stats,
-			Errors:  errors,
-			Message: fmt.Sprintf("collected %d contributions with errors", len(contributions)),
+			Stats:            stats,
+			Errors:           errors,
+			Message:          message,
+			UnprocessedPRs:   unprocessedPRs,
+			UnprocessedRepos: unprocessedRepos,
 		}
 	}
 
+	// Keep the checkpoint if the deadline cut the run short
+	if ctx.Err() == nil {
+		c.removeCheckpoint(gh.cp)
+	}
+
 	c.logger.Printf("Successfully fetched %d contributions", len(contributions))
 	return stats, nil
 }
 
-// searchMergedPRs searches for all merged PRs authored by the user to external repos.
-func (c *Client) searchMergedPRs(ctx context.Context, api github.GithubAPI, username string) ([]github.Issue, error) {
-	// Build search query: merged PRs by user, excluding their own repos
+// removeCheckpoint deletes the checkpoint file after a complete run, so the
+// next run starts from fresh data.
+func (c *Client) removeCheckpoint(cp *checkpoint) {
+	if err := cp.remove(); err != nil {
+		c.logger.Printf("Failed to remove checkpoint: %v", err)
+	}
+}
+
+// saveCheckpoint logs checkpoint write failures without interrupting the run.
+func (c *Client) saveCheckpoint(err error) {
+	if err != nil {
+		c.logger.Printf("Failed to save checkpoint: %v", err)
+	}
+}
+
+// searchQuery builds the search query for merged PRs by the user,
+// excluding their own repos, those of excludeUsers and any excluded
+// organizations.
+func searchQuery(username string, excludeOrgs, excludeUsers []string) string {
 	query := fmt.Sprintf("author:%s type:pr is:merged -user:%s", username, username)
 
-	// Exclude specified organizations
-	for _, org := range c.excludeOrgs {
+	for _, user := range excludeUsers {
+		if user != "" {
+			query += fmt.Sprintf(" -user:%s", user)
+		}
+	}
+
+	for _, org := range excludeOrgs {
 		if org != "" {
 			query += fmt.Sprintf(" -org:%s", org)
 		}
 	}
 
-	var allIssues []github.Issue
-	page := 1
+	return query
+}
+
+// searchResultLimit is the number of results GitHub's search returns at most.
+const searchResultLimit = 1000
+
+// searchMergedPRs searches for all merged PRs authored by the user to external repos.
+// Pages already stored in the checkpoint are not fetched again. It also
+// returns the number of PRs the search matched, which exceeds the PRs
+// returned when WithMaxPRs or the search's result limit cut the search short.
+func (c *Client) searchMergedPRs(ctx context.Context, api github.GithubAPI, username, query string, cp *checkpoint) ([]github.Issue, int, error) {
+	allIssues, total, page, complete := cp.searchProgress()
+	if complete {
+		c.logger.Printf("Using %d PRs from checkpoint", len(allIssues))
+		return allIssues, max(total, len(allIssues)), nil
+	}
 	perPage := 100
+	startPage := page
 
 	for {
 		// Respect search API rate limits
-		if page > 1 {
+		if page > startPage {
 			if err := github.WaitForSearchAPI(ctx); err != nil {
-				return nil, fmt.Errorf("waiting for search API: %w", err)
+				return nil, 0, fmt.Errorf("waiting for search API: %w", err)
 			}
 		}
 
-		result, resp, err := api.SearchIssues(ctx, query, page, perPage)
+		var result *github.SearchIssuesResponse
+		resp, err := retrySecondaryRateLimit(ctx, func() (*http.Response, error) {
+			var resp *http.Response
+			var err error
+			result, resp, err = api.SearchIssues(ctx, query, page, perPage)
+			return resp, err
+		})
 		if err != nil {
-			if github.IsRateLimited(resp) {
-				resetTime := time.Now().Add(time.Minute)
-				if info, err := github.ParseRateLimitHeaders(resp.Header); err == nil {
-					resetTime = info.Reset
-				}
-				return nil, &ErrRateLimited{
-					ResetAt: resetTime,
-					Message: "search API rate limit exceeded",
-				}
-			}
-			if resp != nil && resp.StatusCode == http.StatusUnauthorized {
-				return nil, &ErrAuthentication{Message: "invalid or missing token"}
-			}
-			if resp != nil && resp.StatusCode == http.StatusNotFound {
-				return nil, &ErrNotFound{Username: username}
+			if typedErr := lookupError(resp, err, "search API", &ErrNotFound{Username: username}); typedErr != nil {
+				return nil, 0, typedErr
 			}
-			return nil, fmt.Errorf("searching issues: %w", err)
+			return nil, 0, fmt.Errorf("searching issues: %w", err)
+		}
+
+		if result.IncompleteResults {
+			c.logger.Printf("Warning: search results for page %d are incomplete, some PRs may be missing", page)
 		}
 
 		allIssues = append(allIssues, result.Items...)
+		total = result.TotalCount
 
 		// Check if we've hit the max PRs limit
 		if c.maxPRs > 0 && len(allIssues) >= c.maxPRs {
@@ -155,156 +236,222 @@ func (c *Client) searchMergedPRs(ctx context.Context, api github.GithubAPI, user
 		if len(result.Items) < perPage {
 			break
 		}
+		if len(allIssues) >= searchResultLimit {
+			c.logger.Printf("Reached the search result limit (%d)", searchResultLimit)
+			break
+		}
 
 		page++
+		c.saveCheckpoint(cp.recordSearchPage(allIssues, total, page, false))
 	}
 
-	return allIssues, nil
+	c.saveCheckpoint(cp.recordSearchPage(allIssues, total, page, true))
+	return allIssues, max(total, len(allIssues)), nil
 }
 
 // fetchPRDetails fetches detailed information for each PR and aggregates by repository.
-func (c *Client) fetchPRDetails(ctx context.Context, api github.GithubAPI, issues []github.Issue) ([]Contribution, []error) {
+// PRs already stored in the checkpoint are not fetched again. It also returns
+// how many PRs were skipped because ctx was done.
+func (c *Client) fetchPRDetails(ctx context.Context, api github.GithubAPI, issues []github.Issue, cp *checkpoint) ([]Contribution, []error, int) {
 	// Map to aggregate PRs by repository
 	repoMap := make(map[string]*Contribution)
 	var mu sync.Mutex
 	var errors []error
 
-	// Process PRs with limited concurrency
-	semaphore := make(chan struct{}, 5) // Limit to 5 concurrent requests
-	var wg sync.WaitGroup
-
+	var merged []github.Issue
 	for _, issue := range issues {
-		// Skip if not a PR
-		if issue.PullRequest == nil {
-			continue
+		// Skip if not a merged PR
+		if issue.PullRequest != nil && issue.PullRequest.MergedAt != nil {
+			merged = append(merged, issue)
 		}
+	}
 
-		// Skip if not merged
-		if issue.PullRequest.MergedAt == nil {
-			continue
+	unprocessed := forEachLimited(ctx, maxConcurrentRequests, merged, func(iss github.Issue) bool {
+		// Parse repository URL
+		owner, repo, err := github.ParseRepoURL(iss.RepositoryURL)
+		if err != nil {
+			mu.Lock()
+			errors = append(errors, fmt.Errorf("parsing repo URL: %w", err))
+			mu.Unlock()
+			return false
 		}
 
-		wg.Add(1)
-		go func(iss github.Issue) {
-			defer wg.Done()
-
-			// Acquire semaphore
-			select {
-			case semaphore <- struct{}{}:
-				defer func() { <-semaphore }()
-			case <-ctx.Done():
-				return
+		// Fetch PR details if LOC is enabled
+		var additions, deletions, commits int
+		if c.includeLOC {
+			saved, resp, err := c.fetchPRStats(ctx, api, owner, repo, iss.Number, cp)
+			if err != nil && ctx.Err() != nil {
+				return true
 			}
-
-			// Parse repository URL
-			owner, repo, err := github.ParseRepoURL(iss.RepositoryURL)
 			if err != nil {
-				mu.Lock()
-				errors = append(errors, fmt.Errorf("parsing repo URL: %w", err))
-				mu.Unlock()
-				return
-			}
-
-			// Fetch PR details if LOC is enabled
-			var additions, deletions, commits int
-			if c.includeLOC {
-				pr, resp, err := api.GetPullRequest(ctx, owner, repo, iss.Number)
-				if err != nil {
-					if !github.IsRateLimited(resp) {
-						mu.Lock()
-						errors = append(errors, fmt.Errorf("fetching PR %s/%s#%d: %w", owner, repo, iss.Number, err))
-						mu.Unlock()
-					}
-					return
+				// Rate limited lookups are only reported when checkpointing,
+				// so the checkpoint is kept for the next run
+				if cp != nil || !github.IsRateLimited(resp) {
+					mu.Lock()
+					errors = append(errors, fmt.Errorf("fetching PR %s/%s#%d: %w", owner, repo, iss.Number, err))
+					mu.Unlock()
 				}
-				additions = pr.Additions
-				deletions = pr.Deletions
-				commits = pr.Commits
-			} else {
-				commits = 1 // Default to 1 commit per PR if not fetching details
+				return false
 			}
+			additions = saved.Additions
+			deletions = saved.Deletions
+			commits = saved.Commits
+		} else {
+			commits = 1 // Default to 1 commit per PR if not fetching details
+		}
 
-			// Aggregate by repository
-			repoKey := owner + "/" + repo
-			mu.Lock()
-			defer mu.Unlock()
-
-			if contrib, exists := repoMap[repoKey]; exists {
-				// Update existing contribution
-				contrib.PRsMerged++
-				contrib.Commits += commits
-				contrib.Additions += additions
-				contrib.Deletions += deletions
-
-				// Update first/last contribution times
-				mergedAt := *iss.PullRequest.MergedAt
-				if mergedAt.Before(contrib.FirstContribution) {
-					contrib.FirstContribution = mergedAt
-				}
-				if mergedAt.After(contrib.LastContribution) {
-					contrib.LastContribution = mergedAt
-				}
-			} else {
-				// Create new contribution entry
-				repoMap[repoKey] = &Contribution{
-					Repo:              repoKey,
-					Owner:             owner,
-					RepoName:          repo,
-					PRsMerged:         1,
-					Commits:           commits,
-					Additions:         additions,
-					Deletions:         deletions,
-					FirstContribution: *iss.PullRequest.MergedAt,
-					LastContribution:  *iss.PullRequest.MergedAt,
-				}
-			}
-		}(issue)
-	}
+		// Aggregate by repository
+		repoKey := owner + "/" + repo
+		mu.Lock()
+		defer mu.Unlock()
+
+		var prs []PullRequest
+		if c.includePRDetails {
+			prs = []PullRequest{{
+				Number:    iss.Number,
+				Title:     iss.Title,
+				URL:       iss.HTMLURL,
+				MergedAt:  *iss.PullRequest.MergedAt,
+				Commits:   commits,
+				Additions: additions,
+				Deletions: deletions,
+			}}
+		}
 
-	wg.Wait()
+		if contrib, exists := repoMap[repoKey]; exists {
+			// Update existing contribution
+			contrib.PRsMerged++
+			contrib.Commits += commits
+			contrib.Additions += additions
+			contrib.Deletions += deletions
+			contrib.PullRequests = append(contrib.PullRequests, prs...)
+
+			// Update first/last contribution times
+			mergedAt := *iss.PullRequest.MergedAt
+			if mergedAt.Before(contrib.FirstContribution) {
+				contrib.FirstContribution = mergedAt
+			}
+			if mergedAt.After(contrib.LastContribution) {
+				contrib.LastContribution = mergedAt
+			}
+		} else {
+			// Create new contribution entry
+			repoMap[repoKey] = &Contribution{
+				Repo:              repoKey,
+				Owner:             owner,
+				RepoName:          repo,
+				PRsMerged:         1,
+				Commits:           commits,
+				Additions:         additions,
+				Deletions:         deletions,
+				FirstContribution: *iss.PullRequest.MergedAt,
+				LastContribution:  *iss.PullRequest.MergedAt,
+				PullRequests:      prs,
+			}
+		}
+		return false
+	})
 
 	// Convert map to slice
 	contributions := make([]Contribution, 0, len(repoMap))
 	for _, contrib := range repoMap {
+		sortPullRequests(contrib.PullRequests)
 		contributions = append(contributions, *contrib)
 	}
 
-	return contributions, errors
+	return contributions, errors, unprocessed
+}
+
+// fetchPRStats returns the commit and line counts of a PR, from the
+// checkpoint if it was already fetched. Rate limit and permission failures
+// are returned as the package's typed errors, with the response.
+func (c *Client) fetchPRStats(ctx context.Context, api github.GithubAPI, owner, repo string, number int, cp *checkpoint) (checkpointPR, *http.Response, error) {
+	prKey := prCheckpointKey(owner, repo, number)
+	if saved, ok := cp.pullRequest(prKey); ok {
+		return saved, nil, nil
+	}
+
+	var pr *github.PullRequest
+	resp, err := retrySecondaryRateLimit(ctx, func() (*http.Response, error) {
+		var resp *http.Response
+		var err error
+		pr, resp, err = api.GetPullRequest(ctx, owner, repo, number)
+		return resp, err
+	})
+	if err != nil {
+		if typedErr := classifyAPIError(resp, err, owner+"/"+repo); typedErr != nil {
+			err = typedErr
+		}
+		return checkpointPR{}, resp, err
+	}
+
+	saved := checkpointPR{
+		Commits:   pr.Commits,
+		Additions: pr.Additions,
+		Deletions: pr.Deletions,
+	}
+	c.saveCheckpoint(cp.recordPullRequest(prKey, saved))
+	return saved, resp, nil
 }
 
 // enrichWithRepoData fetches repository metadata and enriches contributions.
-func (c *Client) enrichWithRepoData(ctx context.Context, api github.GithubAPI, contributions []Contribution) []Contribution {
-	var wg sync.WaitGroup
-	semaphore := make(chan struct{}, 5) // Limit concurrent requests
+// Repositories already stored in the checkpoint are not fetched again.
+// Contributions whose lookup fails are marked Incomplete and the failures
+// are returned alongside them, with the number of repositories skipped
+// because ctx was done.
+func (c *Client) enrichWithRepoData(ctx context.Context, api github.GithubAPI, contributions []Contribution, cp *checkpoint) ([]Contribution, []error, int) {
+	var mu sync.Mutex
+	var errors []error
 
+	// Contributions stay incomplete until their metadata is known
+	indexes := make([]int, len(contributions))
 	for i := range contributions {
-		wg.Add(1)
-		go func(idx int) {
-			defer wg.Done()
+		contributions[i].Incomplete = true
+		indexes[i] = i
+	}
 
-			// Acquire semaphore
-			select {
-			case semaphore <- struct{}{}:
-				defer func() { <-semaphore }()
-			case <-ctx.Done():
-				return
+	unprocessed := forEachLimited(ctx, maxConcurrentRequests, indexes, func(idx int) bool {
+		contrib := &contributions[idx]
+		saved, ok := cp.repository(contrib.Repo)
+		if !ok {
+			var repo *github.Repository
+			resp, err := retrySecondaryRateLimit(ctx, func() (*http.Response, error) {
+				var resp *http.Response
+				var err error
+				repo, resp, err = api.GetRepository(ctx, contrib.Owner, contrib.RepoName)
+				return resp, err
+			})
+			if err != nil && ctx.Err() != nil {
+				return true
 			}
-
-			contrib := &contributions[idx]
-			repo, _, err := api.GetRepository(ctx, contrib.Owner, contrib.RepoName)
 			if err != nil {
+				if typedErr := classifyAPIError(resp, err, contrib.Repo); typedErr != nil {
+					err = typedErr
+				}
 				c.logger.Printf("Failed to fetch repo %s: %v", contrib.Repo, err)
-				return
+				mu.Lock()
+				errors = append(errors, fmt.Errorf("fetching repository %s: %w", contrib.Repo, err))
+				mu.Unlock()
+				return false
 			}
+			saved = checkpointRepo{
+				Description: repo.Description,
+				HTMLURL:     repo.HTMLURL,
+				Stars:       repo.StargazersCount,
+				Language:    repo.Language,
+			}
+			c.saveCheckpoint(cp.recordRepository(contrib.Repo, saved))
+		}
 
-			contrib.Description = repo.Description
-			contrib.RepoURL = repo.HTMLURL
-			contrib.Stars = repo.StargazersCount
-		}(i)
-	}
+		contrib.Description = saved.Description
+		contrib.RepoURL = saved.HTMLURL
+		contrib.Stars = saved.Stars
+		contrib.Language = saved.Language
+		contrib.Incomplete = false
+		return false
+	})
 
-	wg.Wait()
-	return contributions
+	return contributions, errors, unprocessed
 }
 
 // applyFilters applies client filters to contributions.
@@ -315,6 +462,10 @@ func (c *Client) applyFilters(contributions []Contribution) []Contribution {
 
 	filtered := make([]Contribution, 0, len(contributions))
 	for _, contrib := range contributions {
+		if contrib.Incomplete && c.unknownStars == UnknownStarsInclude {
+			filtered = append(filtered, contrib)
+			continue
+		}
 		if contrib.Stars >= c.minStars {
 			filtered = append(filtered, contrib)
 		}
@@ -324,7 +475,7 @@ func (c *Client) applyFilters(contributions []Contribution) []Contribution {
 }
 
 // calculateSummary calculates aggregate statistics.
-func (c *Client) calculateSummary(contributions []Contribution) Summary {
+func calculateSummary(contributions []Contribution) Summary {
 	summary := Summary{
 		TotalProjects: len(contributions),
 	}
@@ -338,3 +489,138 @@ func (c *Client) calculateSummary(contributions []Contribution) Summary {
 
 	return summary
 }
+
+// retrySecondaryRateLimit runs call, retrying while GitHub responds with a
+// secondary rate limit. Each retry waits as long as Retry-After requests,
+// falling back to exponential backoff, up to github.MaxBackoffAttempts.
+func retrySecondaryRateLimit(ctx context.Context, call func() (*http.Response, error)) (*http.Response, error) {
+	for attempt := 0; ; attempt++ {
+		resp, err := call()
+		if err == nil || github.ClassifyResponse(resp, err) != github.ClassSecondaryRateLimit {
+			return resp, err
+		}
+		if waitErr := github.HandleRateLimit(ctx, resp, attempt); waitErr != nil {
+			return resp, err
+		}
+	}
+}
+
+// classifyAPIError converts rate limit and permission failures into the
+// package's typed errors. It returns nil for any other failure.
+func classifyAPIError(resp *http.Response, err error, resource string) error {
+	var message string
+	var apiErr *github.APIError
+	if errors.As(err, &apiErr) {
+		message = apiErr.Message
+	}
+
+	switch github.ClassifyResponse(resp, err) {
+	case github.ClassPrimaryRateLimit:
+		resetTime := time.Now().Add(time.Minute)
+		if info, err := github.ParseRateLimitHeaders(resp.Header); err == nil {
+			resetTime = info.Reset
+		}
+		return &ErrRateLimited{
+			ResetAt: resetTime,
+			Message: resource + " rate limit exceeded",
+		}
+	case github.ClassSecondaryRateLimit:
+		retryAfter, _ := github.ParseRetryAfter(resp.Header)
+		return &ErrSecondaryRateLimited{
+			RetryAfter: retryAfter,
+			Message:    message,
+		}
+	case github.ClassSSORequired:
+		return &ErrSSORequired{
+			Resource: resource,
+			URL:      github.ParseSSOURL(resp.Header),
+			Message:  message,
+		}
+	case github.ClassForbidden:
+		return &ErrForbidden{
+			Resource: resource,
+			Message:  message,
+		}
+	}
+
+	return nil
+}
+
+// maxConcurrentRequests limits the API requests a run makes at once.
+const maxConcurrentRequests = 5
+
+// forEachLimited calls fn for each item, running at most n calls at once,
+// and waits for them to return. Items not started by the time ctx is done
+// are skipped, and fn reports whether it skipped its item too, e.g. because
+// a lookup failed once ctx was done. It returns the number of skipped items.
+func forEachLimited[T any](ctx context.Context, n int, items []T, fn func(item T) (skipped bool)) int {
+	var wg sync.WaitGroup
+	var skipped atomic.Int64
+	semaphore := make(chan struct{}, n)
+
+	for _, item := range items {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+
+			// select picks at random when both cases are ready
+			if ctx.Err() != nil {
+				skipped.Add(1)
+				return
+			}
+			select {
+			case semaphore <- struct{}{}:
+				defer func() { <-semaphore }()
+			case <-ctx.Done():
+				skipped.Add(1)
+				return
+			}
+			if fn(item) {
+				skipped.Add(1)
+			}
+		}()
+	}
+
+	wg.Wait()
+	return int(skipped.Load())
+}
+
+// errWorkSkipped is the stop cause of work skipped under a context that is
+// done while the run's own context is not, e.g. a provider's own timeout.
+var errWorkSkipped = errors.New("work skipped before its context was done")
+
+// stopCause returns why a run skipped work: the error of ctx, or
+// errWorkSkipped if ctx itself is not done.
+func stopCause(ctx context.Context) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	return errWorkSkipped
+}
+
+// lookupError converts the failed lookup of resource into a typed error:
+// classified 403 and 429 responses, authentication failures, and notFound
+// for a 404. It returns nil for any other failure, which the caller wraps.
+func lookupError(resp *http.Response, err error, resource string, notFound error) error {
+	if typedErr := classifyAPIError(resp, err, resource); typedErr != nil {
+		return typedErr
+	}
+	if resp != nil && resp.StatusCode == http.StatusUnauthorized {
+		return &ErrAuthentication{Message: "invalid or missing token"}
+	}
+	var tokenErr *github.TokenError
+	if errors.As(err, &tokenErr) {
+		return &ErrAuthentication{Message: tokenErr.Error()}
+	}
+	if resp != nil && resp.StatusCode == http.StatusNotFound {
+		return notFound
+	}
+	return nil
+}
+
+// sortPullRequests sorts PRs by merge date, most recent first.
+func sortPullRequests(prs []PullRequest) {
+	slices.SortFunc(prs, func(a, b PullRequest) int {
+		return cmp.Or(b.MergedAt.Compare(a.MergedAt), cmp.Compare(b.Number, a.Number))
+	})
+}
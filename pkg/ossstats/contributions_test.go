@@ -2,10 +2,20 @@ package ossstats
 
 import (
 	"context"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
 	"encoding/json"
+	"encoding/pem"
+	"errors"
 	"net/http"
 	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"reflect"
+	"slices"
 	"strings"
+	"sync/atomic"
 	"testing"
 	"time"
 
@@ -439,8 +449,217 @@ func TestGetContributionsUserNotFound(t *testing.T) {
 	}
 }
 
+func TestGetContributionsSearchForbidden(t *testing.T) {
+	tests := []struct {
+		name    string
+		headers map[string]string
+		message string
+		check   func(t *testing.T, err error)
+	}{
+		{
+			name:    "SAML SSO required",
+			headers: map[string]string{"X-GitHub-SSO": "required; url=https://github.com/orgs/acme/sso"},
+			message: "Resource protected by organization SAML enforcement.",
+			check: func(t *testing.T, err error) {
+				ssoErr, ok := err.(*ErrSSORequired)
+				if !ok {
+					t.Fatalf("Expected *ErrSSORequired, got %T", err)
+				}
+				if ssoErr.URL != "https://github.com/orgs/acme/sso" {
+					t.Errorf("URL = %q, want SSO authorization URL", ssoErr.URL)
+				}
+			},
+		},
+		{
+			name:    "forbidden",
+			headers: map[string]string{"X-RateLimit-Remaining": "4999"},
+			message: "Resource not accessible by personal access token",
+			check: func(t *testing.T, err error) {
+				if _, ok := err.(*ErrForbidden); !ok {
+					t.Fatalf("Expected *ErrForbidden, got %T", err)
+				}
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				for k, v := range tt.headers {
+					w.Header().Set(k, v)
+				}
+				w.WriteHeader(http.StatusForbidden)
+				json.NewEncoder(w).Encode(map[string]string{"message": tt.message})
+			}))
+			defer server.Close()
+
+			client := New(WithToken("test-token"))
+			client.httpClient.Transport = &mockTransport{server: server}
+
+			_, err := client.GetContributions(context.Background(), "testuser")
+			if err == nil {
+				t.Fatal("Expected error, got nil")
+			}
+			tt.check(t, err)
+		})
+	}
+}
+
+func TestGetContributionsSecondaryRateLimitRetried(t *testing.T) {
+	var searchCalls int
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if strings.HasPrefix(r.URL.Path, "/search/issues") {
+			searchCalls++
+			if searchCalls == 1 {
+				w.Header().Set("Retry-After", "0")
+				w.WriteHeader(http.StatusForbidden)
+				w.Write([]byte(`{"message":"You have exceeded a secondary rate limit."}`))
+				return
+			}
+			json.NewEncoder(w).Encode(github.SearchIssuesResponse{Items: []github.Issue{}})
+			return
+		}
+		http.NotFound(w, r)
+	}))
+	defer server.Close()
+
+	client := New(WithToken("test-token"))
+	client.httpClient.Transport = &mockTransport{server: server}
+
+	if _, err := client.GetContributions(context.Background(), "testuser"); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if searchCalls != 2 {
+		t.Errorf("search calls = %d, want 2 (one retry)", searchCalls)
+	}
+}
+
+func TestGetContributionsPRForbiddenReported(t *testing.T) {
+	mergedAt := time.Now().UTC()
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case strings.HasPrefix(r.URL.Path, "/search/issues"):
+			json.NewEncoder(w).Encode(github.SearchIssuesResponse{
+				Items: []github.Issue{{
+					Number:        1,
+					RepositoryURL: "https://api.github.com/repos/sso-org/repo",
+					PullRequest:   &github.PullRequestRef{MergedAt: &mergedAt},
+				}},
+			})
+		case strings.HasPrefix(r.URL.Path, "/repos/sso-org/repo/pulls"):
+			w.Header().Set("X-GitHub-SSO", "required; url=https://github.com/orgs/sso-org/sso")
+			w.WriteHeader(http.StatusForbidden)
+			w.Write([]byte(`{"message":"Resource protected by organization SAML enforcement."}`))
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer server.Close()
+
+	client := New(WithToken("test-token"), WithLOC(true))
+	client.httpClient.Transport = &mockTransport{server: server}
+
+	_, err := client.GetContributions(context.Background(), "testuser")
+	partialErr, ok := err.(*ErrPartialResults)
+	if !ok {
+		t.Fatalf("Expected *ErrPartialResults, got %T (%v)", err, err)
+	}
+
+	var ssoErr *ErrSSORequired
+	if len(partialErr.Errors) != 1 || !errors.As(partialErr.Errors[0], &ssoErr) {
+		t.Fatalf("Errors = %v, want one wrapped *ErrSSORequired", partialErr.Errors)
+	}
+	if ssoErr.Resource != "sso-org/repo" {
+		t.Errorf("Resource = %q, want sso-org/repo", ssoErr.Resource)
+	}
+}
+
+func TestGetContributionsWithGitHubApp(t *testing.T) {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatal(err)
+	}
+	privateKey := pem.EncodeToMemory(&pem.Block{
+		Type:  "RSA PRIVATE KEY",
+		Bytes: x509.MarshalPKCS1PrivateKey(key),
+	})
+
+	var exchanges int
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.URL.Path == "/app/installations/99/access_tokens":
+			exchanges++
+			w.WriteHeader(http.StatusCreated)
+			json.NewEncoder(w).Encode(map[string]any{
+				"token":      "ghs_installation",
+				"expires_at": time.Now().Add(time.Hour).UTC().Format(time.RFC3339),
+			})
+		case strings.HasPrefix(r.URL.Path, "/search/issues"):
+			if got := r.Header.Get("Authorization"); got != "Bearer ghs_installation" {
+				t.Errorf("Authorization = %q, want installation token", got)
+			}
+			json.NewEncoder(w).Encode(github.SearchIssuesResponse{Items: []github.Issue{}})
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer server.Close()
+
+	client := New(WithGitHubApp(12345, 99, privateKey))
+	client.httpClient.Transport = &mockTransport{server: server}
+
+	for i := 0; i < 2; i++ {
+		if _, err := client.GetContributions(context.Background(), "testuser"); err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+	}
+
+	if exchanges != 1 {
+		t.Errorf("token exchanges = %d, want 1 (cached across calls)", exchanges)
+	}
+}
+
+func TestGetContributionsWithGitHubAppErrors(t *testing.T) {
+	t.Run("invalid private key", func(t *testing.T) {
+		client := New(WithGitHubApp(12345, 99, []byte("not a key")))
+
+		_, err := client.GetContributions(context.Background(), "testuser")
+		if _, ok := err.(*ErrAuthentication); !ok {
+			t.Fatalf("Expected *ErrAuthentication, got %T (%v)", err, err)
+		}
+	})
+
+	t.Run("token exchange rejected", func(t *testing.T) {
+		key, err := rsa.GenerateKey(rand.Reader, 2048)
+		if err != nil {
+			t.Fatal(err)
+		}
+		privateKey := pem.EncodeToMemory(&pem.Block{
+			Type:  "RSA PRIVATE KEY",
+			Bytes: x509.MarshalPKCS1PrivateKey(key),
+		})
+
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusNotFound)
+			w.Write([]byte(`{"message":"Integration not found"}`))
+		}))
+		defer server.Close()
+
+		client := New(WithGitHubApp(12345, 99, privateKey))
+		client.httpClient.Transport = &mockTransport{server: server}
+
+		_, err = client.GetContributions(context.Background(), "testuser")
+		authErr, ok := err.(*ErrAuthentication)
+		if !ok {
+			t.Fatalf("Expected *ErrAuthentication, got %T (%v)", err, err)
+		}
+		if !contains(authErr.Message, "Integration not found") {
+			t.Errorf("Message = %q, want exchange error details", authErr.Message)
+		}
+	})
+}
+
 func TestCalculateSummary(t *testing.T) {
-	client := New()
 
 	contributions := []Contribution{
 		{
@@ -457,7 +676,7 @@ func TestCalculateSummary(t *testing.T) {
 		},
 	}
 
-	summary := client.calculateSummary(contributions)
+	summary := calculateSummary(contributions)
 
 	if summary.TotalProjects != 2 {
 		t.Errorf("TotalProjects = %d, want 2", summary.TotalProjects)
@@ -548,11 +767,15 @@ func TestGetContributionsMultiplePRsSameRepo(t *testing.T) {
 				Items: []github.Issue{
 					{
 						Number:        1,
+						Title:         "Add feature",
+						HTMLURL:       "https://github.com/owner/repo/pull/1",
 						RepositoryURL: "https://api.github.com/repos/owner/repo",
 						PullRequest:   &github.PullRequestRef{MergedAt: &mergedAt1},
 					},
 					{
 						Number:        2,
+						Title:         "Fix bug",
+						HTMLURL:       "https://github.com/owner/repo/pull/2",
 						RepositoryURL: "https://api.github.com/repos/owner/repo",
 						PullRequest:   &github.PullRequestRef{MergedAt: &mergedAt2},
 					},
@@ -614,6 +837,138 @@ func TestGetContributionsMultiplePRsSameRepo(t *testing.T) {
 	if !contrib.LastContribution.Equal(mergedAt2) {
 		t.Errorf("LastContribution = %v, want %v", contrib.LastContribution, mergedAt2)
 	}
+
+	if contrib.PullRequests != nil {
+		t.Errorf("PullRequests = %+v, want none without WithPRDetails", contrib.PullRequests)
+	}
+
+	// With PR details, the PRs are listed most recent first
+	client = New(
+		WithToken("test-token"),
+		WithPRDetails(true),
+	)
+	client.httpClient.Transport = &mockTransport{server: server}
+
+	stats, err = client.GetContributions(context.Background(), "testuser")
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	want := []PullRequest{
+		{Number: 2, Title: "Fix bug", URL: "https://github.com/owner/repo/pull/2", MergedAt: mergedAt2, Commits: 1},
+		{Number: 1, Title: "Add feature", URL: "https://github.com/owner/repo/pull/1", MergedAt: mergedAt1, Commits: 1},
+	}
+	if got := stats.Contributions[0].PullRequests; !reflect.DeepEqual(got, want) {
+		t.Errorf("PullRequests = %+v, want %+v", got, want)
+	}
+}
+
+func TestForEachLimited(t *testing.T) {
+	items := make([]int, 20)
+	var running, peak atomic.Int64
+	skipped := forEachLimited(context.Background(), 3, items, func(int) bool {
+		n := running.Add(1)
+		for {
+			old := peak.Load()
+			if n <= old || peak.CompareAndSwap(old, n) {
+				break
+			}
+		}
+		time.Sleep(time.Millisecond)
+		running.Add(-1)
+		return false
+	})
+	if skipped != 0 || peak.Load() > 3 {
+		t.Errorf("forEachLimited() skipped %d with %d calls at once, want 0 skipped and at most 3 at once", skipped, peak.Load())
+	}
+
+	// Items are skipped once ctx is done, or when fn reports so
+	if skipped := forEachLimited(context.Background(), 3, []bool{true, false, true}, func(skip bool) bool { return skip }); skipped != 2 {
+		t.Errorf("forEachLimited() skipped %d items reported by fn, want 2", skipped)
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	var calls atomic.Int64
+	skipped = forEachLimited(ctx, 1, items, func(int) bool {
+		calls.Add(1)
+		return false
+	})
+	if skipped != len(items) || calls.Load() != 0 {
+		t.Errorf("forEachLimited() after cancel skipped %d and called fn %d times, want all skipped", skipped, calls.Load())
+	}
+}
+
+// skippingProvider is a Provider that reports one PR skipped by its own,
+// already done, context.
+type skippingProvider struct{}
+
+func (skippingProvider) Name() string { return "skipping" }
+
+func (skippingProvider) Contributions(ctx context.Context, username string, opts ProviderOptions) (*ProviderResult, error) {
+	return &ProviderResult{
+		Contributions:  []Contribution{{Repo: "other/repo", Owner: "other", RepoName: "repo", PRsMerged: 1}},
+		UnprocessedPRs: 1,
+	}, nil
+}
+
+func TestGetContributionsStoppedEarlyCause(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(github.SearchIssuesResponse{Items: []github.Issue{}})
+	}))
+	defer server.Close()
+
+	client := New(WithToken("test-token"), WithProvider(skippingProvider{}))
+	client.httpClient.Transport = &mockTransport{server: server}
+
+	_, err := client.GetContributions(context.Background(), "octocat")
+	var partialErr *ErrPartialResults
+	if !errors.As(err, &partialErr) {
+		t.Fatalf("GetContributions() error = %v, want ErrPartialResults", err)
+	}
+	if len(partialErr.Errors) != 1 || !errors.Is(partialErr.Errors[0], errWorkSkipped) || strings.Contains(err.Error(), "%!") {
+		t.Errorf("GetContributions() error = %v, want errWorkSkipped as the cause", err)
+	}
+}
+
+func TestGetContributionsCancelledBeforeFirstLookup(t *testing.T) {
+	mergedAt := time.Date(2025, 6, 1, 12, 0, 0, 0, time.UTC)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if strings.HasPrefix(r.URL.Path, "/search/issues") {
+			w.Header().Set("Content-Type", "application/json")
+			json.NewEncoder(w).Encode(github.SearchIssuesResponse{
+				TotalCount: 1,
+				Items: []github.Issue{{
+					Number:        1,
+					RepositoryURL: "https://api.github.com/repos/alpha/one",
+					PullRequest:   &github.PullRequestRef{MergedAt: &mergedAt},
+				}},
+			})
+			return
+		}
+		// The run is cancelled while the only PR is being looked up
+		cancel()
+		<-r.Context().Done()
+	}))
+	defer server.Close()
+
+	client := New(WithToken("test-token"), WithLOC(true))
+	client.httpClient.Transport = &mockTransport{server: server}
+
+	stats, err := client.GetContributions(ctx, "testuser")
+	var partialErr *ErrPartialResults
+	if !errors.As(err, &partialErr) {
+		t.Fatalf("GetContributions() error = %v, want ErrPartialResults", err)
+	}
+	if partialErr.UnprocessedPRs != 1 || len(stats.Contributions) != 0 {
+		t.Errorf("UnprocessedPRs = %d with %d contributions, want 1 and none", partialErr.UnprocessedPRs, len(stats.Contributions))
+	}
+	if !errors.Is(partialErr.Errors[0], context.Canceled) {
+		t.Errorf("Errors = %v, want context.Canceled as the cause", partialErr.Errors)
+	}
 }
 
 // mockTransport redirects requests to test server
@@ -628,3 +983,139 @@ func (t *mockTransport) RoundTrip(req *http.Request) (*http.Response, error) {
 
 	return http.DefaultTransport.RoundTrip(req)
 }
+
+func TestGetContributionsRecordAndReplay(t *testing.T) {
+	mergedAt := time.Date(2025, 6, 1, 12, 0, 0, 0, time.UTC)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch {
+		case strings.HasPrefix(r.URL.Path, "/search/issues"):
+			json.NewEncoder(w).Encode(github.SearchIssuesResponse{
+				TotalCount: 1,
+				Items: []github.Issue{{
+					Number:        7,
+					RepositoryURL: "https://api.github.com/repos/alpha/one",
+					PullRequest:   &github.PullRequestRef{MergedAt: &mergedAt},
+				}},
+			})
+		case r.URL.Path == "/repos/alpha/one/pulls/7":
+			json.NewEncoder(w).Encode(github.PullRequest{Number: 7, Commits: 2, Additions: 40, Deletions: 4})
+		case r.URL.Path == "/repos/alpha/one":
+			json.NewEncoder(w).Encode(github.Repository{FullName: "alpha/one", StargazersCount: 99, HTMLURL: "https://github.com/alpha/one"})
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer server.Close()
+
+	dir := t.TempDir()
+	recordClient := New(WithToken("test-token"), WithLOC(true), WithRecord(dir))
+	recordClient.httpClient.Transport = &mockTransport{server: server}
+
+	recorded, err := recordClient.GetContributions(context.Background(), "testuser")
+	if err != nil {
+		t.Fatalf("Recording run error: %v", err)
+	}
+	server.Close()
+
+	replayed, err := New(WithLOC(true), WithReplay(dir)).GetContributions(context.Background(), "testuser")
+	if err != nil {
+		t.Fatalf("Replay run error: %v", err)
+	}
+
+	if replayed.Summary != recorded.Summary {
+		t.Errorf("Replayed summary = %+v, want %+v", replayed.Summary, recorded.Summary)
+	}
+	if len(replayed.Contributions) != 1 || replayed.Contributions[0].Stars != 99 {
+		t.Errorf("Replayed contributions = %+v, want alpha/one with 99 stars", replayed.Contributions)
+	}
+}
+
+func TestGetContributionsReplayMissingRecordings(t *testing.T) {
+	client := New(WithReplay(t.TempDir()))
+	if _, err := client.GetContributions(context.Background(), "testuser"); err == nil {
+		t.Error("Expected error when replaying an empty directory")
+	}
+}
+
+func TestGetContributionsRepoEnrichmentFailure(t *testing.T) {
+	scenario := `{
+  "users": {
+    "octocat": [
+      {"repo": "golang/go", "number": 1, "merged_at": "2025-03-04T12:00:00Z"},
+      {"repo": "broken/repo", "number": 2, "merged_at": "2025-03-05T12:00:00Z"}
+    ]
+  },
+  "repositories": [{"full_name": "golang/go", "stargazers_count": 500}],
+  "failures": [{"call": "GetRepository", "target": "broken/repo", "status": 500}]
+}`
+	path := filepath.Join(t.TempDir(), "scenario.json")
+	if err := os.WriteFile(path, []byte(scenario), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name      string
+		opts      []Option
+		wantRepos []string
+	}{
+		{"no min stars", nil, []string{"golang/go", "broken/repo"}},
+		{"min stars excludes unknown", []Option{WithMinStars(100)}, []string{"golang/go"}},
+		{"min stars includes unknown", []Option{WithMinStars(100), WithUnknownStarsPolicy(UnknownStarsInclude)}, []string{"golang/go", "broken/repo"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client := New(append([]Option{WithDebugScenario(path)}, tt.opts...)...)
+
+			_, err := client.GetContributions(context.Background(), "octocat")
+			partialErr, ok := err.(*ErrPartialResults)
+			if !ok {
+				t.Fatalf("Expected *ErrPartialResults, got %T (%v)", err, err)
+			}
+			if len(partialErr.Errors) != 1 || !strings.Contains(partialErr.Errors[0].Error(), "broken/repo") {
+				t.Errorf("Errors = %v, want the broken/repo lookup failure", partialErr.Errors)
+			}
+
+			var repos []string
+			for _, contrib := range partialErr.Stats.Contributions {
+				repos = append(repos, contrib.Repo)
+				wantIncomplete := contrib.Repo == "broken/repo"
+				if contrib.Incomplete != wantIncomplete {
+					t.Errorf("%s Incomplete = %v, want %v", contrib.Repo, contrib.Incomplete, wantIncomplete)
+				}
+			}
+			slices.Sort(repos)
+			want := slices.Clone(tt.wantRepos)
+			slices.Sort(want)
+			if !slices.Equal(repos, want) {
+				t.Errorf("Contributions = %v, want %v", repos, want)
+			}
+		})
+	}
+}
+
+func TestParseUnknownStarsPolicy(t *testing.T) {
+	tests := []struct {
+		input   string
+		want    UnknownStarsPolicy
+		wantErr bool
+	}{
+		{"exclude", UnknownStarsExclude, false},
+		{"include", UnknownStarsInclude, false},
+		{"maybe", UnknownStarsExclude, true},
+	}
+
+	for _, tt := range tests {
+		got, err := ParseUnknownStarsPolicy(tt.input)
+		if (err != nil) != tt.wantErr {
+			t.Errorf("ParseUnknownStarsPolicy(%q) error = %v, wantErr %v", tt.input, err, tt.wantErr)
+		}
+		if got != tt.want {
+			t.Errorf("ParseUnknownStarsPolicy(%q) = %v, want %v", tt.input, got, tt.want)
+		}
+		if !tt.wantErr && got.String() != tt.input {
+			t.Errorf("String() = %q, want %q", got.String(), tt.input)
+		}
+	}
+}
@@ -0,0 +1,322 @@
+package ossstats
+
+import (
+	"cmp"
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/mabd-dev/gh-oss-stats/internal/github"
+)
+
+// RepoContributors lists the external contributors of a repository: authors
+// of merged PRs who are neither its owner nor members of its owning
+// organization.
+type RepoContributors struct {
+	Repo         string                  `json:"repo"`
+	Description  string                  `json:"description"`
+	RepoURL      string                  `json:"repoURL"`
+	Stars        int                     `json:"stars"`
+	GeneratedAt  time.Time               `json:"generatedAt"`
+	Summary      RepoContributorsSummary `json:"summary"`
+	Contributors []ExternalContributor   `json:"contributors"`
+
+	// SearchedPRs is the number of merged PRs searched, and TotalPRs the
+	// number the search matched. Truncated is set when WithMaxPRs or the
+	// search's limit of 1000 results left PRs out: only the most recently
+	// updated PRs are counted, so PR counts are low and first contribution
+	// dates may be too late.
+	SearchedPRs int  `json:"searchedPRs"`
+	TotalPRs    int  `json:"totalPRs"`
+	Truncated   bool `json:"truncated,omitempty"`
+}
+
+// RepoContributorsSummary contains aggregate statistics of RepoContributors.
+type RepoContributorsSummary struct {
+	TotalContributors int `json:"totalContributors"`
+	TotalPRsMerged    int `json:"totalPRsMerged"`
+	TotalCommits      int `json:"totalCommits"`
+	TotalAdditions    int `json:"totalAdditions"`
+	TotalDeletions    int `json:"totalDeletions"`
+}
+
+// ExternalContributor is an external author's contribution to a repository.
+type ExternalContributor struct {
+	Username          string    `json:"username"`
+	PRsMerged         int       `json:"prsMerged"`
+	Commits           int       `json:"commits"`
+	Additions         int       `json:"additions"`
+	Deletions         int       `json:"deletions"`
+	FirstContribution time.Time `json:"firstContribution"` // First merged PR
+	LastContribution  time.Time `json:"lastContribution"`  // Most recent merged PR
+}
+
+// GetRepoContributors finds the merged PRs to a GitHub repository, given as
+// "owner/repo", and aggregates them by author. Authors who own the
+// repository or are members of its owning organization, as reported by
+// GitHub's author association, are left out, as are bots.
+//
+// Commits and lines are only counted with WithLOC; otherwise every PR counts
+// as one commit. WithMaxPRs limits the number of PRs searched, as does
+// GitHub's limit of 1000 search results; RepoContributors.Truncated reports
+// when either left PRs out. If PR lookups
+// fail or the timeout cuts the run short, the contributors collected so far
+// are returned with an ErrPartialResults whose Stats field is nil.
+func (c *Client) GetRepoContributors(ctx context.Context, repo string) (*RepoContributors, error) {
+	owner, name, ok := strings.Cut(strings.TrimSpace(repo), "/")
+	if !ok || owner == "" || name == "" || strings.Contains(name, "/") {
+		return nil, fmt.Errorf("invalid repository %q, expected owner/repo", repo)
+	}
+	repo = owner + "/" + name
+
+	ctx, cancel := context.WithTimeout(ctx, c.timeout)
+	defer cancel()
+
+	apiClient, err := c.newAPIClient()
+	if err != nil {
+		return nil, err
+	}
+
+	// Step 1: Fetch repository metadata, which also checks the repository exists
+	c.logger.Printf("Fetching repository %s...", repo)
+	info, err := c.fetchRepository(ctx, apiClient, owner, name)
+	if err != nil {
+		return nil, err
+	}
+
+	query := fmt.Sprintf("repo:%s type:pr is:merged", repo)
+	var cp *checkpoint
+	if c.checkpointPath != "" {
+		var resumed bool
+		cp, resumed, err = loadCheckpoint(c.checkpointPath, repo, query)
+		if err != nil {
+			return nil, err
+		}
+		if resumed {
+			c.logger.Printf("Resuming from checkpoint %s (%d PRs already fetched)", c.checkpointPath, len(cp.PullRequests))
+		}
+	}
+
+	// Step 2: Search for the repository's merged PRs
+	c.logger.Printf("Searching for merged PRs...")
+	issues, total, err := c.searchMergedPRs(ctx, apiClient, repo, query, cp)
+	if err != nil {
+		return nil, err
+	}
+	c.logger.Printf("Found %d merged PRs", len(issues))
+	if total > len(issues) {
+		c.logger.Printf("Warning: only the %d most recently updated of %d merged PRs were searched", len(issues), total)
+	}
+
+	// Step 3: Aggregate the external authors' PRs
+	contributors, errs, unprocessed := c.aggregateContributors(ctx, apiClient, owner, name, issues, cp)
+	c.saveCheckpoint(cp.flush())
+
+	result := &RepoContributors{
+		Repo:         repo,
+		Description:  info.Description,
+		RepoURL:      info.HTMLURL,
+		Stars:        info.StargazersCount,
+		GeneratedAt:  time.Now().UTC(),
+		Contributors: contributors,
+		SearchedPRs:  len(issues),
+		TotalPRs:     total,
+		Truncated:    total > len(issues),
+	}
+	if info.FullName != "" {
+		result.Repo = info.FullName
+	}
+	for _, contributor := range contributors {
+		result.Summary.TotalPRsMerged += contributor.PRsMerged
+		result.Summary.TotalCommits += contributor.Commits
+		result.Summary.TotalAdditions += contributor.Additions
+		result.Summary.TotalDeletions += contributor.Deletions
+	}
+	result.Summary.TotalContributors = len(contributors)
+
+	message := fmt.Sprintf("collected %d contributors with errors", len(contributors))
+	if unprocessed > 0 {
+		cause := stopCause(ctx)
+		errs = append(errs, fmt.Errorf("stopped early: %w", cause))
+		message = fmt.Sprintf("stopped early (%v) after collecting %d contributors", cause, len(contributors))
+	}
+	if len(errs) > 0 {
+		c.logger.Printf("Completed with %d errors", len(errs))
+		return result, &ErrPartialResults{
+			Errors:         errs,
+			Message:        message,
+			UnprocessedPRs: unprocessed,
+		}
+	}
+
+	c.removeCheckpoint(cp)
+	c.logger.Printf("Successfully fetched %d contributors", len(contributors))
+	return result, nil
+}
+
+// fetchRepository fetches a repository's metadata.
+func (c *Client) fetchRepository(ctx context.Context, api github.GithubAPI, owner, name string) (*github.Repository, error) {
+	repo := owner + "/" + name
+
+	var info *github.Repository
+	resp, err := retrySecondaryRateLimit(ctx, func() (*http.Response, error) {
+		var resp *http.Response
+		var err error
+		info, resp, err = api.GetRepository(ctx, owner, name)
+		return resp, err
+	})
+	if err != nil {
+		if typedErr := lookupError(resp, err, repo, &ErrRepoNotFound{Repo: repo}); typedErr != nil {
+			return nil, typedErr
+		}
+		return nil, fmt.Errorf("fetching repository %s: %w", repo, err)
+	}
+
+	return info, nil
+}
+
+// isExternalAuthor reports whether the author of a PR to a repository owned
+// by owner is neither the owner, a member of the owning organization nor a bot.
+func isExternalAuthor(issue github.Issue, owner string) bool {
+	switch {
+	case issue.User.Login == "", issue.User.Type == "Bot", strings.EqualFold(issue.User.Login, owner):
+		return false
+	case issue.AuthorAssociation == "OWNER", issue.AuthorAssociation == "MEMBER":
+		return false
+	}
+	return true
+}
+
+// aggregateContributors groups the merged PRs of external authors by author,
+// fetching their commit and line counts when LOC is enabled. It returns the
+// failed lookups and how many PRs were skipped because ctx was done.
+func (c *Client) aggregateContributors(ctx context.Context, api github.GithubAPI, owner, name string, issues []github.Issue, cp *checkpoint) ([]ExternalContributor, []error, int) {
+	var (
+		byAuthor = make(map[string]*ExternalContributor)
+		mu       sync.Mutex
+		errs     []error
+	)
+
+	add := func(iss github.Issue, commits, additions, deletions int) {
+		mergedAt := *iss.PullRequest.MergedAt
+		key := strings.ToLower(iss.User.Login)
+		mu.Lock()
+		defer mu.Unlock()
+
+		contributor, ok := byAuthor[key]
+		if !ok {
+			contributor = &ExternalContributor{
+				Username:          iss.User.Login,
+				FirstContribution: mergedAt,
+				LastContribution:  mergedAt,
+			}
+			byAuthor[key] = contributor
+		}
+		contributor.PRsMerged++
+		contributor.Commits += commits
+		contributor.Additions += additions
+		contributor.Deletions += deletions
+		// Name contributors by the login of their earliest merged PR; only
+		// PRs merged at the same instant depend on goroutine order
+		if mergedAt.Before(contributor.FirstContribution) {
+			contributor.FirstContribution = mergedAt
+			contributor.Username = iss.User.Login
+		}
+		if mergedAt.After(contributor.LastContribution) {
+			contributor.LastContribution = mergedAt
+		}
+	}
+
+	var merged []github.Issue
+	for _, issue := range issues {
+		if issue.PullRequest != nil && issue.PullRequest.MergedAt != nil && isExternalAuthor(issue, owner) {
+			merged = append(merged, issue)
+		}
+	}
+
+	var unprocessed int
+	if !c.includeLOC {
+		for _, iss := range merged {
+			add(iss, 1, 0, 0) // Default to 1 commit per PR if not fetching details
+		}
+	} else {
+		unprocessed = forEachLimited(ctx, maxConcurrentRequests, merged, func(iss github.Issue) bool {
+			saved, _, err := c.fetchPRStats(ctx, api, owner, name, iss.Number, cp)
+			if err != nil && ctx.Err() != nil {
+				return true
+			}
+			if err != nil {
+				mu.Lock()
+				errs = append(errs, fmt.Errorf("fetching PR %s/%s#%d: %w", owner, name, iss.Number, err))
+				mu.Unlock()
+				return false
+			}
+			add(iss, saved.Commits, saved.Additions, saved.Deletions)
+			return false
+		})
+	}
+
+	contributors := make([]ExternalContributor, 0, len(byAuthor))
+	for _, contributor := range byAuthor {
+		contributors = append(contributors, *contributor)
+	}
+	slices.SortFunc(contributors, func(a, b ExternalContributor) int {
+		return cmp.Or(
+			cmp.Compare(b.PRsMerged, a.PRsMerged),
+			cmp.Compare(b.Additions+b.Deletions, a.Additions+a.Deletions),
+			cmp.Compare(strings.ToLower(a.Username), strings.ToLower(b.Username)),
+		)
+	})
+
+	return contributors, errs, unprocessed
+}
+
+// Markdown renders the contributors as a Markdown document with one table
+// row per contributor. Lines are only shown when they were counted.
+func (r *RepoContributors) Markdown() string {
+	var b strings.Builder
+
+	fmt.Fprintf(&b, "## External contributors to %s\n\n", markdownRepoLink(r.Repo, r.RepoURL))
+	fmt.Fprintf(&b, "_Generated %s_\n\n", formatDate(r.GeneratedAt))
+	fmt.Fprintf(&b, "%d contributors, %d merged PRs.\n", r.Summary.TotalContributors, r.Summary.TotalPRsMerged)
+	if r.Truncated {
+		fmt.Fprintf(&b, "\n_Only the %d most recently updated of %d merged PRs were searched, so PR counts and first contributions are incomplete._\n",
+			r.SearchedPRs, r.TotalPRs)
+	}
+	if len(r.Contributors) == 0 {
+		return b.String()
+	}
+
+	// Link profiles on the repository's host, e.g. a GitHub Enterprise Server
+	profileBase := "https://github.com"
+	if u, err := url.Parse(r.RepoURL); err == nil && u.Host != "" {
+		profileBase = u.Scheme + "://" + u.Host
+	}
+
+	withLOC := r.Summary.TotalAdditions > 0 || r.Summary.TotalDeletions > 0
+	if withLOC {
+		b.WriteString("\n| Contributor | PRs | Lines | First | Last |\n")
+		b.WriteString("|-------------|-----|-------|-------|------|\n")
+	} else {
+		b.WriteString("\n| Contributor | PRs | First | Last |\n")
+		b.WriteString("|-------------|-----|-------|------|\n")
+	}
+	for _, contributor := range r.Contributors {
+		user := fmt.Sprintf("[%s](%s/%s)", contributor.Username, profileBase, contributor.Username)
+		if withLOC {
+			fmt.Fprintf(&b, "| %s | %d | +%d -%d | %s | %s |\n", user, contributor.PRsMerged,
+				contributor.Additions, contributor.Deletions,
+				formatDate(contributor.FirstContribution), formatDate(contributor.LastContribution))
+		} else {
+			fmt.Fprintf(&b, "| %s | %d | %s | %s |\n", user, contributor.PRsMerged,
+				formatDate(contributor.FirstContribution), formatDate(contributor.LastContribution))
+		}
+	}
+
+	return b.String()
+}
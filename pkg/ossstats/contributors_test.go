@@ -0,0 +1,222 @@
+package ossstats
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/mabd-dev/gh-oss-stats/internal/github"
+)
+
+// newContributorsServer starts a GitHub stand-in where golang/go has merged
+// PRs by alice (#1, #2), bob (#3), the org member rsc (#4), the owner (#5)
+// and a bot (#6).
+func newContributorsServer(t *testing.T, query *string) *httptest.Server {
+	t.Helper()
+
+	pr := func(number int, login, userType, association string) github.Issue {
+		mergedAt := time.Date(2025, 1, number, 12, 0, 0, 0, time.UTC)
+		return github.Issue{
+			Number:            number,
+			RepositoryURL:     "https://api.github.com/repos/golang/go",
+			User:              github.User{Login: login, Type: userType},
+			AuthorAssociation: association,
+			PullRequest:       &github.PullRequestRef{MergedAt: &mergedAt},
+		}
+	}
+	issues := []github.Issue{
+		pr(1, "alice", "User", "CONTRIBUTOR"),
+		pr(2, "Alice", "User", "CONTRIBUTOR"),
+		pr(3, "bob", "User", "FIRST_TIME_CONTRIBUTOR"),
+		pr(4, "rsc", "User", "MEMBER"),
+		pr(5, "golang", "Organization", "OWNER"),
+		pr(6, "dependabot[bot]", "Bot", "NONE"),
+	}
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch {
+		case r.URL.Path == "/repos/golang/go":
+			json.NewEncoder(w).Encode(github.Repository{FullName: "golang/go", StargazersCount: 120, HTMLURL: "https://github.com/golang/go"})
+		case strings.HasPrefix(r.URL.Path, "/search/issues"):
+			*query = r.URL.Query().Get("q")
+			json.NewEncoder(w).Encode(github.SearchIssuesResponse{TotalCount: len(issues), Items: issues})
+		case strings.HasPrefix(r.URL.Path, "/repos/golang/go/pulls/"):
+			var number int
+			fmt.Sscanf(strings.TrimPrefix(r.URL.Path, "/repos/golang/go/pulls/"), "%d", &number)
+			json.NewEncoder(w).Encode(github.PullRequest{Number: number, Commits: 1, Additions: 10 * number, Deletions: number})
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	t.Cleanup(server.Close)
+	return server
+}
+
+func TestGetRepoContributors(t *testing.T) {
+	var query string
+	server := newContributorsServer(t, &query)
+
+	client := New(WithToken("test-token"), WithLOC(true))
+	client.httpClient.Transport = &mockTransport{server: server}
+
+	result, err := client.GetRepoContributors(context.Background(), "golang/go")
+	if err != nil {
+		t.Fatalf("GetRepoContributors() error = %v", err)
+	}
+
+	if query != "repo:golang/go type:pr is:merged" {
+		t.Errorf("Search query = %q", query)
+	}
+	if result.Repo != "golang/go" || result.Stars != 120 || result.RepoURL != "https://github.com/golang/go" {
+		t.Errorf("Repository = %+v", result)
+	}
+
+	// The member, the owner and the bot are left out; alice's PRs are
+	// merged regardless of the login's case
+	if len(result.Contributors) != 2 {
+		t.Fatalf("Contributors = %+v, want alice and bob", result.Contributors)
+	}
+	alice, bob := result.Contributors[0], result.Contributors[1]
+	if alice.Username != "alice" || alice.PRsMerged != 2 || alice.Commits != 2 || alice.Additions != 30 || alice.Deletions != 3 {
+		t.Errorf("alice = %+v, want 2 PRs, 2 commits, +30 -3", alice)
+	}
+	if !alice.FirstContribution.Equal(time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)) ||
+		!alice.LastContribution.Equal(time.Date(2025, 1, 2, 12, 0, 0, 0, time.UTC)) {
+		t.Errorf("alice dates = %v - %v", alice.FirstContribution, alice.LastContribution)
+	}
+	if bob.Username != "bob" || bob.PRsMerged != 1 {
+		t.Errorf("bob = %+v, want 1 PR", bob)
+	}
+
+	want := RepoContributorsSummary{TotalContributors: 2, TotalPRsMerged: 3, TotalCommits: 3, TotalAdditions: 60, TotalDeletions: 6}
+	if result.Summary != want {
+		t.Errorf("Summary = %+v, want %+v", result.Summary, want)
+	}
+	if result.Truncated || result.SearchedPRs != 6 || result.TotalPRs != 6 {
+		t.Errorf("SearchedPRs = %d of %d, Truncated = %v, want all 6 PRs searched", result.SearchedPRs, result.TotalPRs, result.Truncated)
+	}
+}
+
+func TestGetRepoContributorsTruncated(t *testing.T) {
+	var query string
+	server := newContributorsServer(t, &query)
+
+	client := New(WithToken("test-token"), WithMaxPRs(3))
+	client.httpClient.Transport = &mockTransport{server: server}
+
+	result, err := client.GetRepoContributors(context.Background(), "golang/go")
+	if err != nil {
+		t.Fatalf("GetRepoContributors() error = %v", err)
+	}
+	if !result.Truncated || result.SearchedPRs != 3 || result.TotalPRs != 6 {
+		t.Errorf("SearchedPRs = %d of %d, Truncated = %v, want 3 of 6 and truncated", result.SearchedPRs, result.TotalPRs, result.Truncated)
+	}
+	if !strings.Contains(result.Markdown(), "Only the 3 most recently updated of 6 merged PRs were searched") {
+		t.Errorf("Markdown() does not mention the truncation:\n%s", result.Markdown())
+	}
+}
+
+func TestGetRepoContributorsWithoutLOC(t *testing.T) {
+	var query string
+	server := newContributorsServer(t, &query)
+
+	client := New(WithToken("test-token"))
+	client.httpClient.Transport = &mockTransport{server: server}
+
+	result, err := client.GetRepoContributors(context.Background(), "golang/go")
+	if err != nil {
+		t.Fatalf("GetRepoContributors() error = %v", err)
+	}
+	if result.Summary.TotalCommits != 3 || result.Summary.TotalAdditions != 0 {
+		t.Errorf("Summary = %+v, want one commit per PR and no lines", result.Summary)
+	}
+}
+
+func TestGetRepoContributorsErrors(t *testing.T) {
+	var query string
+	server := newContributorsServer(t, &query)
+
+	client := New(WithToken("test-token"))
+	client.httpClient.Transport = &mockTransport{server: server}
+
+	for _, repo := range []string{"golang", "/go", "golang/", "golang/go/extra"} {
+		if _, err := client.GetRepoContributors(context.Background(), repo); err == nil {
+			t.Errorf("GetRepoContributors(%q) error = nil, want invalid repository", repo)
+		}
+	}
+
+	_, err := client.GetRepoContributors(context.Background(), "golang/missing")
+	var notFound *ErrRepoNotFound
+	if !errors.As(err, &notFound) || notFound.Repo != "golang/missing" {
+		t.Errorf("GetRepoContributors() error = %v, want ErrRepoNotFound", err)
+	}
+}
+
+func TestAggregateContributorsUsername(t *testing.T) {
+	pr := func(number, day int, login string) github.Issue {
+		mergedAt := time.Date(2025, 1, day, 12, 0, 0, 0, time.UTC)
+		return github.Issue{
+			Number:            number,
+			User:              github.User{Login: login, Type: "User"},
+			AuthorAssociation: "CONTRIBUTOR",
+			PullRequest:       &github.PullRequestRef{MergedAt: &mergedAt},
+		}
+	}
+	// The later PR comes first, so the earlier one must replace its login
+	issues := []github.Issue{pr(2, 5, "carol"), pr(1, 1, "Carol"), pr(3, 9, "CAROL")}
+
+	client := New(WithToken("test-token"))
+	for range 20 {
+		contributors, errs, unprocessed := client.aggregateContributors(context.Background(), nil, "golang", "go", issues, nil)
+		if len(errs) > 0 || unprocessed > 0 {
+			t.Fatalf("aggregateContributors() errs = %v, unprocessed = %d", errs, unprocessed)
+		}
+		if len(contributors) != 1 || contributors[0].PRsMerged != 3 {
+			t.Fatalf("Contributors = %+v, want carol with 3 PRs", contributors)
+		}
+		if contributors[0].Username != "Carol" {
+			t.Fatalf("Username = %q, want the earliest PR's login %q", contributors[0].Username, "Carol")
+		}
+	}
+}
+
+func TestRepoContributorsMarkdown(t *testing.T) {
+	day := time.Date(2025, 6, 1, 0, 0, 0, 0, time.UTC)
+	result := &RepoContributors{
+		Repo:        "acme/app",
+		RepoURL:     "https://ghe.example.com/acme/app",
+		GeneratedAt: day,
+		Summary:     RepoContributorsSummary{TotalContributors: 1, TotalPRsMerged: 2},
+		Contributors: []ExternalContributor{
+			{Username: "alice", PRsMerged: 2, FirstContribution: day, LastContribution: day},
+		},
+	}
+
+	got := result.Markdown()
+	for _, want := range []string{
+		"## External contributors to [acme/app](https://ghe.example.com/acme/app)",
+		"1 contributors, 2 merged PRs.",
+		"| Contributor | PRs | First | Last |",
+		"| [alice](https://ghe.example.com/alice) | 2 | 2025-06-01 | 2025-06-01 |",
+	} {
+		if !strings.Contains(got, want) {
+			t.Errorf("Markdown() missing %q:\n%s", want, got)
+		}
+	}
+	if strings.Contains(got, "were searched") {
+		t.Errorf("Markdown() of a complete search mentions truncation:\n%s", got)
+	}
+
+	result.Summary.TotalAdditions = 5
+	result.Contributors[0].Additions = 5
+	if got := result.Markdown(); !strings.Contains(got, "| 2 | +5 -0 |") {
+		t.Errorf("Markdown() with lines missing line counts:\n%s", got)
+	}
+}
@@ -0,0 +1,736 @@
+package ossstats
+
+import (
+	"bufio"
+	"bytes"
+	"cmp"
+	"context"
+	"encoding/json"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"maps"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"regexp"
+	"slices"
+	"strings"
+)
+
+// Dependency ecosystems, as used in Dependency.Ecosystem and as the keys of
+// a dependency mapping file.
+const (
+	EcosystemGo    = "go"
+	EcosystemNPM   = "npm"
+	EcosystemCargo = "cargo"
+	EcosystemPyPI  = "pypi"
+	EcosystemMaven = "maven"
+)
+
+// Dependency is a direct dependency declared in a manifest.
+type Dependency struct {
+	Name      string `json:"name"`           // Module, package or crate name; groupId:artifactId for Maven
+	Ecosystem string `json:"ecosystem"`      // One of the Ecosystem constants
+	Manifest  string `json:"manifest"`       // Path of the manifest declaring it
+	Repo      string `json:"repo,omitempty"` // GitHub repository (owner/repo), empty if unresolved
+
+	// source is the version spec or VCS URL the manifest gives, which may
+	// point at the repository
+	source string
+}
+
+// DependencySummary reports how many of a project's direct dependencies
+// received contributions. It is set by Stats.MarkDependencies.
+type DependencySummary struct {
+	Total       int `json:"total"`       // Direct dependencies in the manifests
+	Resolved    int `json:"resolved"`    // Dependencies resolved to a GitHub repository
+	Contributed int `json:"contributed"` // Dependencies whose repository received contributions
+}
+
+// DependencyConfig configures ResolveDependencies.
+type DependencyConfig struct {
+	// Manifests are the paths of the manifests to read: go.mod,
+	// package.json, Cargo.toml, requirements.txt or pom.xml.
+	Manifests []string
+
+	// MappingFile is an optional JSON file mapping dependencies to GitHub
+	// repositories, by ecosystem, e.g.
+	// {"npm": {"react": "facebook/react"}, "go": {"cloud.google.com/go": "googleapis/google-cloud-go"}}.
+	// Go module paths also match the modules below them. Mappings take
+	// precedence over every other way of resolving a dependency.
+	MappingFile string
+
+	// Offline resolves dependencies with the mapping file and naming
+	// conventions only, without querying the npm, crates.io and PyPI
+	// registries.
+	Offline bool
+
+	// HTTPClient is used for registry requests. Default: http.DefaultClient
+	HTTPClient *http.Client
+
+	// Logger receives registry lookup failures. Default: no logging
+	Logger Logger
+
+	// registries overrides the registry base URLs by ecosystem, in tests
+	registries map[string]string
+}
+
+// defaultRegistries are the base URLs of the registries queried for the
+// source repository of a package.
+var defaultRegistries = map[string]string{
+	EcosystemNPM:   "https://registry.npmjs.org",
+	EcosystemCargo: "https://crates.io",
+	EcosystemPyPI:  "https://pypi.org",
+}
+
+// ResolveDependencies reads the direct dependencies of the manifests in cfg
+// and resolves each to its GitHub repository. Dependencies declared in
+// several manifests are listed once.
+//
+// A dependency is resolved, in order, from the mapping file, from naming
+// conventions (github.com/owner/repo Go modules, well-known Go vanity
+// domains, com.github.owner Maven groups, and GitHub URLs or shorthands in
+// version specs), and unless cfg.Offline is set, from the package registry.
+// Dependencies that cannot be resolved are returned with an empty Repo.
+//
+// An error is returned if a manifest or the mapping file cannot be read.
+// Registry failures only leave the dependency unresolved.
+func ResolveDependencies(ctx context.Context, cfg DependencyConfig) ([]Dependency, error) {
+	mapping, err := loadDependencyMapping(cfg.MappingFile)
+	if err != nil {
+		return nil, err
+	}
+
+	var deps []Dependency
+	seen := make(map[string]bool)
+	for _, path := range cfg.Manifests {
+		parsed, err := ParseManifest(path)
+		if err != nil {
+			return nil, err
+		}
+		for _, dep := range parsed {
+			key := dep.Ecosystem + ":" + strings.ToLower(dep.Name)
+			if seen[key] {
+				continue
+			}
+			seen[key] = true
+			deps = append(deps, dep)
+		}
+	}
+
+	var unresolved []int
+	for i := range deps {
+		deps[i].Repo = mapping.lookup(deps[i])
+		if deps[i].Repo == "" {
+			deps[i].Repo = conventionRepo(deps[i])
+		}
+		if deps[i].Repo == "" {
+			unresolved = append(unresolved, i)
+		}
+	}
+
+	if !cfg.Offline {
+		cfg.resolveFromRegistries(ctx, deps, unresolved)
+	}
+	return deps, nil
+}
+
+// MarkDependencies flags the contributions to the GitHub repositories of
+// deps as dependency contributions and sets the Dependencies summary.
+// Flags from an earlier call are cleared first.
+func (s *Stats) MarkDependencies(deps []Dependency) {
+	repos := make(map[string]bool)
+	for _, dep := range deps {
+		if dep.Repo != "" {
+			repos[strings.ToLower(dep.Repo)] = true
+		}
+	}
+
+	contributed := make(map[string]bool)
+	for i := range s.Contributions {
+		contrib := &s.Contributions[i]
+		onGitHub := contrib.Host == "" || strings.EqualFold(contrib.Host, "github.com")
+		contrib.Dependency = onGitHub && repos[strings.ToLower(contrib.Repo)]
+		if contrib.Dependency {
+			contributed[strings.ToLower(contrib.Repo)] = true
+		}
+	}
+
+	summary := &DependencySummary{Total: len(deps)}
+	for _, dep := range deps {
+		if dep.Repo == "" {
+			continue
+		}
+		summary.Resolved++
+		if contributed[strings.ToLower(dep.Repo)] {
+			summary.Contributed++
+		}
+	}
+	s.Dependencies = summary
+}
+
+// ParseManifest returns the direct dependencies declared in a manifest. The
+// format is detected from the file name: go.mod, package.json, Cargo.toml,
+// requirements.txt (or any *requirements*.txt) or pom.xml. Indirect Go
+// requirements are skipped.
+func ParseManifest(path string) ([]Dependency, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("reading manifest: %w", err)
+	}
+
+	var deps []Dependency
+	name := strings.ToLower(filepath.Base(path))
+	switch {
+	case name == "go.mod":
+		deps = parseGoMod(data)
+	case name == "package.json":
+		deps, err = parsePackageJSON(data)
+	case name == "cargo.toml":
+		deps = parseCargoToml(data)
+	case strings.Contains(name, "requirements") && strings.HasSuffix(name, ".txt"):
+		deps = parseRequirements(data)
+	case name == "pom.xml":
+		deps, err = parsePomXML(data)
+	default:
+		return nil, fmt.Errorf("unsupported manifest %s (supported: go.mod, package.json, Cargo.toml, requirements.txt, pom.xml)", path)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("parsing manifest %s: %w", path, err)
+	}
+
+	for i := range deps {
+		deps[i].Manifest = path
+	}
+	return deps, nil
+}
+
+// parseGoMod returns the direct requirements of a go.mod file.
+func parseGoMod(data []byte) []Dependency {
+	var deps []Dependency
+	inBlock := false
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		indirect := strings.Contains(line, "// indirect")
+		if i := strings.Index(line, "//"); i >= 0 {
+			line = strings.TrimSpace(line[:i])
+		}
+
+		switch {
+		case line == "require (":
+			inBlock = true
+			continue
+		case inBlock && line == ")":
+			inBlock = false
+			continue
+		case strings.HasPrefix(line, "require "):
+			line = strings.TrimSpace(strings.TrimPrefix(line, "require"))
+		case !inBlock:
+			continue
+		}
+
+		fields := strings.Fields(line)
+		if len(fields) < 2 || indirect {
+			continue
+		}
+		deps = append(deps, Dependency{Name: strings.Trim(fields[0], `"`), Ecosystem: EcosystemGo})
+	}
+	return deps
+}
+
+// parsePackageJSON returns the dependencies, devDependencies,
+// optionalDependencies and peerDependencies of a package.json file.
+func parsePackageJSON(data []byte) ([]Dependency, error) {
+	var manifest struct {
+		Dependencies         map[string]string `json:"dependencies"`
+		DevDependencies      map[string]string `json:"devDependencies"`
+		OptionalDependencies map[string]string `json:"optionalDependencies"`
+		PeerDependencies     map[string]string `json:"peerDependencies"`
+	}
+	if err := json.Unmarshal(data, &manifest); err != nil {
+		return nil, err
+	}
+
+	var deps []Dependency
+	for _, group := range []map[string]string{manifest.Dependencies, manifest.DevDependencies, manifest.OptionalDependencies, manifest.PeerDependencies} {
+		for name, spec := range group {
+			deps = append(deps, Dependency{Name: name, Ecosystem: EcosystemNPM, source: spec})
+		}
+	}
+	slices.SortFunc(deps, func(a, b Dependency) int { return cmp.Compare(a.Name, b.Name) })
+	return deps, nil
+}
+
+var (
+	cargoGitPattern     = regexp.MustCompile(`\bgit\s*=\s*"([^"]+)"`)
+	cargoPackagePattern = regexp.MustCompile(`\bpackage\s*=\s*"([^"]+)"`)
+)
+
+// parseCargoToml returns the crates in the [dependencies],
+// [dev-dependencies], [build-dependencies] and target-specific dependency
+// tables of a Cargo.toml file, including [dependencies.<name>] tables.
+// Only the subset of TOML used by Cargo manifests is understood.
+func parseCargoToml(data []byte) []Dependency {
+	var deps []Dependency
+	index := make(map[string]int) // crate name -> index in deps, for [dependencies.<name>] tables
+	var table, crate string
+
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+
+		if strings.HasPrefix(line, "[") {
+			table = strings.Trim(line, "[] ")
+			crate = ""
+			// [dependencies.serde] declares one crate as a table
+			for _, suffix := range []string{"dependencies.", "dev-dependencies.", "build-dependencies."} {
+				if i := strings.LastIndex(table, suffix); i >= 0 && (i == 0 || table[i-1] == '.') {
+					crate = strings.Trim(table[i+len(suffix):], `"'`)
+					table = ""
+					if _, ok := index[crate]; !ok {
+						index[crate] = len(deps)
+						deps = append(deps, Dependency{Name: crate, Ecosystem: EcosystemCargo})
+					}
+				}
+			}
+			continue
+		}
+
+		key, value, ok := strings.Cut(line, "=")
+		if !ok {
+			continue
+		}
+		key = strings.Trim(strings.TrimSpace(key), `"'`)
+		if crate == "" {
+			// serde.workspace = true declares serde with a dotted key
+			key, _, _ = strings.Cut(key, ".")
+		}
+
+		if crate != "" {
+			dep := &deps[index[crate]]
+			switch key {
+			case "git":
+				dep.source = strings.Trim(strings.TrimSpace(value), `"'`)
+			case "package":
+				dep.Name = strings.Trim(strings.TrimSpace(value), `"'`)
+			}
+			continue
+		}
+		if !isCargoDependencyTable(table) {
+			continue
+		}
+
+		dep := Dependency{Name: key, Ecosystem: EcosystemCargo}
+		if match := cargoPackagePattern.FindStringSubmatch(value); match != nil {
+			dep.Name = match[1]
+		}
+		if match := cargoGitPattern.FindStringSubmatch(value); match != nil {
+			dep.source = match[1]
+		}
+		if _, ok := index[dep.Name]; !ok {
+			index[dep.Name] = len(deps)
+			deps = append(deps, dep)
+		}
+	}
+	return deps
+}
+
+// isCargoDependencyTable reports whether a Cargo.toml table lists
+// dependencies, e.g. "dependencies" or "target.'cfg(unix)'.dependencies".
+func isCargoDependencyTable(table string) bool {
+	for _, name := range []string{"dependencies", "dev-dependencies", "build-dependencies"} {
+		if table == name || strings.HasSuffix(table, "."+name) {
+			return true
+		}
+	}
+	return false
+}
+
+var (
+	requirementNamePattern = regexp.MustCompile(`^[A-Za-z0-9][A-Za-z0-9._-]*`)
+	requirementRefPattern  = regexp.MustCompile(`^[A-Za-z0-9][A-Za-z0-9._-]*\s*(\[[^\]]*\])?\s*@`)
+	requirementEggPattern  = regexp.MustCompile(`#egg=([A-Za-z0-9][A-Za-z0-9._-]*)`)
+	pypiSeparatorPattern   = regexp.MustCompile(`[-_.]+`)
+)
+
+// parseRequirements returns the packages of a pip requirements file,
+// including editable installs and direct references to VCS URLs. Options
+// such as -r and --index-url are skipped.
+func parseRequirements(data []byte) []Dependency {
+	var deps []Dependency
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if i := strings.Index(line, " #"); i >= 0 {
+			line = strings.TrimSpace(line[:i])
+		}
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+
+		if strings.HasPrefix(line, "-e ") || strings.HasPrefix(line, "--editable ") {
+			_, line, _ = strings.Cut(line, " ")
+			line = strings.TrimSpace(line)
+		} else if strings.HasPrefix(line, "-") {
+			continue
+		}
+
+		// git+https://github.com/owner/repo.git#egg=name
+		if strings.Contains(line, "://") && !requirementRefPattern.MatchString(line) {
+			if match := requirementEggPattern.FindStringSubmatch(line); match != nil {
+				deps = append(deps, Dependency{Name: normalizePyPIName(match[1]), Ecosystem: EcosystemPyPI, source: line})
+			}
+			continue
+		}
+
+		name := requirementNamePattern.FindString(line)
+		if name == "" {
+			continue
+		}
+		dep := Dependency{Name: normalizePyPIName(name), Ecosystem: EcosystemPyPI}
+		// name @ git+https://github.com/owner/repo.git
+		if requirementRefPattern.MatchString(line) {
+			_, dep.source, _ = strings.Cut(line, "@")
+			dep.source = strings.TrimSpace(dep.source)
+		}
+		deps = append(deps, dep)
+	}
+	return deps
+}
+
+// normalizePyPIName normalizes a Python package name as PyPI does.
+func normalizePyPIName(name string) string {
+	return pypiSeparatorPattern.ReplaceAllString(strings.ToLower(name), "-")
+}
+
+// parsePomXML returns the dependencies of a Maven pom.xml, leaving out
+// those managed in dependencyManagement and those with a property as
+// groupId, which usually refer to modules of the same project.
+func parsePomXML(data []byte) ([]Dependency, error) {
+	var pom struct {
+		Dependencies []struct {
+			GroupID    string `xml:"groupId"`
+			ArtifactID string `xml:"artifactId"`
+		} `xml:"dependencies>dependency"`
+	}
+	if err := xml.Unmarshal(data, &pom); err != nil {
+		return nil, err
+	}
+
+	var deps []Dependency
+	for _, dep := range pom.Dependencies {
+		group, artifact := strings.TrimSpace(dep.GroupID), strings.TrimSpace(dep.ArtifactID)
+		if group == "" || artifact == "" || strings.Contains(group, "${") {
+			continue
+		}
+		deps = append(deps, Dependency{Name: group + ":" + artifact, Ecosystem: EcosystemMaven})
+	}
+	return deps, nil
+}
+
+// dependencyMapping maps dependency names to GitHub repositories by ecosystem.
+type dependencyMapping map[string]map[string]string
+
+// loadDependencyMapping reads a dependency mapping file. An empty path
+// returns an empty mapping.
+func loadDependencyMapping(path string) (dependencyMapping, error) {
+	if path == "" {
+		return nil, nil
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("reading dependency mapping: %w", err)
+	}
+	var mapping dependencyMapping
+	if err := json.Unmarshal(data, &mapping); err != nil {
+		return nil, fmt.Errorf("parsing dependency mapping %s: %w", path, err)
+	}
+
+	for ecosystem, repos := range mapping {
+		for name, repo := range repos {
+			resolved := githubRepoFromURL(repo)
+			if resolved == "" && isRepoShorthand(repo) {
+				resolved = repo
+			}
+			if resolved == "" {
+				return nil, fmt.Errorf("dependency mapping %s: %s %q maps to %q, expected owner/repo or a GitHub URL", path, ecosystem, name, repo)
+			}
+			repos[name] = resolved
+		}
+	}
+	return mapping, nil
+}
+
+// lookup returns the mapped repository of dep, or "" if it is not mapped.
+// Go modules also match the mapping of their closest parent module path.
+func (m dependencyMapping) lookup(dep Dependency) string {
+	repos := m[dep.Ecosystem]
+	if repo, ok := repos[dep.Name]; ok {
+		return repo
+	}
+
+	var parent, parentRepo string
+	for name, repo := range repos {
+		switch {
+		case dep.Ecosystem == EcosystemGo:
+			if strings.HasPrefix(dep.Name, name+"/") && len(name) > len(parent) {
+				parent, parentRepo = name, repo
+			}
+		case dep.Ecosystem == EcosystemPyPI && normalizePyPIName(name) == dep.Name:
+			return repo
+		case strings.EqualFold(name, dep.Name):
+			return repo
+		}
+	}
+	return parentRepo
+}
+
+// goVanityOwners maps well-known Go vanity import domains to the GitHub
+// organization hosting their modules, each in a repository named after the
+// first path element.
+var goVanityOwners = map[string]string{
+	"golang.org/x": "golang",
+	"k8s.io":       "kubernetes",
+	"sigs.k8s.io":  "kubernetes-sigs",
+	"go.uber.org":  "uber-go",
+	"go.etcd.io":   "etcd-io",
+}
+
+// goVanityRepos maps Go modules whose repository cannot be derived from the
+// module path to their repository.
+var goVanityRepos = map[string]string{
+	"google.golang.org/grpc":      "grpc/grpc-go",
+	"google.golang.org/protobuf":  "protocolbuffers/protobuf-go",
+	"google.golang.org/api":       "googleapis/google-api-go-client",
+	"google.golang.org/genproto":  "googleapis/go-genproto",
+	"cloud.google.com/go":         "googleapis/google-cloud-go",
+	"go.opentelemetry.io/otel":    "open-telemetry/opentelemetry-go",
+	"go.opentelemetry.io/contrib": "open-telemetry/opentelemetry-go-contrib",
+	"honnef.co/go/tools":          "dominikh/go-tools",
+}
+
+var (
+	githubURLPattern = regexp.MustCompile(`github\.com[/:]([A-Za-z0-9_.-]+)/([A-Za-z0-9_.-]+)`)
+	repoShorthand    = regexp.MustCompile(`^[A-Za-z0-9_.-]+/[A-Za-z0-9_.-]+$`)
+)
+
+// conventionRepo resolves a dependency to its GitHub repository from its
+// name or manifest source alone. It returns "" if no convention applies.
+func conventionRepo(dep Dependency) string {
+	if repo := githubRepoFromURL(dep.source); repo != "" {
+		return repo
+	}
+
+	switch dep.Ecosystem {
+	case EcosystemGo:
+		return goModuleRepo(dep.Name)
+	case EcosystemNPM:
+		// npm accepts "owner/repo" and "github:owner/repo" as version specs
+		spec, _, _ := strings.Cut(strings.TrimPrefix(dep.source, "github:"), "#")
+		if isRepoShorthand(spec) {
+			return spec
+		}
+	case EcosystemMaven:
+		group, artifact, _ := strings.Cut(dep.Name, ":")
+		for _, prefix := range []string{"com.github.", "io.github."} {
+			if owner, ok := strings.CutPrefix(group, prefix); ok && owner != "" && !strings.Contains(owner, ".") {
+				return owner + "/" + artifact
+			}
+		}
+	}
+	return ""
+}
+
+// goModuleRepo resolves a Go module path to its GitHub repository.
+func goModuleRepo(module string) string {
+	parts := strings.Split(module, "/")
+	if parts[0] == "github.com" && len(parts) >= 3 {
+		return parts[1] + "/" + parts[2]
+	}
+
+	for prefix := module; ; {
+		if repo, ok := goVanityRepos[prefix]; ok {
+			return repo
+		}
+		i := strings.LastIndex(prefix, "/")
+		if i < 0 {
+			break
+		}
+		prefix = prefix[:i]
+	}
+	for prefix, owner := range goVanityOwners {
+		if rest, ok := strings.CutPrefix(module, prefix+"/"); ok {
+			name, _, _ := strings.Cut(rest, "/")
+			return owner + "/" + name
+		}
+	}
+
+	// gopkg.in/owner/repo.v1 and gopkg.in/repo.v1 (github.com/go-repo/repo)
+	if parts[0] == "gopkg.in" && len(parts) >= 2 {
+		if len(parts) >= 3 {
+			name, _, _ := strings.Cut(parts[2], ".v")
+			return parts[1] + "/" + name
+		}
+		name, _, _ := strings.Cut(parts[1], ".v")
+		return "go-" + name + "/" + name
+	}
+	return ""
+}
+
+// githubRepoFromURL returns the owner/repo of a GitHub URL in any of the
+// forms found in manifests and registries, e.g. "git+https://github.com/o/r.git"
+// or "git@github.com:o/r". It returns "" for anything else.
+func githubRepoFromURL(s string) string {
+	match := githubURLPattern.FindStringSubmatch(s)
+	if match == nil {
+		return ""
+	}
+	return match[1] + "/" + strings.TrimSuffix(match[2], ".git")
+}
+
+// isRepoShorthand reports whether s has the owner/repo form.
+func isRepoShorthand(s string) bool {
+	return repoShorthand.MatchString(s) && !strings.HasPrefix(s, ".")
+}
+
+// resolveFromRegistries looks up the source repository of the dependencies
+// at the given indexes in their package registry.
+func (cfg DependencyConfig) resolveFromRegistries(ctx context.Context, deps []Dependency, indexes []int) {
+	httpClient := cfg.HTTPClient
+	if httpClient == nil {
+		httpClient = http.DefaultClient
+	}
+	logger := cfg.Logger
+	if logger == nil {
+		logger = defaultLogger{}
+	}
+
+	var pending []int
+	for _, i := range indexes {
+		if cfg.registryURL(deps[i].Ecosystem) != "" {
+			pending = append(pending, i)
+		}
+	}
+
+	forEachLimited(ctx, maxConcurrentRequests, pending, func(i int) bool {
+		dep := &deps[i]
+		repo, err := registryRepo(ctx, httpClient, cfg.registryURL(dep.Ecosystem), *dep)
+		if err != nil {
+			logger.Printf("Failed to resolve %s dependency %s: %v", dep.Ecosystem, dep.Name, err)
+			return false
+		}
+		dep.Repo = repo
+		return false
+	})
+}
+
+// registryURL returns the registry base URL of an ecosystem, or "" if
+// packages of the ecosystem are not looked up.
+func (cfg DependencyConfig) registryURL(ecosystem string) string {
+	if baseURL, ok := cfg.registries[ecosystem]; ok {
+		return baseURL
+	}
+	return defaultRegistries[ecosystem]
+}
+
+// registryRepo fetches the metadata of a package from its registry and
+// returns the GitHub repository it links to, or "" if it links to none.
+func registryRepo(ctx context.Context, httpClient *http.Client, baseURL string, dep Dependency) (string, error) {
+	var endpoint string
+	switch dep.Ecosystem {
+	case EcosystemNPM:
+		endpoint = baseURL + "/" + url.PathEscape(dep.Name) // @scope/name becomes @scope%2Fname
+	case EcosystemCargo:
+		endpoint = baseURL + "/api/v1/crates/" + url.PathEscape(dep.Name)
+	case EcosystemPyPI:
+		endpoint = baseURL + "/pypi/" + url.PathEscape(dep.Name) + "/json"
+	default:
+		return "", nil
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, endpoint, nil)
+	if err != nil {
+		return "", err
+	}
+	req.Header.Set("Accept", "application/json")
+	req.Header.Set("User-Agent", "gh-oss-stats") // Required by crates.io
+
+	resp, err := httpClient.Do(req)
+	if err != nil {
+		return "", err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode == http.StatusNotFound {
+		return "", nil
+	}
+	if resp.StatusCode != http.StatusOK {
+		return "", fmt.Errorf("%s returned HTTP %d", endpoint, resp.StatusCode)
+	}
+
+	body, err := io.ReadAll(io.LimitReader(resp.Body, 10<<20))
+	if err != nil {
+		return "", err
+	}
+
+	var candidates []string
+	switch dep.Ecosystem {
+	case EcosystemNPM:
+		var pkg struct {
+			Repository json.RawMessage `json:"repository"`
+			Homepage   string          `json:"homepage"`
+		}
+		if err := json.Unmarshal(body, &pkg); err != nil {
+			return "", err
+		}
+		// "repository" is either a URL or {"type": "git", "url": "..."}
+		var repository struct {
+			URL string `json:"url"`
+		}
+		if json.Unmarshal(pkg.Repository, &repository) != nil {
+			json.Unmarshal(pkg.Repository, &repository.URL)
+		}
+		candidates = []string{repository.URL, pkg.Homepage}
+	case EcosystemCargo:
+		var crate struct {
+			Crate struct {
+				Repository string `json:"repository"`
+				Homepage   string `json:"homepage"`
+			} `json:"crate"`
+		}
+		if err := json.Unmarshal(body, &crate); err != nil {
+			return "", err
+		}
+		candidates = []string{crate.Crate.Repository, crate.Crate.Homepage}
+	case EcosystemPyPI:
+		var project struct {
+			Info struct {
+				ProjectURLs map[string]string `json:"project_urls"`
+				HomePage    string            `json:"home_page"`
+			} `json:"info"`
+		}
+		if err := json.Unmarshal(body, &project); err != nil {
+			return "", err
+		}
+		for _, label := range []string{"Source", "Source Code", "Repository", "Code", "GitHub", "Homepage"} {
+			candidates = append(candidates, project.Info.ProjectURLs[label])
+		}
+		candidates = append(candidates, project.Info.HomePage)
+		for _, label := range slices.Sorted(maps.Keys(project.Info.ProjectURLs)) {
+			candidates = append(candidates, project.Info.ProjectURLs[label])
+		}
+	}
+
+	for _, candidate := range candidates {
+		if repo := githubRepoFromURL(candidate); repo != "" {
+			return repo, nil
+		}
+	}
+	return "", nil
+}
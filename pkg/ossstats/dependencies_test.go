@@ -0,0 +1,365 @@
+package ossstats
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+)
+
+// writeManifest writes a manifest named name into a new temporary directory.
+func writeManifest(t *testing.T, name, content string) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), name)
+	if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+// dependencyNames returns "name=repo" for each dependency, resolved or not.
+func dependencyNames(deps []Dependency) []string {
+	names := make([]string, len(deps))
+	for i, dep := range deps {
+		names[i] = dep.Name + "=" + dep.Repo
+	}
+	return names
+}
+
+func TestParseManifest(t *testing.T) {
+	tests := []struct {
+		name     string
+		file     string
+		content  string
+		want     []string // name=conventional repo
+		wantEcos string
+	}{
+		{
+			name: "go.mod",
+			file: "go.mod",
+			content: `module example.com/app
+
+go 1.22
+
+require github.com/google/uuid v1.6.0
+
+require (
+	golang.org/x/net v0.30.0
+	github.com/spf13/cobra/v2 v2.0.0
+	gopkg.in/yaml.v3 v3.0.1
+	google.golang.org/grpc/examples v0.1.0
+	github.com/inconshreveable/mousetrap v1.1.0 // indirect
+)
+
+replace (
+	example.com/other => ../other
+)
+`,
+			want: []string{
+				"github.com/google/uuid=google/uuid",
+				"golang.org/x/net=golang/net",
+				"github.com/spf13/cobra/v2=spf13/cobra",
+				"gopkg.in/yaml.v3=go-yaml/yaml",
+				"google.golang.org/grpc/examples=grpc/grpc-go",
+			},
+			wantEcos: EcosystemGo,
+		},
+		{
+			name: "package.json",
+			file: "package.json",
+			content: `{
+  "name": "app",
+  "dependencies": {"react": "^18.0.0", "left-pad": "github:stevemao/left-pad#v1"},
+  "devDependencies": {"@types/node": "^20.0.0", "fork": "someone/fork"},
+  "peerDependencies": {"lib": "git+https://github.com/acme/lib.git"}
+}`,
+			want: []string{
+				"@types/node=",
+				"fork=someone/fork",
+				"left-pad=stevemao/left-pad",
+				"lib=acme/lib",
+				"react=",
+			},
+			wantEcos: EcosystemNPM,
+		},
+		{
+			name: "Cargo.toml",
+			file: "Cargo.toml",
+			content: `[package]
+name = "app"
+version = "0.1.0"
+
+[dependencies]
+serde = { version = "1", features = ["derive"] }
+tokio = "1"
+rand_core = { package = "rand-core-fork", git = "https://github.com/acme/rand.git" }
+anyhow.workspace = true
+
+[dev-dependencies]
+criterion = "0.5"
+
+[target.'cfg(unix)'.dependencies]
+libc = "0.2"
+
+[dependencies.regex]
+version = "1"
+git = "https://github.com/rust-lang/regex"
+
+[features]
+default = []
+`,
+			want: []string{
+				"serde=",
+				"tokio=",
+				"rand-core-fork=acme/rand",
+				"anyhow=",
+				"criterion=",
+				"libc=",
+				"regex=rust-lang/regex",
+			},
+			wantEcos: EcosystemCargo,
+		},
+		{
+			name: "requirements.txt",
+			file: "requirements-dev.txt",
+			content: `# Tooling
+-r requirements.txt
+--index-url https://pypi.org/simple
+Django>=4.2  # web
+requests[security]==2.31.0
+ruamel.yaml
+-e git+https://github.com/acme/tool.git#egg=acme_tool
+mylib @ git+https://github.com/acme/mylib.git@main
+git+https://github.com/acme/other.git#egg=Other.Lib
+`,
+			want: []string{
+				"django=",
+				"requests=",
+				"ruamel-yaml=",
+				"acme-tool=acme/tool",
+				"mylib=acme/mylib",
+				"other-lib=acme/other",
+			},
+			wantEcos: EcosystemPyPI,
+		},
+		{
+			name: "pom.xml",
+			file: "pom.xml",
+			content: `<project>
+  <groupId>com.example</groupId>
+  <artifactId>app</artifactId>
+  <dependencies>
+    <dependency><groupId>org.slf4j</groupId><artifactId>slf4j-api</artifactId></dependency>
+    <dependency><groupId>com.github.ben-manes.caffeine</groupId><artifactId>caffeine</artifactId></dependency>
+    <dependency><groupId>io.github.acme</groupId><artifactId>widget</artifactId></dependency>
+    <dependency><groupId>${project.groupId}</groupId><artifactId>core</artifactId></dependency>
+  </dependencies>
+  <dependencyManagement>
+    <dependencies>
+      <dependency><groupId>com.google.guava</groupId><artifactId>guava</artifactId></dependency>
+    </dependencies>
+  </dependencyManagement>
+</project>`,
+			want: []string{
+				"org.slf4j:slf4j-api=",
+				"com.github.ben-manes.caffeine:caffeine=",
+				"io.github.acme:widget=acme/widget",
+			},
+			wantEcos: EcosystemMaven,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			path := writeManifest(t, tt.file, tt.content)
+
+			deps, err := ParseManifest(path)
+			if err != nil {
+				t.Fatalf("ParseManifest() error = %v", err)
+			}
+			for i := range deps {
+				deps[i].Repo = conventionRepo(deps[i])
+				if deps[i].Ecosystem != tt.wantEcos || deps[i].Manifest != path {
+					t.Errorf("Dependency %s ecosystem/manifest = %s/%s", deps[i].Name, deps[i].Ecosystem, deps[i].Manifest)
+				}
+			}
+			if got := dependencyNames(deps); !slices.Equal(got, tt.want) {
+				t.Errorf("ParseManifest() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseManifestErrors(t *testing.T) {
+	if _, err := ParseManifest(writeManifest(t, "build.gradle", "")); err == nil || !strings.Contains(err.Error(), "unsupported manifest") {
+		t.Errorf("ParseManifest(build.gradle) error = %v, want unsupported manifest", err)
+	}
+	if _, err := ParseManifest(writeManifest(t, "package.json", "{")); err == nil {
+		t.Error("ParseManifest() of invalid JSON error = nil")
+	}
+	if _, err := ParseManifest(filepath.Join(t.TempDir(), "go.mod")); err == nil {
+		t.Error("ParseManifest() of a missing file error = nil")
+	}
+}
+
+func TestGithubRepoFromURL(t *testing.T) {
+	tests := map[string]string{
+		"https://github.com/golang/go":              "golang/go",
+		"git+https://github.com/facebook/react.git": "facebook/react",
+		"git@github.com:rust-lang/regex.git":        "rust-lang/regex",
+		"git://github.com/owner/repo#readme":        "owner/repo",
+		"https://gitlab.com/owner/repo":             "",
+		"":                                          "",
+	}
+	for input, want := range tests {
+		if got := githubRepoFromURL(input); got != want {
+			t.Errorf("githubRepoFromURL(%q) = %q, want %q", input, got, want)
+		}
+	}
+}
+
+func TestResolveDependenciesOffline(t *testing.T) {
+	goMod := writeManifest(t, "go.mod", "module app\n\nrequire (\n\tcloud.google.com/go/storage v1.0.0\n\tgo.example.com/lib v1.0.0\n\tgithub.com/google/uuid v1.6.0\n)\n")
+	packageJSON := writeManifest(t, "package.json", `{"dependencies": {"react": "^18", "unknown": "^1"}}`)
+	otherPackageJSON := writeManifest(t, "package.json", `{"dependencies": {"react": "^18"}}`)
+	mapping := writeManifest(t, "mapping.json", `{
+		"go": {"go.example.com": "example/lib", "github.com/google/uuid": "https://github.com/fork/uuid"},
+		"npm": {"React": "facebook/react"}
+	}`)
+
+	registryCalled := false
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		registryCalled = true
+	}))
+	defer server.Close()
+
+	deps, err := ResolveDependencies(context.Background(), DependencyConfig{
+		Manifests:   []string{goMod, packageJSON, otherPackageJSON},
+		MappingFile: mapping,
+		Offline:     true,
+		registries:  map[string]string{EcosystemNPM: server.URL},
+	})
+	if err != nil {
+		t.Fatalf("ResolveDependencies() error = %v", err)
+	}
+	if registryCalled {
+		t.Error("Registry queried in offline mode")
+	}
+
+	want := []string{
+		"cloud.google.com/go/storage=googleapis/google-cloud-go",
+		"go.example.com/lib=example/lib",
+		"github.com/google/uuid=fork/uuid", // The mapping wins over conventions
+		"react=facebook/react",
+		"unknown=",
+	}
+	if got := dependencyNames(deps); !slices.Equal(got, want) {
+		t.Errorf("ResolveDependencies() = %v, want %v", got, want)
+	}
+}
+
+func TestResolveDependenciesInvalidMapping(t *testing.T) {
+	mapping := writeManifest(t, "mapping.json", `{"npm": {"react": "not a repo"}}`)
+	_, err := ResolveDependencies(context.Background(), DependencyConfig{MappingFile: mapping, Offline: true})
+	if err == nil || !strings.Contains(err.Error(), `"react"`) {
+		t.Errorf("ResolveDependencies() error = %v, want the invalid mapping", err)
+	}
+}
+
+func TestResolveDependenciesFromRegistries(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch r.URL.EscapedPath() {
+		case "/npm/react":
+			w.Write([]byte(`{"repository": {"type": "git", "url": "git+https://github.com/facebook/react.git"}}`))
+		case "/npm/@babel%2Fcore":
+			w.Write([]byte(`{"repository": "https://github.com/babel/babel"}`))
+		case "/cargo/api/v1/crates/serde":
+			if r.Header.Get("User-Agent") == "" {
+				w.WriteHeader(http.StatusForbidden)
+				return
+			}
+			w.Write([]byte(`{"crate": {"repository": "https://github.com/serde-rs/serde"}}`))
+		case "/pypi/pypi/django/json":
+			w.Write([]byte(`{"info": {"home_page": "https://www.djangoproject.com/", "project_urls": {"Source": "https://github.com/django/django"}}}`))
+		case "/npm/broken":
+			w.WriteHeader(http.StatusInternalServerError)
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer server.Close()
+
+	packageJSON := writeManifest(t, "package.json", `{"dependencies": {"react": "^18", "@babel/core": "^7", "broken": "^1", "missing": "^1"}}`)
+	cargo := writeManifest(t, "Cargo.toml", "[dependencies]\nserde = \"1\"\n")
+	requirements := writeManifest(t, "requirements.txt", "Django>=4\n")
+
+	logger := &mockLogger{}
+	deps, err := ResolveDependencies(context.Background(), DependencyConfig{
+		Manifests:  []string{packageJSON, cargo, requirements},
+		HTTPClient: server.Client(),
+		Logger:     logger,
+		registries: map[string]string{
+			EcosystemNPM:   server.URL + "/npm",
+			EcosystemCargo: server.URL + "/cargo",
+			EcosystemPyPI:  server.URL + "/pypi",
+		},
+	})
+	if err != nil {
+		t.Fatalf("ResolveDependencies() error = %v", err)
+	}
+
+	want := []string{
+		"@babel/core=babel/babel",
+		"broken=",
+		"missing=",
+		"react=facebook/react",
+		"serde=serde-rs/serde",
+		"django=django/django",
+	}
+	if got := dependencyNames(deps); !slices.Equal(got, want) {
+		t.Errorf("ResolveDependencies() = %v, want %v", got, want)
+	}
+	if len(logger.messages) != 1 {
+		t.Errorf("Logged %v, want the failed lookup of broken only", logger.messages)
+	}
+}
+
+func TestMarkDependencies(t *testing.T) {
+	stats := &Stats{
+		Contributions: []Contribution{
+			{Repo: "golang/go", Host: "github.com"},
+			{Repo: "Facebook/React"}, // Written before hosts were recorded
+			{Repo: "gitlab-org/gitlab", Host: "gitlab.com"},
+			{Repo: "acme/old", Dependency: true},
+		},
+	}
+	deps := []Dependency{
+		{Name: "golang.org/x/net", Repo: "golang/net"},
+		{Name: "react", Repo: "facebook/react"},
+		{Name: "react-dom", Repo: "facebook/react"},
+		{Name: "gitlab", Repo: "gitlab-org/gitlab"},
+		{Name: "unresolved"},
+	}
+
+	stats.MarkDependencies(deps)
+
+	var flagged []string
+	for _, contrib := range stats.Contributions {
+		if contrib.Dependency {
+			flagged = append(flagged, contrib.Repo)
+		}
+	}
+	if !slices.Equal(flagged, []string{"Facebook/React"}) {
+		t.Errorf("Flagged contributions = %v, want Facebook/React only", flagged)
+	}
+
+	want := DependencySummary{Total: 5, Resolved: 4, Contributed: 2}
+	if stats.Dependencies == nil || *stats.Dependencies != want {
+		t.Errorf("Dependencies = %+v, want %+v", stats.Dependencies, want)
+	}
+}
@@ -0,0 +1,267 @@
+package ossstats
+
+import (
+	"cmp"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+)
+
+// StatsDiff describes what changed between two Stats snapshots of a user.
+type StatsDiff struct {
+	Username string    `json:"username"`
+	From     time.Time `json:"from"` // GeneratedAt of the older snapshot
+	To       time.Time `json:"to"`   // GeneratedAt of the newer snapshot
+
+	Summary SummaryDelta `json:"summary"`
+
+	NewRepos     []Contribution `json:"newRepos"`     // Repos contributed to for the first time
+	UpdatedRepos []RepoChange   `json:"updatedRepos"` // Repos with new merged PRs
+	StarChanges  []StarChange   `json:"starChanges"`  // Star count changes of repos in both snapshots
+	RemovedRepos []Contribution `json:"removedRepos"` // Repos only in the older snapshot, e.g. after changing filters
+}
+
+// SummaryDelta is the difference between two summaries (newer - older).
+type SummaryDelta struct {
+	Projects  int `json:"projects"`
+	PRsMerged int `json:"prsMerged"`
+	Commits   int `json:"commits"`
+	Additions int `json:"additions"`
+	Deletions int `json:"deletions"`
+}
+
+// RepoChange describes new merged PRs to a repository present in both snapshots.
+type RepoChange struct {
+	Repo      string `json:"repo"`
+	RepoURL   string `json:"repoURL"`
+	PRsBefore int    `json:"prsBefore"`
+	PRsAfter  int    `json:"prsAfter"`
+	NewPRs    int    `json:"newPRs"`
+}
+
+// StarChange describes a star count change of a repository present in both snapshots.
+type StarChange struct {
+	Repo    string `json:"repo"`
+	RepoURL string `json:"repoURL"`
+	Before  int    `json:"before"`
+	After   int    `json:"after"`
+	Delta   int    `json:"delta"`
+}
+
+// Diff compares s with a newer snapshot and reports what changed.
+// Star changes are skipped for contributions marked Incomplete, whose star
+// count is unknown.
+func (s *Stats) Diff(newer *Stats) *StatsDiff {
+	diff := &StatsDiff{
+		Username: newer.Username,
+		From:     s.GeneratedAt,
+		To:       newer.GeneratedAt,
+		Summary: SummaryDelta{
+			Projects:  newer.Summary.TotalProjects - s.Summary.TotalProjects,
+			PRsMerged: newer.Summary.TotalPRsMerged - s.Summary.TotalPRsMerged,
+			Commits:   newer.Summary.TotalCommits - s.Summary.TotalCommits,
+			Additions: newer.Summary.TotalAdditions - s.Summary.TotalAdditions,
+			Deletions: newer.Summary.TotalDeletions - s.Summary.TotalDeletions,
+		},
+		NewRepos:     []Contribution{},
+		UpdatedRepos: []RepoChange{},
+		StarChanges:  []StarChange{},
+		RemovedRepos: []Contribution{},
+	}
+
+	older := make(map[string]Contribution, len(s.Contributions))
+	for _, contrib := range s.Contributions {
+		older[contributionKey(contrib)] = contrib
+	}
+
+	seen := make(map[string]bool, len(newer.Contributions))
+	for _, contrib := range newer.Contributions {
+		seen[contributionKey(contrib)] = true
+
+		before, ok := older[contributionKey(contrib)]
+		if !ok {
+			diff.NewRepos = append(diff.NewRepos, contrib)
+			continue
+		}
+
+		if contrib.PRsMerged > before.PRsMerged {
+			diff.UpdatedRepos = append(diff.UpdatedRepos, RepoChange{
+				Repo:      contrib.Repo,
+				RepoURL:   contrib.RepoURL,
+				PRsBefore: before.PRsMerged,
+				PRsAfter:  contrib.PRsMerged,
+				NewPRs:    contrib.PRsMerged - before.PRsMerged,
+			})
+		}
+
+		if !contrib.Incomplete && !before.Incomplete && contrib.Stars != before.Stars {
+			diff.StarChanges = append(diff.StarChanges, StarChange{
+				Repo:    contrib.Repo,
+				RepoURL: contrib.RepoURL,
+				Before:  before.Stars,
+				After:   contrib.Stars,
+				Delta:   contrib.Stars - before.Stars,
+			})
+		}
+	}
+
+	for _, contrib := range s.Contributions {
+		if !seen[contributionKey(contrib)] {
+			diff.RemovedRepos = append(diff.RemovedRepos, contrib)
+		}
+	}
+
+	byStars := func(a, b Contribution) int {
+		return cmp.Or(cmp.Compare(b.Stars, a.Stars), cmp.Compare(a.Repo, b.Repo))
+	}
+	slices.SortFunc(diff.NewRepos, byStars)
+	slices.SortFunc(diff.RemovedRepos, byStars)
+	slices.SortFunc(diff.UpdatedRepos, func(a, b RepoChange) int {
+		return cmp.Or(cmp.Compare(b.NewPRs, a.NewPRs), cmp.Compare(a.Repo, b.Repo))
+	})
+	slices.SortFunc(diff.StarChanges, func(a, b StarChange) int {
+		return cmp.Or(cmp.Compare(abs(b.Delta), abs(a.Delta)), cmp.Compare(a.Repo, b.Repo))
+	})
+
+	return diff
+}
+
+// IsEmpty reports whether the snapshots have no differences.
+func (d *StatsDiff) IsEmpty() bool {
+	return d.Summary == (SummaryDelta{}) &&
+		len(d.NewRepos) == 0 &&
+		len(d.UpdatedRepos) == 0 &&
+		len(d.StarChanges) == 0 &&
+		len(d.RemovedRepos) == 0
+}
+
+// Text renders the diff as human-readable plain text.
+func (d *StatsDiff) Text() string {
+	var b strings.Builder
+
+	fmt.Fprintf(&b, "Changes for %s from %s to %s\n", d.Username, formatDate(d.From), formatDate(d.To))
+	if d.IsEmpty() {
+		b.WriteString("\nNo changes.\n")
+		return b.String()
+	}
+
+	b.WriteString("\nSummary:\n")
+	fmt.Fprintf(&b, "  Projects:      %s\n", signed(d.Summary.Projects))
+	fmt.Fprintf(&b, "  PRs merged:    %s\n", signed(d.Summary.PRsMerged))
+	fmt.Fprintf(&b, "  Commits:       %s\n", signed(d.Summary.Commits))
+	fmt.Fprintf(&b, "  Lines added:   %s\n", signed(d.Summary.Additions))
+	fmt.Fprintf(&b, "  Lines deleted: %s\n", signed(d.Summary.Deletions))
+
+	if len(d.NewRepos) > 0 {
+		fmt.Fprintf(&b, "\nNew repositories (%d):\n", len(d.NewRepos))
+		for _, contrib := range d.NewRepos {
+			fmt.Fprintf(&b, "  %s (%d PRs, %d stars)\n", contrib.Repo, contrib.PRsMerged, contrib.Stars)
+		}
+	}
+
+	if len(d.UpdatedRepos) > 0 {
+		fmt.Fprintf(&b, "\nRepositories with new merged PRs (%d):\n", len(d.UpdatedRepos))
+		for _, change := range d.UpdatedRepos {
+			fmt.Fprintf(&b, "  %s: +%d PRs (%d -> %d)\n", change.Repo, change.NewPRs, change.PRsBefore, change.PRsAfter)
+		}
+	}
+
+	if len(d.StarChanges) > 0 {
+		fmt.Fprintf(&b, "\nStar changes (%d):\n", len(d.StarChanges))
+		for _, change := range d.StarChanges {
+			fmt.Fprintf(&b, "  %s: %s (%d -> %d)\n", change.Repo, signed(change.Delta), change.Before, change.After)
+		}
+	}
+
+	if len(d.RemovedRepos) > 0 {
+		fmt.Fprintf(&b, "\nNo longer listed (%d):\n", len(d.RemovedRepos))
+		for _, contrib := range d.RemovedRepos {
+			fmt.Fprintf(&b, "  %s\n", contrib.Repo)
+		}
+	}
+
+	return b.String()
+}
+
+// Markdown renders the diff as a Markdown section, e.g. for a newsletter.
+func (d *StatsDiff) Markdown() string {
+	var b strings.Builder
+
+	fmt.Fprintf(&b, "## Open source changes for %s\n\n", d.Username)
+	fmt.Fprintf(&b, "_%s → %s_\n\n", formatDate(d.From), formatDate(d.To))
+	if d.IsEmpty() {
+		b.WriteString("No changes.\n")
+		return b.String()
+	}
+
+	b.WriteString("| Metric | Change |\n")
+	b.WriteString("|--------|--------|\n")
+	fmt.Fprintf(&b, "| Projects | %s |\n", signed(d.Summary.Projects))
+	fmt.Fprintf(&b, "| PRs merged | %s |\n", signed(d.Summary.PRsMerged))
+	fmt.Fprintf(&b, "| Commits | %s |\n", signed(d.Summary.Commits))
+	fmt.Fprintf(&b, "| Lines added | %s |\n", signed(d.Summary.Additions))
+	fmt.Fprintf(&b, "| Lines deleted | %s |\n", signed(d.Summary.Deletions))
+
+	if len(d.NewRepos) > 0 {
+		b.WriteString("\n### New repositories\n\n")
+		for _, contrib := range d.NewRepos {
+			fmt.Fprintf(&b, "- %s — %d PRs, ⭐ %d\n", markdownRepoLink(contrib.Repo, contrib.RepoURL), contrib.PRsMerged, contrib.Stars)
+		}
+	}
+
+	if len(d.UpdatedRepos) > 0 {
+		b.WriteString("\n### New merged PRs\n\n")
+		for _, change := range d.UpdatedRepos {
+			fmt.Fprintf(&b, "- %s — +%d PRs (%d total)\n", markdownRepoLink(change.Repo, change.RepoURL), change.NewPRs, change.PRsAfter)
+		}
+	}
+
+	if len(d.StarChanges) > 0 {
+		b.WriteString("\n### Star changes\n\n")
+		for _, change := range d.StarChanges {
+			fmt.Fprintf(&b, "- %s — %s (⭐ %d)\n", markdownRepoLink(change.Repo, change.RepoURL), signed(change.Delta), change.After)
+		}
+	}
+
+	if len(d.RemovedRepos) > 0 {
+		b.WriteString("\n### No longer listed\n\n")
+		for _, contrib := range d.RemovedRepos {
+			fmt.Fprintf(&b, "- %s\n", markdownRepoLink(contrib.Repo, contrib.RepoURL))
+		}
+	}
+
+	return b.String()
+}
+
+// markdownRepoLink links a repository name to its URL when known.
+func markdownRepoLink(repo, url string) string {
+	if url == "" {
+		return repo
+	}
+	return fmt.Sprintf("[%s](%s)", repo, url)
+}
+
+// formatDate formats a snapshot time for diff output.
+func formatDate(t time.Time) string {
+	if t.IsZero() {
+		return "unknown"
+	}
+	return t.UTC().Format("2006-01-02")
+}
+
+// signed formats n with an explicit sign, e.g. "+3", "-2" or "0".
+func signed(n int) string {
+	if n > 0 {
+		return fmt.Sprintf("+%d", n)
+	}
+	return fmt.Sprintf("%d", n)
+}
+
+// abs returns the absolute value of n.
+func abs(n int) int {
+	if n < 0 {
+		return -n
+	}
+	return n
+}
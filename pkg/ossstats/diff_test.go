@@ -0,0 +1,154 @@
+package ossstats
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+	"time"
+)
+
+func diffFixtures() (*Stats, *Stats) {
+	week1 := time.Date(2025, 6, 1, 0, 0, 0, 0, time.UTC)
+	week2 := week1.AddDate(0, 0, 7)
+
+	older := &Stats{
+		Username:    "octocat",
+		GeneratedAt: week1,
+		Summary:     Summary{TotalProjects: 3, TotalPRsMerged: 6, TotalCommits: 10, TotalAdditions: 100, TotalDeletions: 20},
+		Contributions: []Contribution{
+			{Repo: "golang/go", PRsMerged: 3, Stars: 1000, RepoURL: "https://github.com/golang/go"},
+			{Repo: "acme/app", PRsMerged: 2, Stars: 50},
+			{Repo: "old/gone", PRsMerged: 1, Stars: 5},
+		},
+	}
+
+	newer := &Stats{
+		Username:    "octocat",
+		GeneratedAt: week2,
+		Summary:     Summary{TotalProjects: 4, TotalPRsMerged: 10, TotalCommits: 16, TotalAdditions: 180, TotalDeletions: 25},
+		Contributions: []Contribution{
+			{Repo: "golang/go", PRsMerged: 5, Stars: 1010, RepoURL: "https://github.com/golang/go"},
+			{Repo: "acme/app", PRsMerged: 2, Stars: 50},
+			{Repo: "new/small", PRsMerged: 1, Stars: 3},
+			{Repo: "new/big", PRsMerged: 2, Stars: 900},
+		},
+	}
+
+	return older, newer
+}
+
+func TestStatsDiff(t *testing.T) {
+	older, newer := diffFixtures()
+
+	diff := older.Diff(newer)
+
+	if diff.Username != "octocat" || !diff.From.Equal(older.GeneratedAt) || !diff.To.Equal(newer.GeneratedAt) {
+		t.Errorf("Header = %s %v %v", diff.Username, diff.From, diff.To)
+	}
+
+	wantSummary := SummaryDelta{Projects: 1, PRsMerged: 4, Commits: 6, Additions: 80, Deletions: 5}
+	if diff.Summary != wantSummary {
+		t.Errorf("Summary = %+v, want %+v", diff.Summary, wantSummary)
+	}
+
+	if len(diff.NewRepos) != 2 || diff.NewRepos[0].Repo != "new/big" || diff.NewRepos[1].Repo != "new/small" {
+		t.Errorf("NewRepos = %+v, want new/big then new/small", diff.NewRepos)
+	}
+
+	if len(diff.UpdatedRepos) != 1 {
+		t.Fatalf("UpdatedRepos = %+v, want golang/go only", diff.UpdatedRepos)
+	}
+	if got := diff.UpdatedRepos[0]; got.Repo != "golang/go" || got.NewPRs != 2 || got.PRsBefore != 3 || got.PRsAfter != 5 {
+		t.Errorf("UpdatedRepos[0] = %+v", got)
+	}
+
+	if len(diff.StarChanges) != 1 || diff.StarChanges[0].Delta != 10 {
+		t.Errorf("StarChanges = %+v, want golang/go +10", diff.StarChanges)
+	}
+
+	if len(diff.RemovedRepos) != 1 || diff.RemovedRepos[0].Repo != "old/gone" {
+		t.Errorf("RemovedRepos = %+v, want old/gone", diff.RemovedRepos)
+	}
+
+	if diff.IsEmpty() {
+		t.Error("IsEmpty() = true, want false")
+	}
+}
+
+func TestStatsDiffSkipsUnknownStars(t *testing.T) {
+	older := &Stats{Contributions: []Contribution{{Repo: "a/b", Stars: 100}}}
+	newer := &Stats{Contributions: []Contribution{{Repo: "a/b", Stars: 0, Incomplete: true}}}
+
+	if diff := older.Diff(newer); len(diff.StarChanges) != 0 {
+		t.Errorf("StarChanges = %+v, want none for an incomplete contribution", diff.StarChanges)
+	}
+}
+
+func TestStatsDiffIdentical(t *testing.T) {
+	older, _ := diffFixtures()
+
+	diff := older.Diff(older)
+	if !diff.IsEmpty() {
+		t.Errorf("IsEmpty() = false for identical snapshots: %+v", diff)
+	}
+	if !strings.Contains(diff.Text(), "No changes.") {
+		t.Errorf("Text() = %q, want 'No changes.'", diff.Text())
+	}
+	if !strings.Contains(diff.Markdown(), "No changes.") {
+		t.Errorf("Markdown() = %q, want 'No changes.'", diff.Markdown())
+	}
+}
+
+func TestStatsDiffText(t *testing.T) {
+	older, newer := diffFixtures()
+	text := older.Diff(newer).Text()
+
+	for _, want := range []string{
+		"Changes for octocat from 2025-06-01 to 2025-06-08",
+		"PRs merged:    +4",
+		"New repositories (2):",
+		"new/big (2 PRs, 900 stars)",
+		"golang/go: +2 PRs (3 -> 5)",
+		"golang/go: +10 (1000 -> 1010)",
+		"No longer listed (1):",
+	} {
+		if !strings.Contains(text, want) {
+			t.Errorf("Text() missing %q:\n%s", want, text)
+		}
+	}
+}
+
+func TestStatsDiffMarkdown(t *testing.T) {
+	older, newer := diffFixtures()
+	md := older.Diff(newer).Markdown()
+
+	for _, want := range []string{
+		"## Open source changes for octocat",
+		"| PRs merged | +4 |",
+		"### New repositories",
+		"- new/big — 2 PRs, ⭐ 900",
+		"- [golang/go](https://github.com/golang/go) — +2 PRs (5 total)",
+		"### Star changes",
+	} {
+		if !strings.Contains(md, want) {
+			t.Errorf("Markdown() missing %q:\n%s", want, md)
+		}
+	}
+}
+
+func TestStatsDiffJSON(t *testing.T) {
+	older, newer := diffFixtures()
+
+	data, err := json.Marshal(older.Diff(newer))
+	if err != nil {
+		t.Fatalf("Marshal error: %v", err)
+	}
+
+	var decoded StatsDiff
+	if err := json.Unmarshal(data, &decoded); err != nil {
+		t.Fatalf("Unmarshal error: %v", err)
+	}
+	if decoded.Summary.PRsMerged != 4 || len(decoded.NewRepos) != 2 {
+		t.Errorf("Decoded diff = %+v", decoded)
+	}
+}
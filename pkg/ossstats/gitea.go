@@ -0,0 +1,351 @@
+package ossstats
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/mabd-dev/gh-oss-stats/internal/gitea"
+)
+
+// GiteaConfig configures a Gitea, Forgejo or Codeberg instance to collect
+// contributions from.
+type GiteaConfig struct {
+	// BaseURL is the instance URL, e.g. "https://codeberg.org".
+	// A bare host is assumed to use HTTPS. Required.
+	BaseURL string
+
+	// Token is an access token with read access to issues, repositories and
+	// user profiles. With a token of the user, their pull requests are found
+	// with the issue search. Otherwise, and without a token, they are found
+	// through the user's activity feed, which only covers the activity the
+	// instance still keeps and repositories visible to the token.
+	Token string
+
+	// Username is the user's username on the instance, when it differs from
+	// the username passed to GetContributions.
+	Username string
+
+	// HTTPClient is used for Gitea requests. Default: the Client's HTTP client
+	HTTPClient *http.Client
+}
+
+// giteaProvider collects merged pull requests from a Gitea instance.
+type giteaProvider struct {
+	cfg    GiteaConfig
+	client *Client // Set by WithGitea to share the HTTP client; may be nil
+}
+
+// NewGiteaProvider returns a Provider for the Gitea, Forgejo or Codeberg
+// instance in cfg. It finds merged pull requests opened by the user in
+// repositories they don't own, and reports each repository as a
+// contribution tagged with ForgeGitea and the instance host.
+func NewGiteaProvider(cfg GiteaConfig) Provider {
+	return &giteaProvider{cfg: cfg}
+}
+
+// Name implements Provider.
+func (p *giteaProvider) Name() string {
+	u, err := url.Parse(p.baseURL())
+	if err != nil || u.Host == "" {
+		return p.baseURL()
+	}
+	return u.Host
+}
+
+// baseURL returns the configured instance URL, with a scheme.
+func (p *giteaProvider) baseURL() string {
+	baseURL := strings.TrimSuffix(p.cfg.BaseURL, "/")
+	if baseURL != "" && !strings.Contains(baseURL, "://") {
+		baseURL = "https://" + baseURL
+	}
+	return baseURL
+}
+
+// httpClient returns the HTTP client to use for Gitea requests.
+func (p *giteaProvider) httpClient() *http.Client {
+	switch {
+	case p.cfg.HTTPClient != nil:
+		return p.cfg.HTTPClient
+	case p.client != nil:
+		return p.client.httpClient
+	default:
+		return http.DefaultClient
+	}
+}
+
+// Contributions implements Provider.
+func (p *giteaProvider) Contributions(ctx context.Context, username string, opts ProviderOptions) (*ProviderResult, error) {
+	logger := opts.Logger
+	if logger == nil {
+		logger = defaultLogger{}
+	}
+	if p.cfg.Username != "" {
+		username = p.cfg.Username
+	}
+	if p.baseURL() == "" {
+		return nil, errors.New("gitea: instance URL is required")
+	}
+
+	api := gitea.NewAPIClient(p.httpClient(), p.baseURL(), p.cfg.Token)
+
+	var self *gitea.User
+	if p.cfg.Token != "" {
+		user, _, err := api.GetAuthenticatedUser(ctx)
+		if err != nil {
+			return nil, giteaError(err, "user API")
+		}
+		self = user
+	}
+
+	// Step 1: Find merged PRs to repositories the user doesn't own. The issue
+	// search only finds the PRs of the token's user, so the PRs of anyone
+	// else are found through their activity feed.
+	var (
+		prs         []gitea.Issue
+		errs        []error
+		unprocessed int
+		err         error
+	)
+	if self != nil && strings.EqualFold(self.Login, username) {
+		logger.Printf("Searching for merged PRs on %s...", p.Name())
+		prs, err = p.searchMergedPRs(ctx, api, self, opts)
+	} else {
+		user, _, lookupErr := api.GetUser(ctx, username)
+		if lookupErr != nil {
+			var apiErr *gitea.APIError
+			if errors.As(lookupErr, &apiErr) && apiErr.StatusCode == http.StatusNotFound {
+				return nil, &ErrNotFound{Username: username}
+			}
+			return nil, giteaError(lookupErr, "users API")
+		}
+		logger.Printf("Searching the activity feed of %s for merged PRs on %s...", user.Login, p.Name())
+		prs, errs, unprocessed, err = p.feedMergedPRs(ctx, api, user, opts)
+	}
+	if err != nil {
+		return nil, err
+	}
+	logger.Printf("Found %d merged PRs on %s", len(prs), p.Name())
+
+	// Step 2: Aggregate by repository and fetch repository metadata
+	result := &ProviderResult{Contributions: []Contribution{}}
+	if len(prs) > 0 {
+		result = forgeContributions(ctx, prs, opts, logger, p.aggregation(api))
+	}
+	result.Errors = append(errs, result.Errors...)
+	result.UnprocessedPRs += unprocessed
+	return result, nil
+}
+
+// searchMergedPRs pages through the user's closed PRs, keeping the merged
+// ones to repositories outside the user's account and excluded owners.
+// user must be the user the token belongs to.
+func (p *giteaProvider) searchMergedPRs(ctx context.Context, api *gitea.APIClient, user *gitea.User, opts ProviderOptions) ([]gitea.Issue, error) {
+	const limit = 50
+
+	var prs []gitea.Issue
+	for page := 1; ; page++ {
+		issues, resp, err := api.SearchPullRequests(ctx, page, limit)
+		if err != nil {
+			return nil, giteaError(err, "issue search API")
+		}
+
+		for _, issue := range issues {
+			if issue.PullRequest == nil || !issue.PullRequest.Merged || issue.PullRequest.MergedAt == nil {
+				continue
+			}
+			owner := issue.Repository.Owner
+			if strings.EqualFold(owner, user.Login) || opts.excludes(owner) {
+				continue
+			}
+			prs = append(prs, issue)
+		}
+
+		if opts.MaxPRs > 0 && len(prs) >= opts.MaxPRs {
+			return prs[:opts.MaxPRs], nil
+		}
+		if !gitea.HasNextPage(resp, len(issues), limit) {
+			return prs, nil
+		}
+	}
+}
+
+// feedMergedPRs pages through the activity feed of user for the PRs they
+// opened in repositories outside their account and excluded owners, and
+// keeps those that were merged. The feed only covers what the instance
+// still keeps of the user's activity. Failed PR lookups are returned
+// alongside the PRs, with the number of PRs skipped once ctx was done.
+func (p *giteaProvider) feedMergedPRs(ctx context.Context, api *gitea.APIClient, user *gitea.User, opts ProviderOptions) ([]gitea.Issue, []error, int, error) {
+	const limit = 50
+
+	var (
+		prs         []gitea.Issue
+		errs        []error
+		unprocessed int
+		seen        = make(map[string]bool)
+	)
+	for page := 1; ; page++ {
+		activities, resp, err := api.ListUserActivities(ctx, user.Login, page, limit)
+		if err != nil {
+			return nil, nil, 0, giteaError(err, "activity feed API")
+		}
+
+		var opened []gitea.Issue
+		for _, activity := range activities {
+			number, ok := activity.PullRequestNumber()
+			if !ok {
+				continue
+			}
+			repo := activity.Repo
+			owner := repo.Owner.Login
+			key := fmt.Sprintf("%s#%d", strings.ToLower(repo.FullName), number)
+			if seen[key] || strings.EqualFold(owner, user.Login) || opts.excludes(owner) {
+				continue
+			}
+			seen[key] = true
+			opened = append(opened, gitea.Issue{
+				Number: number,
+				Repository: gitea.RepositoryMeta{
+					ID:       repo.ID,
+					Name:     repo.Name,
+					Owner:    owner,
+					FullName: repo.FullName,
+				},
+			})
+		}
+
+		// The feed doesn't say whether a PR was merged, so look each one up
+		indexes := make([]int, len(opened))
+		for i := range opened {
+			indexes[i] = i
+		}
+		merged := make([]bool, len(opened))
+		lookupErrs := make([]error, len(opened))
+		unprocessed += forEachLimited(ctx, maxConcurrentRequests, indexes, func(i int) bool {
+			issue := &opened[i]
+			pr, _, err := api.GetPullRequest(ctx, issue.Repository.Owner, issue.Repository.Name, issue.Number)
+			if err != nil {
+				if ctx.Err() != nil {
+					return true
+				}
+				var apiErr *gitea.APIError
+				if errors.As(err, &apiErr) && apiErr.StatusCode == http.StatusNotFound {
+					return false // The repository was deleted or made private
+				}
+				lookupErrs[i] = fmt.Errorf("fetching PR %s#%d: %w", issue.Repository.FullName, issue.Number, giteaError(err, issue.Repository.FullName))
+				return false
+			}
+			if !pr.Merged || pr.MergedAt == nil {
+				return false
+			}
+			issue.Title = pr.Title
+			issue.HTMLURL = pr.HTMLURL
+			issue.PullRequest = &gitea.PullRequestMeta{Merged: true, MergedAt: pr.MergedAt}
+			merged[i] = true
+			return false
+		})
+		for i, issue := range opened {
+			if merged[i] {
+				prs = append(prs, issue)
+			}
+			if lookupErrs[i] != nil {
+				errs = append(errs, lookupErrs[i])
+			}
+		}
+
+		if opts.MaxPRs > 0 && len(prs) >= opts.MaxPRs {
+			return prs[:opts.MaxPRs], errs, unprocessed, nil
+		}
+		if ctx.Err() != nil || !gitea.HasNextPage(resp, len(activities), limit) {
+			return prs, errs, unprocessed, nil
+		}
+	}
+}
+
+// aggregation adapts pull requests to forgeContributions.
+func (p *giteaProvider) aggregation(api *gitea.APIClient) forgeAggregation[gitea.Issue] {
+	return forgeAggregation[gitea.Issue]{
+		repoNoun: "repository",
+		repository: func(pr gitea.Issue) (string, Contribution) {
+			return strings.ToLower(pr.Repository.FullName), p.newContribution(pr.Repository.Owner, pr.Repository.Name, "")
+		},
+		pullRequest: func(pr gitea.Issue) PullRequest {
+			return PullRequest{Number: pr.Number, Title: pr.Title, URL: pr.HTMLURL, MergedAt: *pr.PullRequest.MergedAt}
+		},
+		prRef: func(repo string, number int) string {
+			return fmt.Sprintf("PR %s#%d", repo, number)
+		},
+		count: func(ctx context.Context, pr gitea.Issue) (int, int, int, error) {
+			return countPullRequest(ctx, api, pr.Repository.Owner, pr.Repository.Name, pr.Number)
+		},
+		fetchRepository: func(ctx context.Context, repo *forgeRepo[gitea.Issue]) error {
+			contrib := &repo.contrib
+			info, _, err := api.GetRepository(ctx, contrib.Owner, contrib.RepoName)
+			if err != nil {
+				return err
+			}
+			if info.HTMLURL != "" {
+				contrib.RepoURL = info.HTMLURL
+			}
+			contrib.Description = info.Description
+			contrib.Stars = info.StarsCount
+			contrib.Language = info.Language
+			return nil
+		},
+		apiError: giteaError,
+	}
+}
+
+// countPullRequest returns the number of commits and the lines added and
+// deleted by a pull request.
+func countPullRequest(ctx context.Context, api *gitea.APIClient, owner, repo string, number int) (commits, additions, deletions int, err error) {
+	const limit = 50
+
+	pr, _, err := api.GetPullRequest(ctx, owner, repo, number)
+	if err != nil {
+		return 0, 0, 0, err
+	}
+
+	for page := 1; ; page++ {
+		pageCommits, resp, err := api.ListPullRequestCommits(ctx, owner, repo, number, page, limit)
+		if err != nil {
+			return 0, 0, 0, err
+		}
+		commits += len(pageCommits)
+		if !gitea.HasNextPage(resp, len(pageCommits), limit) {
+			break
+		}
+	}
+
+	return commits, pr.Additions, pr.Deletions, nil
+}
+
+// newContribution returns an empty contribution to owner/name. Without
+// htmlURL, the repository URL is derived from the instance URL.
+func (p *giteaProvider) newContribution(owner, name, htmlURL string) Contribution {
+	repo := owner + "/" + name
+	if htmlURL == "" {
+		htmlURL = p.baseURL() + "/" + repo
+	}
+	return Contribution{
+		Repo:     repo,
+		Forge:    ForgeGitea,
+		Host:     p.Name(),
+		Owner:    owner,
+		RepoName: name,
+		RepoURL:  htmlURL,
+	}
+}
+
+// giteaError converts authentication, rate limit and permission failures
+// into the package's typed errors. Other errors are returned unchanged.
+func giteaError(err error, resource string) error {
+	var apiErr *gitea.APIError
+	if !errors.As(err, &apiErr) {
+		return err
+	}
+	return forgeStatusError(err, "Gitea", apiErr.StatusCode, apiErr.Message, apiErr.RetryAt, resource)
+}
@@ -0,0 +1,281 @@
+package ossstats
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/mabd-dev/gh-oss-stats/internal/gitea"
+	"github.com/mabd-dev/gh-oss-stats/internal/github"
+)
+
+// newGiteaServer starts a Gitea stand-in where the token belongs to
+// "octocat", who has merged PRs to forgejo/forgejo (twice) and
+// codeberg/pages, an unmerged PR and a merged PR to their own repository.
+// The activity feed of "alice" opens PR 7 to forgejo/forgejo (twice),
+// unmerged PR 8 to codeberg/pages, a PR to her own repository and a PR to a
+// deleted repository.
+func newGiteaServer(t *testing.T) *httptest.Server {
+	t.Helper()
+
+	pr := func(number int, owner, name string, merged bool) gitea.Issue {
+		at := time.Date(2025, 4, number, 12, 0, 0, 0, time.UTC)
+		return gitea.Issue{
+			Number:      number,
+			PullRequest: &gitea.PullRequestMeta{Merged: merged, MergedAt: &at},
+			Repository:  gitea.RepositoryMeta{Owner: owner, Name: name, FullName: owner + "/" + name},
+		}
+	}
+	issues := []gitea.Issue{
+		pr(1, "forgejo", "forgejo", true),
+		pr(2, "forgejo", "forgejo", true),
+		pr(3, "codeberg", "pages", true),
+		pr(4, "codeberg", "pages", false),
+		pr(5, "octocat", "dotfiles", true),
+	}
+	activity := func(opType, owner, name, content string) gitea.Activity {
+		return gitea.Activity{
+			OpType:  opType,
+			ActUser: gitea.User{Login: "alice"},
+			Repo:    gitea.Repository{Name: name, FullName: owner + "/" + name, Owner: gitea.User{Login: owner}},
+			Content: content,
+		}
+	}
+	feed := []gitea.Activity{
+		activity(gitea.OpCreatePullRequest, "forgejo", "forgejo", "7|Fix typo"),
+		activity("commit_repo", "forgejo", "forgejo", "{}"),
+		activity(gitea.OpCreatePullRequest, "codeberg", "pages", "8|WIP"),
+		activity(gitea.OpCreatePullRequest, "alice", "notes", "6|Notes"),
+		activity(gitea.OpCreatePullRequest, "gone", "repo", "9|Lost"),
+		activity(gitea.OpCreatePullRequest, "forgejo", "forgejo", "7|Fix typo"),
+	}
+	repos := map[string]gitea.Repository{
+		"forgejo/forgejo": {FullName: "forgejo/forgejo", Description: "Beyond coding. We forge.", HTMLURL: "https://codeberg.test/forgejo/forgejo", StarsCount: 900, Language: "Go"},
+	}
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		path := strings.TrimPrefix(r.URL.Path, "/api/v1")
+		authorization := r.Header.Get("Authorization")
+		if authorization != "" && authorization != "token test-token" ||
+			authorization == "" && (path == "/user" || path == "/repos/issues/search") {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		switch {
+		case path == "/user":
+			json.NewEncoder(w).Encode(gitea.User{ID: 1, Login: "octocat"})
+		case strings.EqualFold(path, "/users/alice"): // Usernames are case-insensitive
+			json.NewEncoder(w).Encode(gitea.User{ID: 2, Login: "alice"})
+		case path == "/users/alice/activities/feeds":
+			json.NewEncoder(w).Encode(feed)
+		case path == "/repos/issues/search":
+			json.NewEncoder(w).Encode(issues)
+		case strings.HasPrefix(path, "/repos/gone/"):
+			http.NotFound(w, r)
+		case strings.HasSuffix(path, "/commits"):
+			json.NewEncoder(w).Encode([]gitea.Commit{{SHA: "a"}, {SHA: "b"}, {SHA: "c"}})
+		case strings.Contains(path, "/pulls/"):
+			number, _ := strconv.Atoi(path[strings.LastIndex(path, "/")+1:])
+			at := time.Date(2025, 4, number, 12, 0, 0, 0, time.UTC)
+			json.NewEncoder(w).Encode(gitea.PullRequest{
+				Number:    number,
+				Title:     fmt.Sprintf("PR %d", number),
+				HTMLURL:   fmt.Sprintf("%s%s", "https://codeberg.test", strings.TrimPrefix(path, "/repos")),
+				Merged:    number != 4 && number != 8,
+				MergedAt:  &at,
+				Additions: 10,
+				Deletions: 3,
+			})
+		case strings.HasPrefix(path, "/repos/"):
+			repo, ok := repos[strings.TrimPrefix(path, "/repos/")]
+			if !ok {
+				http.NotFound(w, r)
+				return
+			}
+			json.NewEncoder(w).Encode(repo)
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	t.Cleanup(server.Close)
+	return server
+}
+
+func TestGiteaProviderContributions(t *testing.T) {
+	server := newGiteaServer(t)
+	provider := NewGiteaProvider(GiteaConfig{BaseURL: server.URL, Token: "test-token", HTTPClient: server.Client()})
+	host := strings.TrimPrefix(server.URL, "http://")
+
+	result, err := provider.Contributions(context.Background(), "OctoCat", ProviderOptions{IncludeLOC: true, IncludePRDetails: true})
+	if err != nil {
+		t.Fatalf("Contributions() error = %v", err)
+	}
+
+	byRepo := make(map[string]Contribution)
+	for _, contrib := range result.Contributions {
+		byRepo[contrib.Repo] = contrib
+	}
+	if len(byRepo) != 2 {
+		t.Fatalf("Contributions = %+v, want forgejo/forgejo and codeberg/pages only", result.Contributions)
+	}
+
+	forgejo := byRepo["forgejo/forgejo"]
+	if forgejo.Forge != ForgeGitea || forgejo.Host != host {
+		t.Errorf("forgejo/forgejo forge/host = %s/%s, want %s/%s", forgejo.Forge, forgejo.Host, ForgeGitea, host)
+	}
+	if forgejo.PRsMerged != 2 || forgejo.Commits != 6 || forgejo.Additions != 20 || forgejo.Deletions != 6 {
+		t.Errorf("forgejo/forgejo counts = %d PRs, %d commits, +%d -%d, want 2 PRs, 6 commits, +20 -6",
+			forgejo.PRsMerged, forgejo.Commits, forgejo.Additions, forgejo.Deletions)
+	}
+	if forgejo.Stars != 900 || forgejo.Language != "Go" || forgejo.RepoURL != "https://codeberg.test/forgejo/forgejo" {
+		t.Errorf("forgejo/forgejo metadata = %+v", forgejo)
+	}
+	if len(forgejo.PullRequests) != 2 || forgejo.PullRequests[0].Number != 2 || forgejo.PullRequests[0].Commits != 3 || forgejo.PullRequests[1].Number != 1 {
+		t.Errorf("forgejo/forgejo PullRequests = %+v, want #2 and #1 with 3 commits each, most recent first", forgejo.PullRequests)
+	}
+
+	// The repository lookup of codeberg/pages fails
+	pages := byRepo["codeberg/pages"]
+	if !pages.Incomplete || pages.PRsMerged != 1 || pages.RepoURL != server.URL+"/codeberg/pages" {
+		t.Errorf("codeberg/pages = %+v, want an incomplete contribution with 1 PR", pages)
+	}
+	if len(result.Errors) != 1 {
+		t.Errorf("Errors = %v, want the failed repository lookup", result.Errors)
+	}
+}
+
+func TestGiteaProviderAuthentication(t *testing.T) {
+	server := newGiteaServer(t)
+
+	tests := []struct {
+		name     string
+		cfg      GiteaConfig
+		username string
+	}{
+		{"invalid token", GiteaConfig{BaseURL: server.URL, Token: "wrong"}, "octocat"},
+		{"invalid token for another user", GiteaConfig{BaseURL: server.URL, Token: "wrong"}, "alice"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.cfg.HTTPClient = server.Client()
+			_, err := NewGiteaProvider(tt.cfg).Contributions(context.Background(), tt.username, ProviderOptions{})
+
+			var authErr *ErrAuthentication
+			if !errors.As(err, &authErr) {
+				t.Errorf("Contributions() error = %v, want ErrAuthentication", err)
+			}
+		})
+	}
+}
+
+func TestGiteaProviderActivityFeed(t *testing.T) {
+	server := newGiteaServer(t)
+
+	tests := []struct {
+		name  string
+		token string
+	}{
+		{"without a token", ""},
+		{"token of another user", "test-token"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			provider := NewGiteaProvider(GiteaConfig{BaseURL: server.URL, Token: tt.token, HTTPClient: server.Client()})
+			result, err := provider.Contributions(context.Background(), "Alice", ProviderOptions{IncludeLOC: true, IncludePRDetails: true})
+			if err != nil {
+				t.Fatalf("Contributions() error = %v", err)
+			}
+			if len(result.Errors) != 0 || result.UnprocessedPRs != 0 {
+				t.Errorf("Errors = %v, UnprocessedPRs = %d, want none", result.Errors, result.UnprocessedPRs)
+			}
+			if len(result.Contributions) != 1 {
+				t.Fatalf("Contributions = %+v, want forgejo/forgejo only", result.Contributions)
+			}
+
+			forgejo := result.Contributions[0]
+			if forgejo.Repo != "forgejo/forgejo" || forgejo.PRsMerged != 1 || forgejo.Commits != 3 || forgejo.Additions != 10 {
+				t.Errorf("Contribution = %+v, want 1 PR to forgejo/forgejo with 3 commits and 10 additions", forgejo)
+			}
+			if len(forgejo.PullRequests) != 1 || forgejo.PullRequests[0].Number != 7 || forgejo.PullRequests[0].Title != "PR 7" ||
+				forgejo.PullRequests[0].URL != "https://codeberg.test/forgejo/forgejo/pulls/7" {
+				t.Errorf("PullRequests = %+v, want PR 7 with its title and URL", forgejo.PullRequests)
+			}
+		})
+	}
+}
+
+func TestGiteaProviderUnknownUser(t *testing.T) {
+	server := newGiteaServer(t)
+	provider := NewGiteaProvider(GiteaConfig{BaseURL: server.URL, HTTPClient: server.Client()})
+
+	_, err := provider.Contributions(context.Background(), "nobody", ProviderOptions{})
+
+	var notFound *ErrNotFound
+	if !errors.As(err, &notFound) {
+		t.Errorf("Contributions() error = %v, want ErrNotFound", err)
+	}
+}
+
+func TestGetContributionsAcrossForges(t *testing.T) {
+	githubServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mergedAt := time.Date(2025, 2, 1, 12, 0, 0, 0, time.UTC)
+		w.Header().Set("Content-Type", "application/json")
+		switch {
+		case strings.HasPrefix(r.URL.Path, "/search/issues"):
+			json.NewEncoder(w).Encode(github.SearchIssuesResponse{
+				TotalCount: 1,
+				Items: []github.Issue{{
+					Number:        1,
+					RepositoryURL: "https://api.github.com/repos/golang/go",
+					PullRequest:   &github.PullRequestRef{MergedAt: &mergedAt},
+				}},
+			})
+		case r.URL.Path == "/repos/golang/go":
+			json.NewEncoder(w).Encode(github.Repository{FullName: "golang/go", StargazersCount: 5, HTMLURL: "https://github.com/golang/go", Language: "Go"})
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer githubServer.Close()
+	gitlabServer := newGitLabServer(t)
+	giteaServer := newGiteaServer(t)
+
+	client := New(
+		WithToken("test-token"),
+		WithGitLab(GitLabConfig{BaseURL: gitlabServer.URL, HTTPClient: gitlabServer.Client()}),
+		WithGitea(GiteaConfig{BaseURL: giteaServer.URL, Token: "test-token", HTTPClient: giteaServer.Client()}),
+	)
+	client.httpClient.Transport = &mockTransport{server: githubServer}
+
+	stats, err := client.GetContributions(context.Background(), "octocat")
+
+	// The failed codeberg/pages lookup makes the results partial
+	var partialErr *ErrPartialResults
+	if !errors.As(err, &partialErr) || len(partialErr.Errors) != 1 {
+		t.Fatalf("GetContributions() error = %v, want partial results with 1 error", err)
+	}
+	if !strings.HasPrefix(partialErr.Errors[0].Error(), strings.TrimPrefix(giteaServer.URL, "http://")) {
+		t.Errorf("Error = %v, want it prefixed with the Gitea host", partialErr.Errors[0])
+	}
+
+	forges := make(map[string]int)
+	for _, contrib := range stats.Contributions {
+		forges[contrib.Forge]++
+	}
+	if forges[ForgeGitHub] != 1 || forges[ForgeGitLab] != 2 || forges[ForgeGitea] != 2 {
+		t.Errorf("Contributions per forge = %v, want 1 github, 2 gitlab and 2 gitea", forges)
+	}
+	if stats.Summary.TotalProjects != 5 || stats.Summary.TotalPRsMerged != 7 {
+		t.Errorf("Summary = %+v, want 5 projects and 7 PRs", stats.Summary)
+	}
+}
@@ -0,0 +1,262 @@
+package ossstats
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+
+	"github.com/mabd-dev/gh-oss-stats/internal/gitlab"
+)
+
+// GitLabConfig configures a GitLab instance to collect contributions from.
+type GitLabConfig struct {
+	// BaseURL is the instance URL, e.g. "https://gitlab.gnome.org".
+	// A bare host is assumed to use HTTPS. Default: https://gitlab.com
+	BaseURL string
+
+	// Token is a personal access token with the read_api scope. Without it
+	// only public merge requests and projects are visible.
+	Token string
+
+	// Username is the user's GitLab username, when it differs from the
+	// username passed to GetContributions.
+	Username string
+
+	// HTTPClient is used for GitLab requests. Default: the Client's HTTP client
+	HTTPClient *http.Client
+}
+
+// gitlabProvider collects merged merge requests from a GitLab instance.
+type gitlabProvider struct {
+	cfg    GitLabConfig
+	client *Client // Set by WithGitLab to share the HTTP client; may be nil
+}
+
+// NewGitLabProvider returns a Provider for the GitLab instance in cfg.
+// It finds merged merge requests authored by the user in projects outside
+// their own namespace, and reports each project as a contribution tagged
+// with ForgeGitLab and the instance host.
+func NewGitLabProvider(cfg GitLabConfig) Provider {
+	return &gitlabProvider{cfg: cfg}
+}
+
+// Name implements Provider.
+func (p *gitlabProvider) Name() string {
+	u, err := url.Parse(p.baseURL())
+	if err != nil || u.Host == "" {
+		return p.baseURL()
+	}
+	return u.Host
+}
+
+// baseURL returns the configured instance URL, with a scheme.
+func (p *gitlabProvider) baseURL() string {
+	baseURL := strings.TrimSuffix(p.cfg.BaseURL, "/")
+	if baseURL == "" {
+		return gitlab.GitLabBaseURL
+	}
+	if !strings.Contains(baseURL, "://") {
+		baseURL = "https://" + baseURL
+	}
+	return baseURL
+}
+
+// httpClient returns the HTTP client to use for GitLab requests.
+func (p *gitlabProvider) httpClient() *http.Client {
+	switch {
+	case p.cfg.HTTPClient != nil:
+		return p.cfg.HTTPClient
+	case p.client != nil:
+		return p.client.httpClient
+	default:
+		return http.DefaultClient
+	}
+}
+
+// Contributions implements Provider.
+func (p *gitlabProvider) Contributions(ctx context.Context, username string, opts ProviderOptions) (*ProviderResult, error) {
+	logger := opts.Logger
+	if logger == nil {
+		logger = defaultLogger{}
+	}
+	if p.cfg.Username != "" {
+		username = p.cfg.Username
+	}
+
+	api := gitlab.NewAPIClient(p.httpClient(), p.baseURL(), p.cfg.Token)
+
+	user, _, err := api.FindUser(ctx, username)
+	if err != nil {
+		return nil, gitlabError(err, "users API")
+	}
+	if user == nil {
+		return nil, &ErrNotFound{Username: username}
+	}
+
+	// Step 1: List merged MRs to projects outside the user's namespace
+	logger.Printf("Searching for merged merge requests on %s...", p.Name())
+	mrs, err := p.listMergedMRs(ctx, api, user, opts)
+	if err != nil {
+		return nil, err
+	}
+	logger.Printf("Found %d merged merge requests on %s", len(mrs), p.Name())
+
+	if len(mrs) == 0 {
+		return &ProviderResult{Contributions: []Contribution{}}, nil
+	}
+
+	// Step 2: Aggregate by project and fetch project metadata
+	return forgeContributions(ctx, mrs, opts, logger, p.aggregation(api)), nil
+}
+
+// listMergedMRs pages through the merged MRs authored by user, skipping
+// those to projects in the user's own namespace or an excluded one.
+func (p *gitlabProvider) listMergedMRs(ctx context.Context, api *gitlab.APIClient, user *gitlab.User, opts ProviderOptions) ([]gitlab.MergeRequest, error) {
+	const perPage = 100
+
+	var mrs []gitlab.MergeRequest
+	for page := 1; ; page++ {
+		pageMRs, resp, err := api.ListMergedMergeRequests(ctx, user.ID, page, perPage)
+		if err != nil {
+			return nil, gitlabError(err, "merge requests API")
+		}
+
+		for _, mr := range pageMRs {
+			if mr.MergedAt == nil {
+				continue
+			}
+			namespace, _, _ := strings.Cut(mergeRequestProject(mr), "/")
+			if strings.EqualFold(namespace, user.Username) || opts.excludes(namespace) {
+				continue
+			}
+			mrs = append(mrs, mr)
+		}
+
+		if opts.MaxPRs > 0 && len(mrs) >= opts.MaxPRs {
+			return mrs[:opts.MaxPRs], nil
+		}
+		if !gitlab.HasNextPage(resp, len(pageMRs), perPage) {
+			return mrs, nil
+		}
+	}
+}
+
+// aggregation adapts merge requests to forgeContributions. MRs are grouped
+// by project ID, so renamed projects are counted once.
+func (p *gitlabProvider) aggregation(api *gitlab.APIClient) forgeAggregation[gitlab.MergeRequest] {
+	return forgeAggregation[gitlab.MergeRequest]{
+		repoNoun: "project",
+		repository: func(mr gitlab.MergeRequest) (string, Contribution) {
+			return strconv.Itoa(mr.ProjectID), p.newContribution(mergeRequestProject(mr), "", mr.ProjectID)
+		},
+		pullRequest: func(mr gitlab.MergeRequest) PullRequest {
+			return PullRequest{Number: mr.IID, Title: mr.Title, URL: mr.WebURL, MergedAt: *mr.MergedAt}
+		},
+		prRef: func(repo string, number int) string {
+			return fmt.Sprintf("MR %s!%d", repo, number)
+		},
+		count: func(ctx context.Context, mr gitlab.MergeRequest) (int, int, int, error) {
+			return countMergeRequest(ctx, api, mr)
+		},
+		fetchRepository: func(ctx context.Context, project *forgeRepo[gitlab.MergeRequest]) error {
+			info, _, err := api.GetProject(ctx, project.prs[0].ProjectID)
+			if err != nil {
+				return err
+			}
+			contrib := &project.contrib
+			enriched := p.newContribution(info.PathWithNamespace, info.WebURL, info.ID)
+			contrib.Repo = enriched.Repo
+			contrib.Owner = enriched.Owner
+			contrib.RepoName = enriched.RepoName
+			contrib.RepoURL = enriched.RepoURL
+			contrib.Description = info.Description
+			contrib.Stars = info.StarCount
+			return nil
+		},
+		apiError: gitlabError,
+	}
+}
+
+// countMergeRequest returns the number of commits and the lines added and
+// deleted by a merge request.
+func countMergeRequest(ctx context.Context, api *gitlab.APIClient, mr gitlab.MergeRequest) (commits, additions, deletions int, err error) {
+	const perPage = 100
+
+	for page := 1; ; page++ {
+		pageCommits, resp, err := api.ListMergeRequestCommits(ctx, mr.ProjectID, mr.IID, page, perPage)
+		if err != nil {
+			return 0, 0, 0, err
+		}
+		commits += len(pageCommits)
+		if !gitlab.HasNextPage(resp, len(pageCommits), perPage) {
+			break
+		}
+	}
+
+	for page := 1; ; page++ {
+		diffs, resp, err := api.ListMergeRequestDiffs(ctx, mr.ProjectID, mr.IID, page, perPage)
+		if err != nil {
+			return 0, 0, 0, err
+		}
+		for _, diff := range diffs {
+			added, deleted := gitlab.CountLines(diff.Diff)
+			additions += added
+			deletions += deleted
+		}
+		if !gitlab.HasNextPage(resp, len(diffs), perPage) {
+			break
+		}
+	}
+
+	return commits, additions, deletions, nil
+}
+
+// newContribution returns an empty contribution to the project at path.
+// Without webURL, the project URL is derived from the instance URL.
+func (p *gitlabProvider) newContribution(path, webURL string, projectID int) Contribution {
+	if path == "" {
+		path = fmt.Sprintf("projects/%d", projectID)
+	}
+	if webURL == "" {
+		webURL = p.baseURL() + "/" + path
+	}
+
+	owner, name := "", path
+	if i := strings.LastIndex(path, "/"); i >= 0 {
+		owner, name = path[:i], path[i+1:]
+	}
+
+	return Contribution{
+		Repo:     path,
+		Forge:    ForgeGitLab,
+		Host:     p.Name(),
+		Owner:    owner,
+		RepoName: name,
+		RepoURL:  webURL,
+	}
+}
+
+// mergeRequestProject returns the project path of a merge request from its
+// web URL, e.g. "GNOME/gtk" for https://gitlab.gnome.org/GNOME/gtk/-/merge_requests/1.
+func mergeRequestProject(mr gitlab.MergeRequest) string {
+	u, err := url.Parse(mr.WebURL)
+	if err != nil {
+		return ""
+	}
+	path, _, _ := strings.Cut(u.Path, "/-/")
+	return strings.Trim(path, "/")
+}
+
+// gitlabError converts authentication, rate limit and permission failures
+// into the package's typed errors. Other errors are returned unchanged.
+func gitlabError(err error, resource string) error {
+	var apiErr *gitlab.APIError
+	if !errors.As(err, &apiErr) {
+		return err
+	}
+	return forgeStatusError(err, "GitLab", apiErr.StatusCode, apiErr.Message, apiErr.RetryAt, resource)
+}
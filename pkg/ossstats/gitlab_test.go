@@ -0,0 +1,277 @@
+package ossstats
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/mabd-dev/gh-oss-stats/internal/github"
+	"github.com/mabd-dev/gh-oss-stats/internal/gitlab"
+)
+
+// newGitLabServer starts a GitLab stand-in where "octocat" has merged MRs
+// to GNOME/gtk (twice), group/sub/tool and their own project. Projects in
+// failProjects respond with 500.
+func newGitLabServer(t *testing.T, failProjects ...int) *httptest.Server {
+	t.Helper()
+
+	merged := func(day int) *time.Time {
+		at := time.Date(2025, 3, day, 12, 0, 0, 0, time.UTC)
+		return &at
+	}
+	mrs := []gitlab.MergeRequest{
+		{IID: 1, ProjectID: 10, MergedAt: merged(1), WebURL: "https://gitlab.test/GNOME/gtk/-/merge_requests/1"},
+		{IID: 2, ProjectID: 10, MergedAt: merged(5), WebURL: "https://gitlab.test/GNOME/gtk/-/merge_requests/2"},
+		{IID: 3, ProjectID: 20, MergedAt: merged(3), WebURL: "https://gitlab.test/group/sub/tool/-/merge_requests/3"},
+		{IID: 4, ProjectID: 30, MergedAt: merged(4), WebURL: "https://gitlab.test/octocat/dotfiles/-/merge_requests/4"},
+	}
+	projects := map[string]gitlab.Project{
+		"10": {ID: 10, PathWithNamespace: "GNOME/gtk", Description: "GUI toolkit", WebURL: "https://gitlab.test/GNOME/gtk", StarCount: 1200},
+		"20": {ID: 20, PathWithNamespace: "group/sub/tool", WebURL: "https://gitlab.test/group/sub/tool", StarCount: 7},
+	}
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		path := strings.TrimPrefix(r.URL.Path, "/api/v4")
+		switch {
+		case path == "/users":
+			if r.URL.Query().Get("username") == "octocat" {
+				json.NewEncoder(w).Encode([]gitlab.User{{ID: 42, Username: "octocat"}})
+				return
+			}
+			json.NewEncoder(w).Encode([]gitlab.User{})
+		case path == "/merge_requests":
+			if r.URL.Query().Get("author_id") != "42" || r.URL.Query().Get("state") != "merged" {
+				t.Errorf("unexpected merge request query %s", r.URL.RawQuery)
+			}
+			w.Header().Set("X-Page", "1")
+			json.NewEncoder(w).Encode(mrs)
+		case strings.HasSuffix(path, "/commits"):
+			json.NewEncoder(w).Encode([]gitlab.Commit{{ID: "a"}, {ID: "b"}})
+		case strings.HasSuffix(path, "/diffs"):
+			json.NewEncoder(w).Encode([]gitlab.Diff{{NewPath: "main.c", Diff: "@@ -1 +1,2 @@\n-old\n+new\n+added\n"}})
+		case strings.HasPrefix(path, "/projects/"):
+			id := strings.TrimPrefix(path, "/projects/")
+			for _, fail := range failProjects {
+				if id == fmt.Sprint(fail) {
+					w.WriteHeader(http.StatusInternalServerError)
+					return
+				}
+			}
+			project, ok := projects[id]
+			if !ok {
+				http.NotFound(w, r)
+				return
+			}
+			json.NewEncoder(w).Encode(project)
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	t.Cleanup(server.Close)
+	return server
+}
+
+func TestGitLabProviderContributions(t *testing.T) {
+	server := newGitLabServer(t)
+	provider := NewGitLabProvider(GitLabConfig{BaseURL: server.URL, HTTPClient: server.Client()})
+	host := strings.TrimPrefix(server.URL, "http://")
+
+	if provider.Name() != host {
+		t.Errorf("Name() = %q, want %q", provider.Name(), host)
+	}
+
+	result, err := provider.Contributions(context.Background(), "octocat", ProviderOptions{IncludeLOC: true, IncludePRDetails: true})
+	if err != nil {
+		t.Fatalf("Contributions() error = %v", err)
+	}
+	if len(result.Errors) != 0 {
+		t.Errorf("Errors = %v, want none", result.Errors)
+	}
+
+	byRepo := make(map[string]Contribution)
+	for _, contrib := range result.Contributions {
+		byRepo[contrib.Repo] = contrib
+	}
+	if len(byRepo) != 2 {
+		t.Fatalf("Contributions = %+v, want GNOME/gtk and group/sub/tool only", result.Contributions)
+	}
+
+	gtk := byRepo["GNOME/gtk"]
+	if gtk.Forge != ForgeGitLab || gtk.Host != host {
+		t.Errorf("GNOME/gtk forge/host = %s/%s, want %s/%s", gtk.Forge, gtk.Host, ForgeGitLab, host)
+	}
+	if gtk.PRsMerged != 2 || gtk.Commits != 4 || gtk.Additions != 4 || gtk.Deletions != 2 {
+		t.Errorf("GNOME/gtk counts = %d PRs, %d commits, +%d -%d, want 2 PRs, 4 commits, +4 -2",
+			gtk.PRsMerged, gtk.Commits, gtk.Additions, gtk.Deletions)
+	}
+	if gtk.Stars != 1200 || gtk.Description != "GUI toolkit" || gtk.RepoURL != "https://gitlab.test/GNOME/gtk" {
+		t.Errorf("GNOME/gtk metadata = %+v", gtk)
+	}
+	if !gtk.FirstContribution.Equal(time.Date(2025, 3, 1, 12, 0, 0, 0, time.UTC)) ||
+		!gtk.LastContribution.Equal(time.Date(2025, 3, 5, 12, 0, 0, 0, time.UTC)) {
+		t.Errorf("GNOME/gtk contribution dates = %v - %v", gtk.FirstContribution, gtk.LastContribution)
+	}
+	if len(gtk.PullRequests) != 2 || gtk.PullRequests[0].Number != 2 || gtk.PullRequests[1].Number != 1 {
+		t.Fatalf("GNOME/gtk PullRequests = %+v, want !2 and !1, most recent first", gtk.PullRequests)
+	}
+	if mr := gtk.PullRequests[0]; mr.URL != "https://gitlab.test/GNOME/gtk/-/merge_requests/2" || mr.Commits != 2 || mr.Additions != 2 || mr.Deletions != 1 {
+		t.Errorf("GNOME/gtk!2 = %+v, want its URL, 2 commits, +2 -1", mr)
+	}
+
+	tool := byRepo["group/sub/tool"]
+	if tool.Owner != "group/sub" || tool.RepoName != "tool" {
+		t.Errorf("group/sub/tool owner/name = %s/%s, want group/sub and tool", tool.Owner, tool.RepoName)
+	}
+}
+
+func TestGitLabProviderOptions(t *testing.T) {
+	server := newGitLabServer(t)
+	provider := NewGitLabProvider(GitLabConfig{BaseURL: server.URL, HTTPClient: server.Client()})
+
+	result, err := provider.Contributions(context.Background(), "octocat", ProviderOptions{ExcludeOrgs: []string{"gnome"}})
+	if err != nil {
+		t.Fatalf("Contributions() error = %v", err)
+	}
+	if len(result.Contributions) != 1 || result.Contributions[0].Repo != "group/sub/tool" {
+		t.Fatalf("Contributions = %+v, want group/sub/tool only", result.Contributions)
+	}
+	if result.Contributions[0].Commits != 1 {
+		t.Errorf("Commits = %d, want 1 per MR without LOC", result.Contributions[0].Commits)
+	}
+
+	result, err = provider.Contributions(context.Background(), "octocat", ProviderOptions{MaxPRs: 1})
+	if err != nil {
+		t.Fatalf("Contributions() error = %v", err)
+	}
+	if len(result.Contributions) != 1 || result.Contributions[0].PRsMerged != 1 {
+		t.Errorf("Contributions = %+v, want a single MR with MaxPRs 1", result.Contributions)
+	}
+}
+
+func TestGitLabProviderErrors(t *testing.T) {
+	server := newGitLabServer(t, 20)
+	provider := NewGitLabProvider(GitLabConfig{BaseURL: server.URL, HTTPClient: server.Client()})
+
+	_, err := provider.Contributions(context.Background(), "nobody", ProviderOptions{})
+	var notFound *ErrNotFound
+	if !errors.As(err, &notFound) {
+		t.Errorf("Contributions(nobody) error = %v, want ErrNotFound", err)
+	}
+
+	result, err := provider.Contributions(context.Background(), "octocat", ProviderOptions{})
+	if err != nil {
+		t.Fatalf("Contributions() error = %v", err)
+	}
+	if len(result.Errors) != 1 {
+		t.Errorf("Errors = %v, want the failed project lookup", result.Errors)
+	}
+	for _, contrib := range result.Contributions {
+		if contrib.Incomplete != (contrib.Repo == "group/sub/tool") {
+			t.Errorf("%s Incomplete = %v", contrib.Repo, contrib.Incomplete)
+		}
+	}
+}
+
+func TestGitLabError(t *testing.T) {
+	tests := []struct {
+		status int
+		check  func(error) bool
+	}{
+		{http.StatusUnauthorized, func(err error) bool { var e *ErrAuthentication; return errors.As(err, &e) }},
+		{http.StatusForbidden, func(err error) bool { var e *ErrForbidden; return errors.As(err, &e) }},
+		{http.StatusTooManyRequests, func(err error) bool { var e *ErrRateLimited; return errors.As(err, &e) }},
+		{http.StatusInternalServerError, func(err error) bool { var e *gitlab.APIError; return errors.As(err, &e) }},
+	}
+
+	for _, tt := range tests {
+		err := gitlabError(&gitlab.APIError{StatusCode: tt.status}, "GNOME/gtk")
+		if !tt.check(err) {
+			t.Errorf("gitlabError(%d) = %T, unexpected type", tt.status, err)
+		}
+	}
+}
+
+func TestGetContributionsWithGitLab(t *testing.T) {
+	githubServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mergedAt := time.Date(2025, 2, 1, 12, 0, 0, 0, time.UTC)
+		w.Header().Set("Content-Type", "application/json")
+		switch {
+		case strings.HasPrefix(r.URL.Path, "/search/issues"):
+			json.NewEncoder(w).Encode(github.SearchIssuesResponse{
+				TotalCount: 1,
+				Items: []github.Issue{{
+					Number:        1,
+					RepositoryURL: "https://api.github.com/repos/GNOME/gtk",
+					PullRequest:   &github.PullRequestRef{MergedAt: &mergedAt},
+				}},
+			})
+		case r.URL.Path == "/repos/GNOME/gtk":
+			json.NewEncoder(w).Encode(github.Repository{FullName: "GNOME/gtk", StargazersCount: 5, HTMLURL: "https://github.com/GNOME/gtk"})
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer githubServer.Close()
+	gitlabServer := newGitLabServer(t)
+
+	client := New(
+		WithToken("test-token"),
+		WithGitLab(GitLabConfig{BaseURL: gitlabServer.URL, HTTPClient: gitlabServer.Client()}),
+	)
+	client.httpClient.Transport = &mockTransport{server: githubServer}
+
+	stats, err := client.GetContributions(context.Background(), "octocat")
+	if err != nil {
+		t.Fatalf("GetContributions() error = %v", err)
+	}
+
+	// The GitHub mirror and the GitLab project are distinct contributions
+	forges := make(map[string]int)
+	for _, contrib := range stats.Contributions {
+		forges[contrib.Forge]++
+	}
+	if forges[ForgeGitHub] != 1 || forges[ForgeGitLab] != 2 {
+		t.Errorf("Contributions per forge = %v, want 1 github and 2 gitlab", forges)
+	}
+	if stats.Summary.TotalProjects != 3 || stats.Summary.TotalPRsMerged != 4 {
+		t.Errorf("Summary = %+v, want 3 projects and 4 PRs", stats.Summary)
+	}
+	if stats.Contributions[len(stats.Contributions)-1].Host != "github.com" {
+		t.Errorf("Oldest contribution = %+v, want the github.com one", stats.Contributions[len(stats.Contributions)-1])
+	}
+}
+
+func TestGetContributionsGitLabFailureIsPartial(t *testing.T) {
+	githubServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(github.SearchIssuesResponse{Items: []github.Issue{}})
+	}))
+	defer githubServer.Close()
+	gitlabServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusUnauthorized)
+	}))
+	defer gitlabServer.Close()
+
+	client := New(
+		WithToken("test-token"),
+		WithGitLab(GitLabConfig{BaseURL: gitlabServer.URL, HTTPClient: gitlabServer.Client()}),
+	)
+	client.httpClient.Transport = &mockTransport{server: githubServer}
+
+	_, err := client.GetContributions(context.Background(), "octocat")
+	var partialErr *ErrPartialResults
+	if !errors.As(err, &partialErr) {
+		t.Fatalf("GetContributions() error = %v, want ErrPartialResults", err)
+	}
+	var authErr *ErrAuthentication
+	if len(partialErr.Errors) != 1 || !errors.As(partialErr.Errors[0], &authErr) {
+		t.Errorf("Errors = %v, want the GitLab authentication failure", partialErr.Errors)
+	}
+}
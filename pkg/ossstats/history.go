@@ -0,0 +1,238 @@
+package ossstats
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"runtime"
+	"slices"
+	"strings"
+	"time"
+)
+
+// historyFileName is the name of the history file inside the data directory.
+const historyFileName = "history.jsonl"
+
+// History is a local store of Stats snapshots, kept as a JSON-lines file
+// with one snapshot per line. Snapshots are keyed by username and
+// GeneratedAt, so appending the same snapshot twice has no effect. Lines
+// that cannot be parsed, such as one cut short by a crash, are skipped.
+type History struct {
+	path   string
+	logger Logger
+}
+
+// TrendPoint summarizes one snapshot and its growth since the previous one.
+type TrendPoint struct {
+	GeneratedAt time.Time `json:"generatedAt"`
+	Projects    int       `json:"projects"`
+	PRsMerged   int       `json:"prsMerged"`
+	Stars       int       `json:"stars"` // Total stars of contributed repos with known star counts
+
+	ProjectsDelta int `json:"projectsDelta"` // Net change in projects since the previous snapshot, negative if some dropped out
+	NewPRs        int `json:"newPRs"`        // PRs merged since the previous snapshot
+	StarsGained   int `json:"starsGained"`   // Star changes of repos present in both snapshots
+}
+
+// NewHistory returns a history store backed by the file at path.
+// The file and its directory are created on the first Append.
+func NewHistory(path string) *History {
+	return &History{path: path, logger: defaultLogger{}}
+}
+
+// SetLogger sets the logger that warns about skipped history lines.
+func (h *History) SetLogger(logger Logger) {
+	h.logger = logger
+}
+
+// DefaultHistoryPath returns the history file location under the user data
+// directory: $XDG_DATA_HOME (or ~/.local/share) on Linux,
+// ~/Library/Application Support on macOS and %LocalAppData% on Windows.
+func DefaultHistoryPath() (string, error) {
+	dir, err := userDataDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, "gh-oss-stats", historyFileName), nil
+}
+
+// userDataDir returns the base directory for user-specific data files.
+func userDataDir() (string, error) {
+	switch runtime.GOOS {
+	case "windows":
+		if dir := os.Getenv("LocalAppData"); dir != "" {
+			return dir, nil
+		}
+		return "", errors.New("%LocalAppData% is not defined")
+	case "darwin", "ios":
+		home, err := os.UserHomeDir()
+		if err != nil {
+			return "", err
+		}
+		return filepath.Join(home, "Library", "Application Support"), nil
+	}
+
+	if dir := os.Getenv("XDG_DATA_HOME"); dir != "" {
+		return dir, nil
+	}
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(home, ".local", "share"), nil
+}
+
+// Path returns the location of the history file.
+func (h *History) Path() string {
+	return h.path
+}
+
+// Append adds a snapshot to the history. Snapshots already stored for the
+// same username and GeneratedAt are not written again.
+func (h *History) Append(stats *Stats) error {
+	if stats == nil {
+		return errors.New("stats cannot be nil")
+	}
+
+	existing, err := h.Snapshots(stats.Username)
+	if err != nil {
+		return err
+	}
+	for _, snapshot := range existing {
+		if snapshot.GeneratedAt.Equal(stats.GeneratedAt) {
+			return nil
+		}
+	}
+
+	data, err := json.Marshal(stats)
+	if err != nil {
+		return fmt.Errorf("encoding snapshot: %w", err)
+	}
+
+	if err := os.MkdirAll(filepath.Dir(h.path), 0o755); err != nil {
+		return fmt.Errorf("creating history directory: %w", err)
+	}
+
+	f, err := os.OpenFile(h.path, os.O_CREATE|os.O_APPEND|os.O_RDWR, 0o644)
+	if err != nil {
+		return fmt.Errorf("opening history: %w", err)
+	}
+	// Start a new line after a last line that was cut short
+	if info, err := f.Stat(); err == nil && info.Size() > 0 {
+		last := make([]byte, 1)
+		if _, err := f.ReadAt(last, info.Size()-1); err == nil && last[0] != '\n' {
+			data = append([]byte{'\n'}, data...)
+		}
+	}
+	if _, err := f.Write(append(data, '\n')); err != nil {
+		f.Close()
+		return fmt.Errorf("writing history: %w", err)
+	}
+	return f.Close()
+}
+
+// Snapshots returns the stored snapshots of username, oldest first.
+// Snapshots stored by older versions are migrated to the current schema.
+// Usernames are matched case-insensitively; an empty username returns the
+// snapshots of all users. A missing history file yields no snapshots, and
+// lines that cannot be parsed are skipped with a warning.
+func (h *History) Snapshots(username string) ([]Stats, error) {
+	f, err := os.Open(h.path)
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("reading history: %w", err)
+	}
+	defer f.Close()
+
+	type key struct {
+		username    string
+		generatedAt int64
+	}
+	seen := make(map[key]bool)
+
+	var snapshots []Stats
+	scanner := bufio.NewScanner(f)
+	scanner.Buffer(make([]byte, 0, 64*1024), 64*1024*1024)
+	for line := 1; scanner.Scan(); line++ {
+		data := bytes.TrimSpace(scanner.Bytes())
+		if len(data) == 0 {
+			continue
+		}
+
+		stats, err := ParseStats(data)
+		if err != nil {
+			h.logger.Printf("Skipping history %s line %d: %v", h.path, line, err)
+			continue
+		}
+		if username != "" && !strings.EqualFold(stats.Username, username) {
+			continue
+		}
+
+		k := key{strings.ToLower(stats.Username), stats.GeneratedAt.UnixNano()}
+		if seen[k] {
+			continue
+		}
+		seen[k] = true
+		snapshots = append(snapshots, *stats)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("reading history: %w", err)
+	}
+
+	slices.SortStableFunc(snapshots, func(a, b Stats) int {
+		return a.GeneratedAt.Compare(b.GeneratedAt)
+	})
+	return snapshots, nil
+}
+
+// Previous returns the newest stored snapshot of the same user generated
+// before stats, or nil if there is none.
+func (h *History) Previous(stats *Stats) (*Stats, error) {
+	snapshots, err := h.Snapshots(stats.Username)
+	if err != nil {
+		return nil, err
+	}
+
+	for i := len(snapshots) - 1; i >= 0; i-- {
+		if snapshots[i].GeneratedAt.Before(stats.GeneratedAt) {
+			return &snapshots[i], nil
+		}
+	}
+	return nil, nil
+}
+
+// Trend summarizes snapshots (oldest first) and the growth between each
+// snapshot and the one before it. The first point has no growth.
+func Trend(snapshots []Stats) []TrendPoint {
+	points := make([]TrendPoint, len(snapshots))
+	for i := range snapshots {
+		snapshot := &snapshots[i]
+		point := TrendPoint{
+			GeneratedAt: snapshot.GeneratedAt,
+			Projects:    snapshot.Summary.TotalProjects,
+			PRsMerged:   snapshot.Summary.TotalPRsMerged,
+		}
+		for _, contrib := range snapshot.Contributions {
+			if !contrib.Incomplete {
+				point.Stars += contrib.Stars
+			}
+		}
+
+		if i > 0 {
+			diff := snapshots[i-1].Diff(snapshot)
+			point.ProjectsDelta = diff.Summary.Projects
+			point.NewPRs = diff.Summary.PRsMerged
+			for _, change := range diff.StarChanges {
+				point.StarsGained += change.Delta
+			}
+		}
+		points[i] = point
+	}
+	return points
+}
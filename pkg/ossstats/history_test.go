@@ -0,0 +1,152 @@
+package ossstats
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func snapshot(username string, generatedAt time.Time, prs int, contributions ...Contribution) *Stats {
+	return &Stats{
+		Username:      username,
+		GeneratedAt:   generatedAt,
+		Summary:       Summary{TotalProjects: len(contributions), TotalPRsMerged: prs},
+		Contributions: contributions,
+	}
+}
+
+func TestHistoryAppendAndSnapshots(t *testing.T) {
+	history := NewHistory(filepath.Join(t.TempDir(), "nested", "history.jsonl"))
+	day1 := time.Date(2025, 6, 1, 0, 0, 0, 0, time.UTC)
+	day2 := day1.AddDate(0, 0, 1)
+
+	// Missing file is an empty history
+	snapshots, err := history.Snapshots("octocat")
+	if err != nil || len(snapshots) != 0 {
+		t.Fatalf("Snapshots() on missing file = %v, %v, want none", snapshots, err)
+	}
+
+	// Appended out of order, and one duplicate
+	for _, stats := range []*Stats{
+		snapshot("octocat", day2, 5),
+		snapshot("octocat", day1, 3),
+		snapshot("hubot", day1, 1),
+		snapshot("octocat", day2, 5),
+	} {
+		if err := history.Append(stats); err != nil {
+			t.Fatalf("Append() error: %v", err)
+		}
+	}
+
+	snapshots, err = history.Snapshots("OctoCat")
+	if err != nil {
+		t.Fatalf("Snapshots() error: %v", err)
+	}
+	if len(snapshots) != 2 {
+		t.Fatalf("Snapshots() = %d snapshots, want 2", len(snapshots))
+	}
+	if !snapshots[0].GeneratedAt.Equal(day1) || !snapshots[1].GeneratedAt.Equal(day2) {
+		t.Errorf("Snapshots() not sorted oldest first: %v, %v", snapshots[0].GeneratedAt, snapshots[1].GeneratedAt)
+	}
+
+	all, err := history.Snapshots("")
+	if err != nil || len(all) != 3 {
+		t.Errorf("Snapshots(\"\") = %d snapshots, %v, want 3", len(all), err)
+	}
+}
+
+func TestHistoryPrevious(t *testing.T) {
+	history := NewHistory(filepath.Join(t.TempDir(), "history.jsonl"))
+	day1 := time.Date(2025, 6, 1, 0, 0, 0, 0, time.UTC)
+
+	for i := 0; i < 3; i++ {
+		if err := history.Append(snapshot("octocat", day1.AddDate(0, 0, i), i)); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	previous, err := history.Previous(snapshot("octocat", day1.AddDate(0, 0, 2), 2))
+	if err != nil {
+		t.Fatalf("Previous() error: %v", err)
+	}
+	if previous == nil || !previous.GeneratedAt.Equal(day1.AddDate(0, 0, 1)) {
+		t.Errorf("Previous() = %v, want the snapshot of day 2", previous)
+	}
+
+	previous, err = history.Previous(snapshot("octocat", day1, 0))
+	if err != nil || previous != nil {
+		t.Errorf("Previous() of the first snapshot = %v, %v, want nil", previous, err)
+	}
+}
+
+func TestHistoryCorruptLine(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "history.jsonl")
+	day1 := time.Date(2025, 6, 1, 0, 0, 0, 0, time.UTC)
+	day2 := day1.AddDate(0, 0, 1)
+
+	history := NewHistory(path)
+	logger := &mockLogger{}
+	history.SetLogger(logger)
+	if err := history.Append(snapshot("octocat", day1, 3)); err != nil {
+		t.Fatalf("Append() error: %v", err)
+	}
+
+	// A crash cut the last line short
+	f, err := os.OpenFile(path, os.O_APPEND|os.O_WRONLY, 0o644)
+	if err != nil {
+		t.Fatal(err)
+	}
+	f.WriteString(`{"username":"octocat","generatedAt":"2025-`)
+	f.Close()
+
+	snapshots, err := history.Snapshots("octocat")
+	if err != nil || len(snapshots) != 1 {
+		t.Fatalf("Snapshots() = %d snapshots, %v, want the intact one", len(snapshots), err)
+	}
+	if len(logger.messages) != 1 || !strings.Contains(logger.messages[0], "line %d") {
+		t.Errorf("Logged %q, want a warning with the line number", logger.messages)
+	}
+
+	// Later snapshots are still appended, on a line of their own
+	if err := history.Append(snapshot("octocat", day2, 5)); err != nil {
+		t.Fatalf("Append() after a truncated line error: %v", err)
+	}
+	snapshots, err = history.Snapshots("octocat")
+	if err != nil || len(snapshots) != 2 || !snapshots[1].GeneratedAt.Equal(day2) {
+		t.Errorf("Snapshots() after Append = %d snapshots, %v, want both intact ones", len(snapshots), err)
+	}
+}
+
+func TestDefaultHistoryPath(t *testing.T) {
+	t.Setenv("XDG_DATA_HOME", t.TempDir())
+	t.Setenv("LocalAppData", t.TempDir())
+
+	path, err := DefaultHistoryPath()
+	if err != nil {
+		t.Fatalf("DefaultHistoryPath() error: %v", err)
+	}
+	if filepath.Base(path) != "history.jsonl" || filepath.Base(filepath.Dir(path)) != "gh-oss-stats" {
+		t.Errorf("DefaultHistoryPath() = %s, want .../gh-oss-stats/history.jsonl", path)
+	}
+}
+
+func TestTrend(t *testing.T) {
+	older, newer := diffFixtures()
+
+	points := Trend([]Stats{*older, *newer})
+	if len(points) != 2 {
+		t.Fatalf("Trend() = %d points, want 2", len(points))
+	}
+
+	first := points[0]
+	if first.Projects != 3 || first.PRsMerged != 6 || first.Stars != 1055 || first.NewPRs != 0 || first.StarsGained != 0 {
+		t.Errorf("points[0] = %+v", first)
+	}
+
+	second := points[1]
+	if second.ProjectsDelta != 1 || second.NewPRs != 4 || second.StarsGained != 10 || second.Stars != 1963 {
+		t.Errorf("points[1] = %+v, want +1 project, +4 PRs, +10 stars gained, 1963 stars", second)
+	}
+}
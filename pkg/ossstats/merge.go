@@ -0,0 +1,176 @@
+package ossstats
+
+import (
+	"context"
+	"fmt"
+	"path/filepath"
+	"slices"
+	"strings"
+)
+
+// MergeStats merges the stats of several accounts of one person, e.g. a work
+// and a personal GitHub account, into a single profile.
+//
+// Contributions to the same repository are de-duplicated: PRs, commits and
+// lines are summed, the earliest first contribution and latest last
+// contribution are kept, and repository metadata comes from an account whose
+// lookup succeeded. The summary is recalculated. The merged stats take the
+// username of the first account, the latest GeneratedAt, and record every
+// merged account in Accounts. Dependency flags are kept, but the Dependencies
+// summary is not; call MarkDependencies on the merged stats to recount it.
+// Nil stats and repeated accounts are skipped.
+func MergeStats(stats ...*Stats) *Stats {
+	merged := &Stats{
+		SchemaVersion: StatsSchemaVersion,
+		Contributions: []Contribution{},
+	}
+
+	byRepo := make(map[string]int) // contributionKey -> index in merged.Contributions
+	seenAccounts := make(map[string]bool)
+	for _, s := range stats {
+		if s == nil {
+			continue
+		}
+
+		accounts := s.Accounts
+		if len(accounts) == 0 {
+			accounts = []string{s.Username}
+		}
+		if seenAccounts[strings.ToLower(s.Username)] {
+			continue
+		}
+		for _, account := range accounts {
+			seenAccounts[strings.ToLower(account)] = true
+		}
+		merged.Accounts = append(merged.Accounts, accounts...)
+
+		if merged.Username == "" {
+			merged.Username = s.Username
+		}
+		if s.GeneratedAt.After(merged.GeneratedAt) {
+			merged.GeneratedAt = s.GeneratedAt
+		}
+
+		for _, contrib := range s.Contributions {
+			key := contributionKey(contrib)
+			i, ok := byRepo[key]
+			if !ok {
+				byRepo[key] = len(merged.Contributions)
+				merged.Contributions = append(merged.Contributions, contrib)
+				continue
+			}
+			mergeContribution(&merged.Contributions[i], contrib)
+		}
+	}
+
+	slices.SortFunc(merged.Contributions, func(a, b Contribution) int {
+		return b.FirstContribution.Compare(a.FirstContribution)
+	})
+	merged.Summary = calculateSummary(merged.Contributions)
+
+	return merged
+}
+
+// mergeContribution adds other's activity to the same repository into c.
+func mergeContribution(c *Contribution, other Contribution) {
+	c.PRsMerged += other.PRsMerged
+	c.Commits += other.Commits
+	c.Additions += other.Additions
+	c.Deletions += other.Deletions
+	c.Dependency = c.Dependency || other.Dependency
+	if len(other.PullRequests) > 0 {
+		c.PullRequests = append(slices.Clone(c.PullRequests), other.PullRequests...)
+		sortPullRequests(c.PullRequests)
+	}
+
+	if c.FirstContribution.IsZero() || (!other.FirstContribution.IsZero() && other.FirstContribution.Before(c.FirstContribution)) {
+		c.FirstContribution = other.FirstContribution
+	}
+	if other.LastContribution.After(c.LastContribution) {
+		c.LastContribution = other.LastContribution
+	}
+
+	// Prefer metadata from a successful repository lookup
+	if c.Incomplete && !other.Incomplete {
+		c.Owner = other.Owner
+		c.RepoName = other.RepoName
+		c.Description = other.Description
+		c.RepoURL = other.RepoURL
+		c.Stars = other.Stars
+		c.Language = other.Language
+		c.Incomplete = false
+	} else if !other.Incomplete && other.Stars > c.Stars {
+		c.Stars = other.Stars
+	}
+}
+
+// GetMergedContributions runs GetContributions for each of a person's
+// accounts and merges the results with MergeStats.
+//
+// Accounts are fetched one after the other, each with its own timeout, and
+// PRs to the repositories of the other accounts are not counted. Other
+// forges are only queried with the first account, since their usernames
+// come from the provider configuration. With a checkpoint configured, each
+// account uses its own checkpoint file, named after the account. If any account returns partial results, the
+// merged stats are returned in an ErrPartialResults combining their errors.
+// Any other error stops the run and is returned as is.
+func (c *Client) GetMergedContributions(ctx context.Context, usernames []string) (*Stats, error) {
+	var accounts []string
+	seen := make(map[string]bool)
+	for _, username := range usernames {
+		username = strings.TrimSpace(username)
+		if username == "" || seen[strings.ToLower(username)] {
+			continue
+		}
+		seen[strings.ToLower(username)] = true
+		accounts = append(accounts, username)
+	}
+	if len(accounts) == 0 {
+		return nil, fmt.Errorf("no usernames given")
+	}
+	if len(accounts) == 1 {
+		return c.GetContributions(ctx, accounts[0])
+	}
+
+	var (
+		results  []*Stats
+		partial  ErrPartialResults
+		messages []string
+	)
+	for i, username := range accounts {
+		others := slices.Delete(slices.Clone(accounts), i, i+1)
+		stats, err := c.getContributions(ctx, username, runOptions{
+			checkpointPath: accountCheckpointPath(c.checkpointPath, username),
+			excludeUsers:   others,
+			githubOnly:     i > 0,
+		})
+		if partialErr, ok := err.(*ErrPartialResults); ok {
+			partial.Errors = append(partial.Errors, partialErr.Errors...)
+			partial.UnprocessedPRs += partialErr.UnprocessedPRs
+			partial.UnprocessedRepos += partialErr.UnprocessedRepos
+			messages = append(messages, fmt.Sprintf("%s: %s", username, partialErr.Message))
+			stats = partialErr.Stats
+		} else if err != nil {
+			return nil, err
+		}
+		results = append(results, stats)
+	}
+
+	merged := MergeStats(results...)
+	if len(partial.Errors) > 0 {
+		partial.Stats = merged
+		partial.Message = strings.Join(messages, "; ")
+		return merged, &partial
+	}
+	return merged, nil
+}
+
+// accountCheckpointPath returns the checkpoint file of one of several
+// accounts, e.g. "progress.octocat.json" for "progress.json".
+func accountCheckpointPath(path, username string) string {
+	if path == "" {
+		return ""
+	}
+	ext := filepath.Ext(path)
+	return strings.TrimSuffix(path, ext) + "." + username + ext
+}
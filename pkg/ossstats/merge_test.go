@@ -0,0 +1,190 @@
+package ossstats
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestMergeStats(t *testing.T) {
+	jan := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	mar := time.Date(2025, 3, 1, 0, 0, 0, 0, time.UTC)
+	jun := time.Date(2025, 6, 1, 0, 0, 0, 0, time.UTC)
+
+	personal := &Stats{
+		Username:    "octocat",
+		GeneratedAt: jun,
+		Contributions: []Contribution{
+			{Repo: "golang/go", PRsMerged: 2, Commits: 3, Additions: 10, Deletions: 1, Stars: 100, FirstContribution: mar, LastContribution: mar},
+			{Repo: "acme/app", PRsMerged: 1, Stars: 5, FirstContribution: jan, LastContribution: jan},
+		},
+	}
+	work := &Stats{
+		Username:    "octocat-work",
+		GeneratedAt: mar,
+		Contributions: []Contribution{
+			{Repo: "Golang/Go", PRsMerged: 3, Commits: 4, Additions: 20, Deletions: 2, Incomplete: true, FirstContribution: jan, LastContribution: jun},
+			{Repo: "kubernetes/kubernetes", PRsMerged: 1, Stars: 1000, FirstContribution: jun, LastContribution: jun},
+		},
+	}
+
+	merged := MergeStats(personal, nil, work, personal)
+
+	if merged.Username != "octocat" || !merged.GeneratedAt.Equal(jun) || merged.SchemaVersion != StatsSchemaVersion {
+		t.Errorf("Header = %s %v v%d, want octocat, %v, v%d", merged.Username, merged.GeneratedAt, merged.SchemaVersion, jun, StatsSchemaVersion)
+	}
+	if len(merged.Accounts) != 2 || merged.Accounts[0] != "octocat" || merged.Accounts[1] != "octocat-work" {
+		t.Errorf("Accounts = %v, want [octocat octocat-work]", merged.Accounts)
+	}
+
+	wantSummary := Summary{TotalProjects: 3, TotalPRsMerged: 7, TotalCommits: 7, TotalAdditions: 30, TotalDeletions: 3}
+	if merged.Summary != wantSummary {
+		t.Errorf("Summary = %+v, want %+v", merged.Summary, wantSummary)
+	}
+
+	var golang *Contribution
+	for i := range merged.Contributions {
+		if merged.Contributions[i].Repo == "golang/go" {
+			golang = &merged.Contributions[i]
+		}
+	}
+	if golang == nil {
+		t.Fatalf("Contributions = %+v, want golang/go merged", merged.Contributions)
+	}
+	if golang.PRsMerged != 5 || golang.Commits != 7 || golang.Stars != 100 || golang.Incomplete {
+		t.Errorf("golang/go = %+v, want 5 PRs, 7 commits, 100 stars, complete", golang)
+	}
+	if !golang.FirstContribution.Equal(jan) || !golang.LastContribution.Equal(jun) {
+		t.Errorf("golang/go dates = %v - %v, want %v - %v", golang.FirstContribution, golang.LastContribution, jan, jun)
+	}
+
+	if merged.Contributions[0].Repo != "kubernetes/kubernetes" {
+		t.Errorf("Contributions[0] = %s, want the most recent first contribution", merged.Contributions[0].Repo)
+	}
+}
+
+func TestMergeStatsIncompleteMetadata(t *testing.T) {
+	a := &Stats{Username: "a", Contributions: []Contribution{{Repo: "x/y", PRsMerged: 1, Incomplete: true}}}
+	b := &Stats{Username: "b", Contributions: []Contribution{{Repo: "x/y", PRsMerged: 1, Stars: 42, RepoURL: "https://github.com/x/y"}}}
+
+	merged := MergeStats(a, b)
+	contrib := merged.Contributions[0]
+	if contrib.Incomplete || contrib.Stars != 42 || contrib.RepoURL != "https://github.com/x/y" {
+		t.Errorf("Contribution = %+v, want metadata from the complete lookup", contrib)
+	}
+
+	if merged := MergeStats(a); !merged.Contributions[0].Incomplete {
+		t.Error("Incomplete = false, want true when every lookup failed")
+	}
+}
+
+func TestGetMergedContributions(t *testing.T) {
+	scenario := `{
+		"users": {
+			"octocat": [
+				{"repo": "golang/go", "number": 1, "merged_at": "2025-03-04T12:00:00Z", "additions": 5},
+				{"repo": "acme/app", "number": 2, "merged_at": "2025-03-05T12:00:00Z", "additions": 1}
+			],
+			"octocat-work": [
+				{"repo": "golang/go", "number": 3, "merged_at": "2025-04-04T12:00:00Z", "additions": 7},
+				{"repo": "octocat/dotfiles", "number": 4, "merged_at": "2025-04-05T12:00:00Z", "additions": 3}
+			]
+		},
+		"repositories": [
+			{"full_name": "golang/go", "stargazers_count": 100},
+			{"full_name": "acme/app", "stargazers_count": 5},
+			{"full_name": "octocat/dotfiles", "stargazers_count": 1}
+		]
+	}`
+	path := filepath.Join(t.TempDir(), "scenario.json")
+	if err := os.WriteFile(path, []byte(scenario), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	checkpointPath := filepath.Join(t.TempDir(), "progress.json")
+	client := New(WithLOC(true), WithDebugScenario(path), WithCheckpoint(checkpointPath))
+
+	stats, err := client.GetMergedContributions(context.Background(), []string{"octocat", "octocat-work", "OCTOCAT"})
+	if err != nil {
+		t.Fatalf("GetMergedContributions() error: %v", err)
+	}
+
+	if stats.Username != "octocat" || len(stats.Accounts) != 2 {
+		t.Errorf("Username = %s, Accounts = %v, want octocat with 2 accounts", stats.Username, stats.Accounts)
+	}
+	if stats.Summary.TotalProjects != 2 || stats.Summary.TotalPRsMerged != 3 || stats.Summary.TotalAdditions != 13 {
+		t.Errorf("Summary = %+v, want 2 projects, 3 PRs, 13 additions (no PR to another account's repository)", stats.Summary)
+	}
+}
+
+func TestGetMergedContributionsWithGitLab(t *testing.T) {
+	scenario := `{
+		"users": {
+			"a": [{"repo": "x/y", "number": 1, "merged_at": "2025-03-04T12:00:00Z"}],
+			"b": [{"repo": "x/y", "number": 2, "merged_at": "2025-03-05T12:00:00Z"}]
+		},
+		"repositories": [{"full_name": "x/y", "stargazers_count": 3}]
+	}`
+	path := filepath.Join(t.TempDir(), "scenario.json")
+	if err := os.WriteFile(path, []byte(scenario), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	server := newGitLabServer(t)
+
+	client := New(
+		WithDebugScenario(path),
+		WithGitLab(GitLabConfig{BaseURL: server.URL, Username: "octocat", HTTPClient: server.Client()}),
+	)
+	stats, err := client.GetMergedContributions(context.Background(), []string{"a", "b"})
+	if err != nil {
+		t.Fatalf("GetMergedContributions() error: %v", err)
+	}
+
+	byRepo := make(map[string]Contribution)
+	for _, contrib := range stats.Contributions {
+		byRepo[contrib.Repo] = contrib
+	}
+	// The GitLab account is fetched once, not once per merged account
+	if byRepo["GNOME/gtk"].PRsMerged != 2 || byRepo["group/sub/tool"].PRsMerged != 1 || byRepo["x/y"].PRsMerged != 2 {
+		t.Errorf("Contributions = %+v, want 2 MRs to GNOME/gtk, 1 to group/sub/tool and 2 PRs to x/y", stats.Contributions)
+	}
+}
+
+func TestGetMergedContributionsPartial(t *testing.T) {
+	scenario := `{
+		"users": {
+			"a": [{"repo": "x/y", "number": 1, "merged_at": "2025-03-04T12:00:00Z"}],
+			"b": [{"repo": "x/z", "number": 2, "merged_at": "2025-03-04T12:00:00Z"}]
+		},
+		"repositories": [{"full_name": "x/y"}],
+		"failures": [{"call": "GetRepository", "target": "x/z", "status": 500, "times": 10}]
+	}`
+	path := filepath.Join(t.TempDir(), "scenario.json")
+	if err := os.WriteFile(path, []byte(scenario), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	_, err := New(WithDebugScenario(path)).GetMergedContributions(context.Background(), []string{"a", "b"})
+	partialErr, ok := err.(*ErrPartialResults)
+	if !ok {
+		t.Fatalf("Expected *ErrPartialResults, got %T (%v)", err, err)
+	}
+	if partialErr.Stats.Summary.TotalProjects != 2 || len(partialErr.Stats.Accounts) != 2 {
+		t.Errorf("Stats = %+v, want both accounts merged", partialErr.Stats)
+	}
+}
+
+func TestAccountCheckpointPath(t *testing.T) {
+	tests := []struct{ path, want string }{
+		{"progress.json", "progress.octocat.json"},
+		{"dir/progress", "dir/progress.octocat"},
+		{"", ""},
+	}
+	for _, tt := range tests {
+		if got := accountCheckpointPath(tt.path, "octocat"); got != tt.want {
+			t.Errorf("accountCheckpointPath(%q) = %q, want %q", tt.path, got, tt.want)
+		}
+	}
+}
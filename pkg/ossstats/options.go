@@ -17,6 +17,32 @@ func WithToken(token string) Option {
 	}
 }
 
+// WithTokens spreads requests across several GitHub tokens.
+// Each request uses the token with the most remaining rate limit budget, and
+// a request rejected because one token's limit is exhausted is retried with
+// the next. Combined with WithToken if both are set. Use Client.TokenUsage to
+// report per-token usage after a run.
+func WithTokens(tokens []string) Option {
+	return func(c *Client) {
+		c.tokens = tokens
+	}
+}
+
+// WithGitHubApp authenticates as a GitHub App installation instead of a
+// personal access token. privateKey is the App's private key in PEM format.
+// The client mints a JWT, exchanges it for an installation access token and
+// refreshes the token before it expires. Takes precedence over WithToken
+// and WithTokens.
+func WithGitHubApp(appID, installationID int64, privateKey []byte) Option {
+	return func(c *Client) {
+		c.app = &appConfig{
+			appID:          appID,
+			installationID: installationID,
+			privateKey:     privateKey,
+		}
+	}
+}
+
 // WithLOC enables or disables fetching lines of code metrics (additions/deletions).
 // Default: false
 func WithLOC(enabled bool) Option {
@@ -26,7 +52,8 @@ func WithLOC(enabled bool) Option {
 }
 
 // WithPRDetails enables or disables including detailed PR information.
-// When enabled, includes a list of individual PR details for each contribution.
+// When enabled, includes a list of individual PR details for each contribution,
+// on GitHub and on the providers added with WithGitLab and WithGitea.
 // Default: false
 func WithPRDetails(enabled bool) Option {
 	return func(c *Client) {
@@ -43,6 +70,16 @@ func WithMinStars(stars int) Option {
 	}
 }
 
+// WithUnknownStarsPolicy sets how WithMinStars treats contributions whose
+// repository lookup failed, leaving the star count unknown. Such
+// contributions are marked Incomplete either way.
+// Default: UnknownStarsExclude
+func WithUnknownStarsPolicy(policy UnknownStarsPolicy) Option {
+	return func(c *Client) {
+		c.unknownStars = policy
+	}
+}
+
 // WithMaxPRs limits the maximum number of PRs to fetch.
 // Useful for large contributors to avoid excessive API calls.
 // Default: 500
@@ -86,6 +123,42 @@ func WithHTTPClient(httpClient *http.Client) Option {
 	}
 }
 
+// WithBaseURL sets the GitHub REST API base URL.
+// Use it for GitHub Enterprise Server, e.g. "https://ghe.example.com/api/v3".
+// Default: https://api.github.com
+func WithBaseURL(baseURL string) Option {
+	return func(c *Client) {
+		c.baseURL = baseURL
+	}
+}
+
+// WithProvider adds a forge to query after GitHub, such as a GitLab or
+// Gitea instance. Its contributions are tagged with their forge and host and
+// counted alongside the GitHub ones. Can be given several times.
+func WithProvider(p Provider) Option {
+	return func(c *Client) {
+		c.providers = append(c.providers, p)
+	}
+}
+
+// WithGitLab adds a GitLab instance to query after GitHub.
+// It is a shorthand for WithProvider(NewGitLabProvider(cfg)); an empty
+// cfg.HTTPClient uses the client's HTTP client.
+func WithGitLab(cfg GitLabConfig) Option {
+	return func(c *Client) {
+		c.providers = append(c.providers, &gitlabProvider{cfg: cfg, client: c})
+	}
+}
+
+// WithGitea adds a Gitea, Forgejo or Codeberg instance to query after
+// GitHub. It is a shorthand for WithProvider(NewGiteaProvider(cfg)); an
+// empty cfg.HTTPClient uses the client's HTTP client.
+func WithGitea(cfg GiteaConfig) Option {
+	return func(c *Client) {
+		c.providers = append(c.providers, &giteaProvider{cfg: cfg, client: c})
+	}
+}
+
 // WithVerbose enables verbose logging to the default logger.
 // This is a convenience option that sets up a standard logger.
 func WithVerbose() Option {
@@ -101,3 +174,44 @@ func WithDebug(debug bool) Option {
 		c.debug = debug
 	}
 }
+
+// WithDebugScenario enables debug mode with a mock API client that serves the
+// scenario in the given JSON fixture file: users, PRs, repositories and
+// injected failures such as 403/429/500 responses, latency and incomplete
+// search results.
+// Default: "" (built-in mock data when debug mode is enabled)
+func WithDebugScenario(path string) Option {
+	return func(c *Client) {
+		c.debugScenario = path
+	}
+}
+
+// WithCheckpoint persists search results and completed PR/repository lookups
+// to the given file as the run progresses. A later run with the same file,
+// username and filters skips the work already done. The file is removed once
+// a run completes without errors.
+// Default: "" (disabled)
+func WithCheckpoint(path string) Option {
+	return func(c *Client) {
+		c.checkpointPath = path
+	}
+}
+
+// WithRecord captures every GitHub API request and response made by the
+// client into dir, one JSON file per exchange, for offline reproduction with
+// WithReplay. Tokens are scrubbed from the recordings.
+// Default: "" (disabled)
+func WithRecord(dir string) Option {
+	return func(c *Client) {
+		c.recordDir = dir
+	}
+}
+
+// WithReplay serves GitHub API responses from recordings made with
+// WithRecord instead of calling GitHub. No token or network is needed.
+// Default: "" (disabled)
+func WithReplay(dir string) Option {
+	return func(c *Client) {
+		c.replayDir = dir
+	}
+}
@@ -19,6 +19,30 @@ func TestWithToken(t *testing.T) {
 	}
 }
 
+func TestWithTokens(t *testing.T) {
+	client := New(
+		WithToken("token-a"),
+		WithTokens([]string{"token-b", "token-c"}),
+	)
+
+	if len(client.tokens) != 2 {
+		t.Errorf("tokens length = %d, want 2", len(client.tokens))
+	}
+
+	usage := client.TokenUsage()
+	if len(usage) != 3 {
+		t.Errorf("TokenUsage() length = %d, want 3 (token + tokens)", len(usage))
+	}
+}
+
+func TestTokenUsageWithoutPool(t *testing.T) {
+	client := New(WithToken("token-a"))
+
+	if usage := client.TokenUsage(); usage != nil {
+		t.Errorf("TokenUsage() = %v, want nil without WithTokens", usage)
+	}
+}
+
 func TestWithLOC(t *testing.T) {
 	tests := []struct {
 		name    string
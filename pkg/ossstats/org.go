@@ -0,0 +1,268 @@
+package ossstats
+
+import (
+	"cmp"
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/mabd-dev/gh-oss-stats/internal/github"
+)
+
+// OrgReport lists the upstream projects the members of an organization
+// contribute to, with each project's contributing members.
+type OrgReport struct {
+	Org         string       `json:"org"`
+	GeneratedAt time.Time    `json:"generatedAt"`
+	Summary     OrgSummary   `json:"summary"`
+	Projects    []OrgProject `json:"projects"`
+
+	// Members lists the members whose contributions were collected
+	Members []string `json:"members"`
+}
+
+// OrgSummary contains aggregate statistics of an OrgReport.
+type OrgSummary struct {
+	TotalProjects       int `json:"totalProjects"`
+	TotalPRsMerged      int `json:"totalPRsMerged"`
+	TotalMembers        int `json:"totalMembers"`
+	ContributingMembers int `json:"contributingMembers"` // Members with at least one contribution
+}
+
+// OrgProject is an upstream project with the members contributing to it.
+type OrgProject struct {
+	Repo              string           `json:"repo"`
+	Description       string           `json:"description"`
+	RepoURL           string           `json:"repoURL"`
+	Stars             int              `json:"stars"`
+	PRsMerged         int              `json:"prsMerged"`         // Merged PRs of all members
+	FirstContribution time.Time        `json:"firstContribution"` // First PR merged by any member
+	LastContribution  time.Time        `json:"lastContribution"`  // Most recent PR merged by any member
+	Contributors      []OrgContributor `json:"contributors"`
+
+	// Incomplete is set when the repository metadata could not be fetched
+	Incomplete bool `json:"incomplete,omitempty"`
+}
+
+// OrgContributor is a member's contribution to an OrgProject.
+type OrgContributor struct {
+	Username          string    `json:"username"`
+	PRsMerged         int       `json:"prsMerged"`
+	FirstContribution time.Time `json:"firstContribution"`
+	LastContribution  time.Time `json:"lastContribution"`
+}
+
+// GetOrgMemberContributions lists the members of a GitHub organization and
+// collects each member's contributions to repositories outside the
+// organization, grouped into a report by upstream project.
+//
+// GitHub lists every member when the token belongs to a member of the
+// organization, and only the public members otherwise. Members are fetched
+// one after the other on GitHub only, each with its own timeout and, with a
+// checkpoint configured, its own checkpoint file. A member that fails, e.g.
+// because they were renamed or the rate limit ran out, is left out of the
+// report. If any member fails or returns partial results, the report is
+// returned with an ErrPartialResults combining their errors; its Stats
+// field is nil. Only a failure to list the members aborts the run.
+func (c *Client) GetOrgMemberContributions(ctx context.Context, org string) (*OrgReport, error) {
+	org = strings.TrimSpace(org)
+	if org == "" {
+		return nil, errors.New("organization cannot be empty")
+	}
+
+	members, err := c.listOrgMembers(ctx, org)
+	if err != nil {
+		return nil, err
+	}
+	c.logger.Printf("Found %d members of %s", len(members), org)
+
+	var (
+		results  []*Stats
+		partial  ErrPartialResults
+		messages []string
+	)
+	for i, member := range members {
+		c.logger.Printf("Fetching member %d/%d: %s", i+1, len(members), member)
+		stats, err := c.getContributions(ctx, member, runOptions{
+			checkpointPath: accountCheckpointPath(c.checkpointPath, member),
+			excludeOrgs:    []string{org},
+			githubOnly:     true,
+		})
+		if partialErr, ok := err.(*ErrPartialResults); ok {
+			partial.Errors = append(partial.Errors, partialErr.Errors...)
+			partial.UnprocessedPRs += partialErr.UnprocessedPRs
+			partial.UnprocessedRepos += partialErr.UnprocessedRepos
+			messages = append(messages, fmt.Sprintf("%s: %s", member, partialErr.Message))
+			stats = partialErr.Stats
+		} else if err != nil {
+			c.logger.Printf("Failed to fetch member %s: %v", member, err)
+			partial.Errors = append(partial.Errors, fmt.Errorf("%s: %w", member, err))
+			messages = append(messages, fmt.Sprintf("%s: %v", member, err))
+			continue
+		}
+		results = append(results, stats)
+	}
+
+	report := NewOrgReport(org, results...)
+	if len(partial.Errors) > 0 {
+		partial.Message = strings.Join(messages, "; ")
+		return report, &partial
+	}
+	return report, nil
+}
+
+// listOrgMembers returns the logins of an organization's members.
+func (c *Client) listOrgMembers(ctx context.Context, org string) ([]string, error) {
+	ctx, cancel := context.WithTimeout(ctx, c.timeout)
+	defer cancel()
+
+	apiClient, err := c.newAPIClient()
+	if err != nil {
+		return nil, err
+	}
+
+	const perPage = 100
+	var members []string
+	for page := 1; ; page++ {
+		var users []github.User
+		resp, err := retrySecondaryRateLimit(ctx, func() (*http.Response, error) {
+			var resp *http.Response
+			var err error
+			users, resp, err = apiClient.ListOrgMembers(ctx, org, page, perPage)
+			return resp, err
+		})
+		if err != nil {
+			if typedErr := lookupError(resp, err, "members of "+org, &ErrOrgNotFound{Org: org}); typedErr != nil {
+				return nil, typedErr
+			}
+			return nil, fmt.Errorf("listing members of %s: %w", org, err)
+		}
+
+		for _, user := range users {
+			members = append(members, user.Login)
+		}
+		if len(users) < perPage {
+			return members, nil
+		}
+	}
+}
+
+// NewOrgReport groups the contributions of an organization's members by
+// upstream project. Projects are ordered by number of contributing members,
+// then by merged PRs; contributors by merged PRs. Nil stats are skipped.
+func NewOrgReport(org string, members ...*Stats) *OrgReport {
+	report := &OrgReport{
+		Org:      org,
+		Members:  []string{},
+		Projects: []OrgProject{},
+	}
+
+	byRepo := make(map[string]int) // contributionKey -> index in report.Projects
+	for _, stats := range members {
+		if stats == nil {
+			continue
+		}
+		report.Members = append(report.Members, stats.Username)
+		if stats.GeneratedAt.After(report.GeneratedAt) {
+			report.GeneratedAt = stats.GeneratedAt
+		}
+		if len(stats.Contributions) > 0 {
+			report.Summary.ContributingMembers++
+		}
+
+		for _, contrib := range stats.Contributions {
+			key := contributionKey(contrib)
+			i, ok := byRepo[key]
+			if !ok {
+				i = len(report.Projects)
+				byRepo[key] = i
+				report.Projects = append(report.Projects, OrgProject{
+					Repo:              contrib.Repo,
+					Description:       contrib.Description,
+					RepoURL:           contrib.RepoURL,
+					Stars:             contrib.Stars,
+					FirstContribution: contrib.FirstContribution,
+					LastContribution:  contrib.LastContribution,
+					Incomplete:        contrib.Incomplete,
+				})
+			}
+
+			project := &report.Projects[i]
+			if project.Incomplete && !contrib.Incomplete {
+				project.Description = contrib.Description
+				project.RepoURL = contrib.RepoURL
+				project.Stars = contrib.Stars
+				project.Incomplete = false
+			}
+			project.PRsMerged += contrib.PRsMerged
+			if contrib.FirstContribution.Before(project.FirstContribution) {
+				project.FirstContribution = contrib.FirstContribution
+			}
+			if contrib.LastContribution.After(project.LastContribution) {
+				project.LastContribution = contrib.LastContribution
+			}
+			project.Contributors = append(project.Contributors, OrgContributor{
+				Username:          stats.Username,
+				PRsMerged:         contrib.PRsMerged,
+				FirstContribution: contrib.FirstContribution,
+				LastContribution:  contrib.LastContribution,
+			})
+		}
+	}
+
+	for i := range report.Projects {
+		project := &report.Projects[i]
+		slices.SortFunc(project.Contributors, func(a, b OrgContributor) int {
+			return cmp.Or(cmp.Compare(b.PRsMerged, a.PRsMerged), cmp.Compare(a.Username, b.Username))
+		})
+		report.Summary.TotalPRsMerged += project.PRsMerged
+	}
+	slices.SortFunc(report.Projects, func(a, b OrgProject) int {
+		return cmp.Or(
+			cmp.Compare(len(b.Contributors), len(a.Contributors)),
+			cmp.Compare(b.PRsMerged, a.PRsMerged),
+			cmp.Compare(a.Repo, b.Repo),
+		)
+	})
+
+	report.Summary.TotalProjects = len(report.Projects)
+	report.Summary.TotalMembers = len(report.Members)
+	return report
+}
+
+// Markdown renders the report as a Markdown document with one table row
+// per upstream project.
+func (r *OrgReport) Markdown() string {
+	var b strings.Builder
+
+	fmt.Fprintf(&b, "## Upstream contributions by %s members\n\n", r.Org)
+	fmt.Fprintf(&b, "_Generated %s_\n\n", formatDate(r.GeneratedAt))
+	fmt.Fprintf(&b, "%d projects, %d merged PRs, %d of %d members contributing.\n",
+		r.Summary.TotalProjects, r.Summary.TotalPRsMerged, r.Summary.ContributingMembers, r.Summary.TotalMembers)
+	if len(r.Projects) == 0 {
+		return b.String()
+	}
+
+	b.WriteString("\n| Project | Stars | PRs | Members | First | Last |\n")
+	b.WriteString("|---------|-------|-----|---------|-------|------|\n")
+	for _, project := range r.Projects {
+		contributors := make([]string, len(project.Contributors))
+		for i, contributor := range project.Contributors {
+			contributors[i] = fmt.Sprintf("%s (%d)", contributor.Username, contributor.PRsMerged)
+		}
+
+		stars := fmt.Sprintf("%d", project.Stars)
+		if project.Incomplete {
+			stars = "?"
+		}
+		fmt.Fprintf(&b, "| %s | %s | %d | %s | %s | %s |\n",
+			markdownRepoLink(project.Repo, project.RepoURL), stars, project.PRsMerged,
+			strings.Join(contributors, ", "), formatDate(project.FirstContribution), formatDate(project.LastContribution))
+	}
+
+	return b.String()
+}
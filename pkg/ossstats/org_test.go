@@ -0,0 +1,239 @@
+package ossstats
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/mabd-dev/gh-oss-stats/internal/github"
+)
+
+func TestNewOrgReport(t *testing.T) {
+	jan := time.Date(2025, 1, 10, 0, 0, 0, 0, time.UTC)
+	mar := time.Date(2025, 3, 10, 0, 0, 0, 0, time.UTC)
+	may := time.Date(2025, 5, 10, 0, 0, 0, 0, time.UTC)
+
+	alice := &Stats{
+		Username:    "alice",
+		GeneratedAt: mar,
+		Contributions: []Contribution{
+			{Repo: "golang/go", Host: "github.com", PRsMerged: 3, Stars: 100, FirstContribution: mar, LastContribution: may},
+			{Repo: "rust-lang/rust", Host: "github.com", PRsMerged: 6, Incomplete: true, FirstContribution: jan, LastContribution: jan},
+		},
+	}
+	bob := &Stats{
+		Username:    "bob",
+		GeneratedAt: may,
+		Contributions: []Contribution{
+			{Repo: "Golang/Go", Host: "github.com", PRsMerged: 3, Stars: 120, FirstContribution: jan, LastContribution: mar},
+			{Repo: "rust-lang/rust", Host: "github.com", PRsMerged: 1, Stars: 90, FirstContribution: may, LastContribution: may},
+		},
+	}
+	carol := &Stats{Username: "carol", GeneratedAt: jan, Contributions: []Contribution{}}
+
+	report := NewOrgReport("acme", alice, nil, bob, carol)
+
+	if report.Org != "acme" || !report.GeneratedAt.Equal(may) {
+		t.Errorf("Org/GeneratedAt = %s/%v, want acme/%v", report.Org, report.GeneratedAt, may)
+	}
+	wantSummary := OrgSummary{TotalProjects: 2, TotalPRsMerged: 13, TotalMembers: 3, ContributingMembers: 2}
+	if report.Summary != wantSummary {
+		t.Errorf("Summary = %+v, want %+v", report.Summary, wantSummary)
+	}
+	if len(report.Projects) != 2 {
+		t.Fatalf("Projects = %+v, want 2", report.Projects)
+	}
+
+	// Both projects have two members; rust-lang/rust has more PRs
+	rust, golang := report.Projects[0], report.Projects[1]
+	if rust.Repo != "rust-lang/rust" || golang.Repo != "golang/go" {
+		t.Fatalf("Projects order = %s, %s, want rust-lang/rust, golang/go", rust.Repo, golang.Repo)
+	}
+	if rust.Incomplete || rust.Stars != 90 {
+		t.Errorf("rust-lang/rust = %+v, want metadata taken from the complete contribution", rust)
+	}
+	if rust.Contributors[0].Username != "alice" || rust.Contributors[1].Username != "bob" {
+		t.Errorf("rust-lang/rust contributors = %+v, want alice before bob", rust.Contributors)
+	}
+	if golang.PRsMerged != 6 || !golang.FirstContribution.Equal(jan) || !golang.LastContribution.Equal(may) {
+		t.Errorf("golang/go = %+v, want 6 PRs from %v to %v", golang, jan, may)
+	}
+	// Ties on PRs are ordered by username
+	if golang.Contributors[0].Username != "alice" || golang.Contributors[1].Username != "bob" {
+		t.Errorf("golang/go contributors = %+v, want alice before bob", golang.Contributors)
+	}
+}
+
+func TestOrgReportMarkdown(t *testing.T) {
+	report := NewOrgReport("acme",
+		&Stats{
+			Username:    "alice",
+			GeneratedAt: time.Date(2025, 6, 1, 0, 0, 0, 0, time.UTC),
+			Contributions: []Contribution{
+				{Repo: "golang/go", RepoURL: "https://github.com/golang/go", PRsMerged: 2, Stars: 100},
+				{Repo: "rust-lang/rust", PRsMerged: 1, Incomplete: true},
+			},
+		},
+		&Stats{Username: "bob"},
+	)
+
+	got := report.Markdown()
+	for _, want := range []string{
+		"## Upstream contributions by acme members",
+		"_Generated 2025-06-01_",
+		"2 projects, 3 merged PRs, 1 of 2 members contributing.",
+		"| [golang/go](https://github.com/golang/go) | 100 | 2 | alice (2) |",
+		"| rust-lang/rust | ? | 1 | alice (1) |",
+	} {
+		if !strings.Contains(got, want) {
+			t.Errorf("Markdown() missing %q:\n%s", want, got)
+		}
+	}
+
+	empty := NewOrgReport("acme").Markdown()
+	if strings.Contains(empty, "| Project |") {
+		t.Errorf("Markdown() of an empty report has a table:\n%s", empty)
+	}
+}
+
+func TestGetOrgMemberContributions(t *testing.T) {
+	mergedAt := time.Date(2025, 2, 1, 12, 0, 0, 0, time.UTC)
+	var queries []string
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch {
+		case r.URL.Path == "/orgs/acme/members":
+			json.NewEncoder(w).Encode([]github.User{{Login: "alice"}, {Login: "bob"}})
+		case strings.HasPrefix(r.URL.Path, "/search/issues"):
+			query := r.URL.Query().Get("q")
+			queries = append(queries, query)
+			var items []github.Issue
+			if strings.Contains(query, "author:alice") {
+				items = append(items, github.Issue{
+					Number:        1,
+					RepositoryURL: "https://api.github.com/repos/golang/go",
+					PullRequest:   &github.PullRequestRef{MergedAt: &mergedAt},
+				})
+			}
+			json.NewEncoder(w).Encode(github.SearchIssuesResponse{TotalCount: len(items), Items: items})
+		case r.URL.Path == "/repos/golang/go":
+			json.NewEncoder(w).Encode(github.Repository{FullName: "golang/go", StargazersCount: 5, HTMLURL: "https://github.com/golang/go"})
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer server.Close()
+
+	client := New(WithToken("test-token"))
+	client.httpClient.Transport = &mockTransport{server: server}
+
+	report, err := client.GetOrgMemberContributions(context.Background(), "acme")
+	if err != nil {
+		t.Fatalf("GetOrgMemberContributions() error = %v", err)
+	}
+
+	if len(queries) != 2 {
+		t.Fatalf("Search queries = %v, want one per member", queries)
+	}
+	for _, query := range queries {
+		if !strings.Contains(query, "-org:acme") {
+			t.Errorf("Search query %q does not exclude the organization", query)
+		}
+	}
+
+	if strings.Join(report.Members, ",") != "alice,bob" {
+		t.Errorf("Members = %v, want [alice bob]", report.Members)
+	}
+	if len(report.Projects) != 1 || report.Projects[0].Repo != "golang/go" || report.Projects[0].Stars != 5 {
+		t.Fatalf("Projects = %+v, want golang/go", report.Projects)
+	}
+	if contributors := report.Projects[0].Contributors; len(contributors) != 1 || contributors[0].Username != "alice" {
+		t.Errorf("Contributors = %+v, want alice", contributors)
+	}
+	if report.Summary.ContributingMembers != 1 || report.Summary.TotalMembers != 2 {
+		t.Errorf("Summary = %+v, want 1 of 2 members contributing", report.Summary)
+	}
+}
+
+func TestGetOrgMemberContributionsMemberFails(t *testing.T) {
+	mergedAt := time.Date(2025, 2, 1, 12, 0, 0, 0, time.UTC)
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch {
+		case r.URL.Path == "/orgs/acme/members":
+			json.NewEncoder(w).Encode([]github.User{{Login: "alice"}, {Login: "bob"}})
+		case strings.HasPrefix(r.URL.Path, "/search/issues"):
+			// bob was renamed after the member listing
+			if strings.Contains(r.URL.Query().Get("q"), "author:bob") {
+				http.NotFound(w, r)
+				return
+			}
+			json.NewEncoder(w).Encode(github.SearchIssuesResponse{TotalCount: 1, Items: []github.Issue{{
+				Number:        1,
+				RepositoryURL: "https://api.github.com/repos/golang/go",
+				PullRequest:   &github.PullRequestRef{MergedAt: &mergedAt},
+			}}})
+		case r.URL.Path == "/repos/golang/go":
+			json.NewEncoder(w).Encode(github.Repository{FullName: "golang/go", StargazersCount: 5})
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer server.Close()
+
+	client := New(WithToken("test-token"))
+	client.httpClient.Transport = &mockTransport{server: server}
+
+	report, err := client.GetOrgMemberContributions(context.Background(), "acme")
+	var partialErr *ErrPartialResults
+	if !errors.As(err, &partialErr) {
+		t.Fatalf("GetOrgMemberContributions() error = %v, want ErrPartialResults", err)
+	}
+	var notFound *ErrNotFound
+	if len(partialErr.Errors) != 1 || !errors.As(partialErr.Errors[0], &notFound) || !strings.Contains(partialErr.Message, "bob") {
+		t.Errorf("ErrPartialResults = %+v, want bob's ErrNotFound", partialErr)
+	}
+	if report == nil || len(report.Projects) != 1 || strings.Join(report.Members, ",") != "alice" {
+		t.Errorf("Report = %+v, want alice's golang/go contribution", report)
+	}
+}
+
+func TestGetOrgMemberContributionsErrors(t *testing.T) {
+	tests := []struct {
+		name    string
+		status  int
+		wantErr func(error) bool
+	}{
+		{"unknown organization", http.StatusNotFound, func(err error) bool {
+			var notFound *ErrOrgNotFound
+			return errors.As(err, &notFound) && notFound.Org == "acme"
+		}},
+		{"invalid token", http.StatusUnauthorized, func(err error) bool {
+			var authErr *ErrAuthentication
+			return errors.As(err, &authErr)
+		}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.WriteHeader(tt.status)
+			}))
+			defer server.Close()
+
+			client := New(WithToken("test-token"))
+			client.httpClient.Transport = &mockTransport{server: server}
+
+			if _, err := client.GetOrgMemberContributions(context.Background(), "acme"); !tt.wantErr(err) {
+				t.Errorf("GetOrgMemberContributions() error = %v", err)
+			}
+		})
+	}
+}
@@ -0,0 +1,105 @@
+package output
+
+import (
+	"encoding/csv"
+	"io"
+	"strconv"
+	"time"
+
+	"github.com/mabd-dev/gh-oss-stats/pkg/ossstats"
+)
+
+func init() {
+	Register("csv", delimited(',', contributionRows))
+	Register("tsv", delimited('\t', contributionRows))
+	Register("csv-prs", delimited(',', pullRequestRows))
+	Register("tsv-prs", delimited('\t', pullRequestRows))
+}
+
+// ContributionColumns are the header of the csv and tsv formats, which write
+// one row per contribution. Columns are only ever appended, so that
+// spreadsheets and scripts can rely on their positions.
+var ContributionColumns = []string{
+	"repo", "forge", "host", "owner", "repoName", "description", "repoURL", "language",
+	"stars", "prsMerged", "commits", "additions", "deletions",
+	"firstContribution", "lastContribution", "dependency", "incomplete",
+}
+
+// PullRequestColumns are the header of the csv-prs and tsv-prs formats,
+// which write one row per PR recorded with WithPRDetails. Columns are only
+// ever appended.
+var PullRequestColumns = []string{
+	"repo", "forge", "host", "number", "title", "url", "mergedAt",
+	"commits", "additions", "deletions",
+}
+
+// delimited returns a formatter writing the rows of stats, with a header,
+// separated by comma. Fields containing the separator, quotes or newlines
+// are quoted.
+func delimited(comma rune, rows func(*ossstats.Stats) [][]string) Formatter {
+	return FormatterFunc(func(w io.Writer, stats *ossstats.Stats, _ Options) error {
+		cw := csv.NewWriter(w)
+		cw.Comma = comma
+		if err := cw.WriteAll(rows(stats)); err != nil {
+			return err
+		}
+		return cw.Error()
+	})
+}
+
+// contributionRows returns the header and one row per contribution.
+func contributionRows(stats *ossstats.Stats) [][]string {
+	rows := [][]string{ContributionColumns}
+	for _, contrib := range stats.Contributions {
+		rows = append(rows, []string{
+			contrib.Repo,
+			contrib.Forge,
+			contrib.Host,
+			contrib.Owner,
+			contrib.RepoName,
+			contrib.Description,
+			contrib.RepoURL,
+			contrib.Language,
+			strconv.Itoa(contrib.Stars),
+			strconv.Itoa(contrib.PRsMerged),
+			strconv.Itoa(contrib.Commits),
+			strconv.Itoa(contrib.Additions),
+			strconv.Itoa(contrib.Deletions),
+			csvTime(contrib.FirstContribution),
+			csvTime(contrib.LastContribution),
+			strconv.FormatBool(contrib.Dependency),
+			strconv.FormatBool(contrib.Incomplete),
+		})
+	}
+	return rows
+}
+
+// pullRequestRows returns the header and one row per PR.
+func pullRequestRows(stats *ossstats.Stats) [][]string {
+	rows := [][]string{PullRequestColumns}
+	for _, contrib := range stats.Contributions {
+		for _, pr := range contrib.PullRequests {
+			rows = append(rows, []string{
+				contrib.Repo,
+				contrib.Forge,
+				contrib.Host,
+				strconv.Itoa(pr.Number),
+				pr.Title,
+				pr.URL,
+				csvTime(pr.MergedAt),
+				strconv.Itoa(pr.Commits),
+				strconv.Itoa(pr.Additions),
+				strconv.Itoa(pr.Deletions),
+			})
+		}
+	}
+	return rows
+}
+
+// csvTime formats t as ISO-8601 in UTC, or "" if it is unknown.
+func csvTime(t time.Time) string {
+	if t.IsZero() {
+		return ""
+	}
+	return t.UTC().Format(time.RFC3339)
+}
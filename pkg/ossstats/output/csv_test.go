@@ -0,0 +1,98 @@
+package output
+
+import (
+	"bytes"
+	"encoding/csv"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/mabd-dev/gh-oss-stats/pkg/ossstats"
+)
+
+func csvStats() *ossstats.Stats {
+	mergedAt := time.Date(2025, 3, 2, 9, 30, 0, 0, time.FixedZone("CET", 3600))
+	return &ossstats.Stats{
+		Username: "octocat",
+		Contributions: []ossstats.Contribution{
+			{
+				Repo: "golang/go", Forge: "github", Host: "github.com", Owner: "golang", RepoName: "go",
+				Description: "The Go \"programming\" language,\nfast\tand simple", RepoURL: "https://github.com/golang/go",
+				Language: "Go", Stars: 120000, PRsMerged: 2, Commits: 3, Additions: 10, Deletions: 4,
+				FirstContribution: mergedAt, LastContribution: mergedAt.Add(24 * time.Hour), Dependency: true,
+				PullRequests: []ossstats.PullRequest{
+					{Number: 42, Title: `cmd/go: quote "paths", tabs	and commas`, URL: "https://github.com/golang/go/pull/42", MergedAt: mergedAt.Add(24 * time.Hour), Commits: 2, Additions: 8, Deletions: 1},
+					{Number: 7, Title: "docs", URL: "https://github.com/golang/go/pull/7", MergedAt: mergedAt, Commits: 1, Additions: 2, Deletions: 3},
+				},
+			},
+			{Repo: "lost/repo", Incomplete: true, PRsMerged: 1, Commits: 1},
+		},
+	}
+}
+
+func TestRenderDelimited(t *testing.T) {
+	tests := []struct {
+		format string
+		comma  rune
+		header []string
+		want   [][]string
+	}{
+		{
+			format: "csv",
+			comma:  ',',
+			header: ContributionColumns,
+			want: [][]string{
+				{"golang/go", "github", "github.com", "golang", "go", "The Go \"programming\" language,\nfast\tand simple", "https://github.com/golang/go", "Go",
+					"120000", "2", "3", "10", "4", "2025-03-02T08:30:00Z", "2025-03-03T08:30:00Z", "true", "false"},
+				{"lost/repo", "", "", "", "", "", "", "", "0", "1", "1", "0", "0", "", "", "false", "true"},
+			},
+		},
+		{
+			format: "tsv-prs",
+			comma:  '\t',
+			header: PullRequestColumns,
+			want: [][]string{
+				{"golang/go", "github", "github.com", "42", `cmd/go: quote "paths", tabs	and commas`, "https://github.com/golang/go/pull/42", "2025-03-03T08:30:00Z", "2", "8", "1"},
+				{"golang/go", "github", "github.com", "7", "docs", "https://github.com/golang/go/pull/7", "2025-03-02T08:30:00Z", "1", "2", "3"},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.format, func(t *testing.T) {
+			var buf bytes.Buffer
+			if err := Render(&buf, tt.format, csvStats(), Options{}); err != nil {
+				t.Fatalf("Render() error = %v", err)
+			}
+
+			// Reading the output back checks the quoting
+			r := csv.NewReader(&buf)
+			r.Comma = tt.comma
+			records, err := r.ReadAll()
+			if err != nil {
+				t.Fatalf("Reading %s output: %v", tt.format, err)
+			}
+			if len(records) != len(tt.want)+1 || !slices.Equal(records[0], tt.header) {
+				t.Fatalf("Records = %q, want header and %d rows", records, len(tt.want))
+			}
+			for i, want := range tt.want {
+				if !slices.Equal(records[i+1], want) {
+					t.Errorf("Row %d = %q, want %q", i+1, records[i+1], want)
+				}
+			}
+		})
+	}
+}
+
+func TestRenderDelimitedHeaderOnly(t *testing.T) {
+	for _, format := range []string{"tsv", "csv-prs"} {
+		var buf bytes.Buffer
+		if err := Render(&buf, format, &ossstats.Stats{}, Options{}); err != nil {
+			t.Fatalf("Render(%s) error = %v", format, err)
+		}
+		if lines := strings.Split(strings.TrimSuffix(buf.String(), "\n"), "\n"); len(lines) != 1 {
+			t.Errorf("Render(%s) = %q, want the header only", format, buf.String())
+		}
+	}
+}
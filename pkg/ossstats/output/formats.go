@@ -0,0 +1,79 @@
+package output
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+
+	"github.com/mabd-dev/gh-oss-stats/pkg/ossstats"
+	"github.com/mabd-dev/gh-oss-stats/pkg/ossstats/badge"
+)
+
+func init() {
+	Register("json", FormatterFunc(formatJSON))
+	Register("svg", FormatterFunc(formatSVG))
+	Register("png", FormatterFunc(formatPNG))
+	Register("shields", FormatterFunc(formatShields))
+}
+
+// formatJSON writes the stats as indented JSON.
+func formatJSON(w io.Writer, stats *ossstats.Stats, _ Options) error {
+	data, err := json.MarshalIndent(stats, "", "  ")
+	if err != nil {
+		return fmt.Errorf("encoding JSON: %w", err)
+	}
+	_, err = w.Write(append(data, '\n'))
+	return err
+}
+
+// formatSVG writes the stats as an SVG badge configured by opts.Badge, using
+// the default style, variant and theme where they are unset.
+func formatSVG(w io.Writer, stats *ossstats.Stats, opts Options) error {
+	svg, err := badge.RenderSVG(stats, badgeOptions(opts))
+	if err != nil {
+		return fmt.Errorf("failed to render badge: %w", err)
+	}
+	_, err = io.WriteString(w, svg)
+	return err
+}
+
+// formatPNG writes the stats as a PNG badge configured by opts.Badge, like
+// formatSVG, at opts.Badge.Scale.
+func formatPNG(w io.Writer, stats *ossstats.Stats, opts Options) error {
+	data, err := badge.RenderPNG(stats, badgeOptions(opts))
+	if err != nil {
+		return fmt.Errorf("failed to render badge: %w", err)
+	}
+	_, err = w.Write(data)
+	return err
+}
+
+// formatShields writes the stats as shields.io endpoint JSON, showing the
+// metric and theme of opts.Badge.
+func formatShields(w io.Writer, stats *ossstats.Stats, opts Options) error {
+	endpoint, err := badge.RenderShieldsEndpoint(stats, badgeOptions(opts))
+	if err != nil {
+		return fmt.Errorf("failed to render badge: %w", err)
+	}
+	data, err := json.MarshalIndent(endpoint, "", "  ")
+	if err != nil {
+		return fmt.Errorf("encoding JSON: %w", err)
+	}
+	_, err = w.Write(append(data, '\n'))
+	return err
+}
+
+// badgeOptions returns opts.Badge with defaults for unset fields.
+func badgeOptions(opts Options) badge.BadgeOptions {
+	badgeOpts := opts.Badge
+	if badgeOpts.Style == "" {
+		badgeOpts.Style = badge.DefaultBadgeStyle
+	}
+	if badgeOpts.Variant == "" {
+		badgeOpts.Variant = badge.DefaultBadgeVariant
+	}
+	if badgeOpts.Theme == "" {
+		badgeOpts.Theme = badge.DefaultBadgeTheme
+	}
+	return badgeOpts
+}
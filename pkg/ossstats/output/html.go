@@ -0,0 +1,281 @@
+package output
+
+import (
+	"fmt"
+	"html/template"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/mabd-dev/gh-oss-stats/pkg/ossstats"
+	"github.com/mabd-dev/gh-oss-stats/pkg/ossstats/badge"
+)
+
+func init() {
+	Register("html", FormatterFunc(formatHTML))
+}
+
+var htmlPage = template.Must(template.New("page").Parse(htmlTemplate))
+
+// htmlData holds the data passed to the HTML page template.
+type htmlData struct {
+	Username  string
+	Accounts  string
+	Generated string
+	Colors    badge.ThemeColors
+	Cards     []htmlCard
+	Columns   []htmlColumn
+	Rows      []htmlRow
+	Total     int
+	Timeline  *htmlTimeline
+}
+
+// htmlCard is a summary card in the page header.
+type htmlCard struct {
+	Label  string
+	Value  string
+	Detail string
+}
+
+// htmlColumn is a header of the contributions table.
+type htmlColumn struct {
+	Header  string
+	Numeric bool
+}
+
+// htmlRow is a row of the contributions table.
+type htmlRow struct {
+	Cells []htmlCell
+	PRs   []ossstats.PullRequest
+}
+
+// htmlCell is a table cell. Value is the cell's sort key.
+type htmlCell struct {
+	Text  string
+	URL   string
+	Value string
+	Class string
+}
+
+// formatHTML writes the stats as a self-contained HTML page, styled with
+// the palette of opts.Badge: summary cards, a timeline of the contributions
+// and a sortable, filterable table configured by opts.Report.
+func formatHTML(w io.Writer, stats *ossstats.Stats, opts Options) error {
+	contributions := reportContributions(stats, opts.Report)
+	columns := opts.Report.columns()
+
+	data := htmlData{
+		Username:  stats.Username,
+		Generated: markdownDate(stats.GeneratedAt),
+		Colors:    opts.Badge.Colors(),
+		Total:     len(stats.Contributions),
+		Timeline:  newHTMLTimeline(contributions),
+	}
+	if len(stats.Accounts) > 1 {
+		data.Accounts = strings.Join(stats.Accounts, ", ")
+	}
+
+	data.Cards = []htmlCard{
+		{Label: "Projects", Value: groupDigits(stats.Summary.TotalProjects)},
+		{Label: "PRs merged", Value: groupDigits(stats.Summary.TotalPRsMerged)},
+		{Label: "Commits", Value: groupDigits(stats.Summary.TotalCommits)},
+		{
+			Label:  "Lines changed",
+			Value:  groupDigits(stats.Summary.TotalAdditions + stats.Summary.TotalDeletions),
+			Detail: fmt.Sprintf("+%s / -%s", groupDigits(stats.Summary.TotalAdditions), groupDigits(stats.Summary.TotalDeletions)),
+		},
+	}
+	if deps := stats.Dependencies; deps != nil {
+		data.Cards = append(data.Cards, htmlCard{
+			Label:  "Dependencies",
+			Value:  fmt.Sprintf("%d / %d", deps.Contributed, deps.Total),
+			Detail: "received contributions",
+		})
+	}
+
+	for _, column := range columns {
+		data.Columns = append(data.Columns, htmlColumn{Header: markdownHeaders[column], Numeric: numericColumn(column)})
+	}
+	for _, contrib := range contributions {
+		row := htmlRow{PRs: contrib.PullRequests}
+		for _, column := range columns {
+			cell := htmlTableCell(contrib, column)
+			if numericColumn(column) {
+				cell.Class = strings.TrimSpace(cell.Class + " num")
+			}
+			row.Cells = append(row.Cells, cell)
+		}
+		data.Rows = append(data.Rows, row)
+	}
+
+	return htmlPage.Execute(w, data)
+}
+
+// numericColumn reports whether a column is sorted numerically.
+func numericColumn(column Column) bool {
+	return column == ColumnStars || column == ColumnPRs || column == ColumnCommits || column == ColumnLOC
+}
+
+// htmlTableCell returns a contribution's cell in a table column.
+func htmlTableCell(contrib ossstats.Contribution, column Column) htmlCell {
+	switch column {
+	case ColumnRepo:
+		return htmlCell{Text: contrib.Repo, URL: contrib.RepoURL, Value: strings.ToLower(contrib.Repo), Class: "repo"}
+	case ColumnDescription:
+		return htmlCell{Text: contrib.Description, Value: strings.ToLower(contrib.Description), Class: "muted"}
+	case ColumnLanguage:
+		return htmlCell{Text: contrib.Language, Value: strings.ToLower(contrib.Language)}
+	case ColumnStars:
+		if contrib.Incomplete {
+			return htmlCell{Text: "?", Value: "-1", Class: "star"}
+		}
+		return htmlCell{Text: groupDigits(contrib.Stars), Value: strconv.Itoa(contrib.Stars), Class: "star"}
+	case ColumnPRs:
+		return htmlCell{Text: groupDigits(contrib.PRsMerged), Value: strconv.Itoa(contrib.PRsMerged)}
+	case ColumnCommits:
+		return htmlCell{Text: groupDigits(contrib.Commits), Value: strconv.Itoa(contrib.Commits)}
+	case ColumnLOC:
+		return htmlCell{
+			Text:  fmt.Sprintf("+%s / -%s", groupDigits(contrib.Additions), groupDigits(contrib.Deletions)),
+			Value: strconv.Itoa(contrib.Additions + contrib.Deletions),
+		}
+	case ColumnFirst:
+		return htmlCell{Text: markdownDate(contrib.FirstContribution), Value: csvTime(contrib.FirstContribution)}
+	case ColumnLast:
+		return htmlCell{Text: markdownDate(contrib.LastContribution), Value: csvTime(contrib.LastContribution)}
+	}
+	return htmlCell{}
+}
+
+// Timeline chart geometry, in SVG user units
+const (
+	timelineWidth     = 960
+	timelineLabels    = 220 // Width of the repository labels
+	timelineRowHeight = 24
+	timelineAxis      = 28 // Height of the date axis
+	timelineMaxRows   = 20
+)
+
+// htmlTimeline is an SVG chart with one bar per contribution, from its first
+// to its last merged PR, and a dot per PR when PR details are present.
+type htmlTimeline struct {
+	Width  int
+	Height int
+	Left   int
+	Bars   []timelineBar
+	Ticks  []timelineTick
+	More   int // Contributions left out of the chart
+}
+
+type timelineBar struct {
+	Label string
+	Title string
+	Y     int // Top of the bar
+	Mid   int // Vertical center of the bar
+	X     float64
+	Width float64
+	Dots  []float64 // PR merge dates
+}
+
+type timelineTick struct {
+	X     float64
+	Label string
+}
+
+// newHTMLTimeline lays out the timeline of the contributions with known
+// dates, or returns nil if there are none.
+func newHTMLTimeline(contributions []ossstats.Contribution) *htmlTimeline {
+	var dated []ossstats.Contribution
+	for _, contrib := range contributions {
+		if contrib.FirstContribution.IsZero() || contrib.LastContribution.IsZero() {
+			continue
+		}
+		dated = append(dated, contrib)
+	}
+	if len(dated) == 0 {
+		return nil
+	}
+
+	timeline := &htmlTimeline{Width: timelineWidth, Left: timelineLabels}
+	if len(dated) > timelineMaxRows {
+		timeline.More = len(dated) - timelineMaxRows
+		dated = dated[:timelineMaxRows]
+	}
+
+	// Span only the rows drawn, so hidden ones don't squash the bars
+	var from, to time.Time
+	for _, contrib := range dated {
+		if from.IsZero() || contrib.FirstContribution.Before(from) {
+			from = contrib.FirstContribution
+		}
+		if contrib.LastContribution.After(to) {
+			to = contrib.LastContribution
+		}
+	}
+
+	// Pad the range so that single-day contributions stay visible
+	from = from.UTC().AddDate(0, 0, -7)
+	to = to.UTC().AddDate(0, 0, 7)
+	span := to.Sub(from).Seconds()
+	area := float64(timelineWidth - timelineLabels - 10)
+	x := func(t time.Time) float64 {
+		return float64(timelineLabels) + t.Sub(from).Seconds()/span*area
+	}
+
+	for i, contrib := range dated {
+		bar := timelineBar{
+			Label: contrib.Repo,
+			Title: fmt.Sprintf("%s: %d PRs, %s → %s", contrib.Repo, contrib.PRsMerged,
+				markdownDate(contrib.FirstContribution), markdownDate(contrib.LastContribution)),
+			Y: timelineAxis + i*timelineRowHeight,
+			X: x(contrib.FirstContribution),
+		}
+		bar.Mid = bar.Y + 9
+		bar.Width = max(x(contrib.LastContribution)-bar.X, 4)
+		for _, pr := range contrib.PullRequests {
+			if !pr.MergedAt.IsZero() {
+				bar.Dots = append(bar.Dots, x(pr.MergedAt))
+			}
+		}
+		timeline.Bars = append(timeline.Bars, bar)
+	}
+	timeline.Height = timelineAxis + len(dated)*timelineRowHeight + 8
+
+	// Label years, or months for ranges under two years
+	months := 12
+	if to.Sub(from) < 2*365*24*time.Hour {
+		months = 3
+		if to.Sub(from) < 180*24*time.Hour {
+			months = 1
+		}
+	}
+	tick := time.Date(from.Year(), from.Month(), 1, 0, 0, 0, 0, time.UTC)
+	if months == 12 {
+		tick = time.Date(from.Year(), time.January, 1, 0, 0, 0, 0, time.UTC)
+	}
+	for ; !tick.After(to); tick = tick.AddDate(0, months, 0) {
+		if tick.Before(from) {
+			continue
+		}
+		label := tick.Format("Jan 2006")
+		if months == 12 {
+			label = tick.Format("2006")
+		}
+		timeline.Ticks = append(timeline.Ticks, timelineTick{X: x(tick), Label: label})
+	}
+
+	return timeline
+}
+
+// groupDigits formats n with thousands separators, e.g. "12,450".
+func groupDigits(n int) string {
+	if n < 0 {
+		return "-" + groupDigits(-n)
+	}
+	s := strconv.Itoa(n)
+	for i := len(s) - 3; i > 0; i -= 3 {
+		s = s[:i] + "," + s[i:]
+	}
+	return s
+}
@@ -0,0 +1,185 @@
+package output
+
+// htmlTemplate is the page of the html format. It has no external assets:
+// styles, the timeline chart and the table script are all inline.
+const htmlTemplate = `<!DOCTYPE html>
+<html lang="en">
+<head>
+<meta charset="utf-8">
+<meta name="viewport" content="width=device-width, initial-scale=1">
+<meta name="generator" content="gh-oss-stats">
+<title>Open source contributions by {{.Username}}</title>
+<style>
+  :root {
+    --bg: {{.Colors.Background}};
+    --bg-alt: {{.Colors.BackgroundAlt}};
+    --text: {{.Colors.Text}};
+    --muted: {{.Colors.TextSecondary}};
+    --border: {{.Colors.Border}};
+    --accent: {{.Colors.Accent}};
+    --positive: {{.Colors.Positive}};
+    --negative: {{.Colors.Negative}};
+    --star: {{.Colors.Star}};
+  }
+  * { box-sizing: border-box; }
+  body {
+    margin: 0;
+    background: var(--bg);
+    color: var(--text);
+    font-family: system-ui, -apple-system, BlinkMacSystemFont, "Segoe UI", Helvetica, Arial, sans-serif;
+    line-height: 1.5;
+  }
+  main { max-width: 1040px; margin: 0 auto; padding: 32px 16px 48px; }
+  a { color: var(--accent); text-decoration: none; }
+  a:hover { text-decoration: underline; }
+  header h1 { margin: 0; font-size: 28px; }
+  header p, .muted { color: var(--muted); }
+  header p { margin: 4px 0 0; }
+  h2 { font-size: 18px; margin: 40px 0 12px; }
+  .cards { display: grid; grid-template-columns: repeat(auto-fit, minmax(180px, 1fr)); gap: 12px; margin-top: 24px; }
+  .card { background: var(--bg-alt); border: 1px solid var(--border); border-radius: 8px; padding: 16px; }
+  .card .label { color: var(--muted); font-size: 13px; }
+  .card .value { font-size: 28px; font-weight: 700; color: var(--accent); }
+  .card .detail { color: var(--muted); font-size: 13px; }
+  .panel { background: var(--bg-alt); border: 1px solid var(--border); border-radius: 8px; padding: 12px; overflow-x: auto; }
+  svg text { fill: var(--muted); font-size: 12px; }
+  svg .label { fill: var(--text); }
+  svg .grid { stroke: var(--border); }
+  svg .bar { fill: var(--accent); opacity: 0.85; }
+  svg .dot { fill: var(--positive); }
+  input[type=search] {
+    width: 100%; max-width: 320px; margin-bottom: 12px; padding: 6px 10px;
+    background: var(--bg-alt); color: var(--text); border: 1px solid var(--border); border-radius: 6px;
+  }
+  table { width: 100%; border-collapse: collapse; font-size: 14px; }
+  th, td { text-align: left; padding: 8px; border-bottom: 1px solid var(--border); vertical-align: top; }
+  th { cursor: pointer; user-select: none; white-space: nowrap; color: var(--muted); }
+  th.num, td.num { text-align: right; white-space: nowrap; }
+  th[aria-sort=ascending]::after { content: " ▲"; }
+  th[aria-sort=descending]::after { content: " ▼"; }
+  td.star { color: var(--star); }
+  td.repo { white-space: nowrap; }
+  details { margin-top: 4px; font-size: 13px; }
+  details ul { margin: 4px 0; padding-left: 18px; white-space: normal; }
+  footer { margin-top: 32px; font-size: 12px; color: var(--muted); }
+</style>
+</head>
+<body>
+<main>
+<header>
+  <h1>Open source contributions by {{.Username}}</h1>
+  <p>{{if .Accounts}}Accounts: {{.Accounts}} · {{end}}Generated {{.Generated}}</p>
+  <section class="cards">
+    {{- range .Cards}}
+    <div class="card">
+      <div class="label">{{.Label}}</div>
+      <div class="value">{{.Value}}</div>
+      {{- if .Detail}}
+      <div class="detail">{{.Detail}}</div>
+      {{- end}}
+    </div>
+    {{- end}}
+  </section>
+</header>
+{{- with .Timeline}}
+
+<h2>Timeline</h2>
+<div class="panel">
+<svg width="{{.Width}}" height="{{.Height}}" viewBox="0 0 {{.Width}} {{.Height}}" xmlns="http://www.w3.org/2000/svg" role="img" aria-label="Contribution timeline">
+  {{- range .Ticks}}
+  <line class="grid" x1="{{printf "%.1f" .X}}" y1="16" x2="{{printf "%.1f" .X}}" y2="{{$.Timeline.Height}}"/>
+  <text x="{{printf "%.1f" .X}}" y="12" text-anchor="middle">{{.Label}}</text>
+  {{- end}}
+  {{- range .Bars}}
+  <g>
+    <title>{{.Title}}</title>
+    <text class="label" x="{{$.Timeline.Left}}" dx="-8" y="{{.Y}}" dy="15" text-anchor="end">{{.Label}}</text>
+    <rect class="bar" x="{{printf "%.1f" .X}}" y="{{.Y}}" width="{{printf "%.1f" .Width}}" height="18" rx="4"/>
+    {{- $mid := .Mid}}
+    {{- range .Dots}}
+    <circle class="dot" cx="{{printf "%.1f" .}}" cy="{{$mid}}" r="3"/>
+    {{- end}}
+  </g>
+  {{- end}}
+</svg>
+{{- if .More}}
+<p class="muted">and {{.More}} more projects</p>
+{{- end}}
+</div>
+{{- end}}
+
+<h2>Contributions</h2>
+<input type="search" id="filter" placeholder="Filter projects…" aria-label="Filter projects">
+<div class="panel">
+<table id="contributions">
+  <thead>
+    <tr>
+      {{- range .Columns}}
+      <th{{if .Numeric}} class="num"{{end}}>{{.Header}}</th>
+      {{- end}}
+    </tr>
+  </thead>
+  <tbody>
+    {{- range .Rows}}
+    <tr>
+      {{- $prs := .PRs}}
+      {{- range $i, $cell := .Cells}}
+      <td data-value="{{$cell.Value}}"{{if $cell.Class}} class="{{$cell.Class}}"{{end}}>
+        {{- if $cell.URL}}<a href="{{$cell.URL}}">{{$cell.Text}}</a>{{else}}{{$cell.Text}}{{end}}
+        {{- if and (eq $i 0) $prs}}
+        <details>
+          <summary>{{len $prs}} pull requests</summary>
+          <ul>
+            {{- range $prs}}
+            <li>{{if .URL}}<a href="{{.URL}}">#{{.Number}}</a>{{else}}#{{.Number}}{{end}} {{.Title}} <span class="muted">{{.MergedAt.Format "2006-01-02"}}</span></li>
+            {{- end}}
+          </ul>
+        </details>
+        {{- end -}}
+      </td>
+      {{- end}}
+    </tr>
+    {{- end}}
+  </tbody>
+</table>
+</div>
+{{- if lt (len .Rows) .Total}}
+<p class="muted">Top {{len .Rows}} of {{.Total}} projects.</p>
+{{- end}}
+
+<footer>Generated with gh-oss-stats</footer>
+</main>
+<script>
+(function () {
+  var table = document.getElementById("contributions");
+  var body = table.tBodies[0];
+  var headers = table.tHead.rows[0].cells;
+
+  Array.prototype.forEach.call(headers, function (th, col) {
+    th.addEventListener("click", function () {
+      var ascending = th.getAttribute("aria-sort") !== "ascending";
+      var numeric = th.classList.contains("num");
+      Array.prototype.forEach.call(headers, function (h) { h.removeAttribute("aria-sort"); });
+      th.setAttribute("aria-sort", ascending ? "ascending" : "descending");
+
+      var rows = Array.prototype.slice.call(body.rows);
+      rows.sort(function (a, b) {
+        var x = a.cells[col].dataset.value, y = b.cells[col].dataset.value;
+        var order = numeric ? Number(x) - Number(y) : x.localeCompare(y);
+        return ascending ? order : -order;
+      });
+      rows.forEach(function (row) { body.appendChild(row); });
+    });
+  });
+
+  document.getElementById("filter").addEventListener("input", function (e) {
+    var query = e.target.value.toLowerCase();
+    Array.prototype.forEach.call(body.rows, function (row) {
+      row.hidden = row.textContent.toLowerCase().indexOf(query) === -1;
+    });
+  });
+})();
+</script>
+</body>
+</html>
+`
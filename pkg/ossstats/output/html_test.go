@@ -0,0 +1,128 @@
+package output
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/mabd-dev/gh-oss-stats/pkg/ossstats"
+	"github.com/mabd-dev/gh-oss-stats/pkg/ossstats/badge"
+)
+
+func TestRenderHTML(t *testing.T) {
+	stats := reportStats()
+	stats.Contributions[0].Description = `<script>alert("x")</script>`
+	stats.Dependencies = &ossstats.DependencySummary{Total: 8, Resolved: 6, Contributed: 2}
+
+	var buf bytes.Buffer
+	opts := Options{Badge: badge.BadgeOptions{
+		Theme:        badge.ThemeGithubLight,
+		CustomColors: &badge.ThemeColors{Accent: "#ff00ff"},
+	}}
+	if err := Render(&buf, "html", stats, opts); err != nil {
+		t.Fatalf("Render() error = %v", err)
+	}
+	page := buf.String()
+
+	light := badge.GetThemeColors(badge.ThemeGithubLight)
+	for _, want := range []string{
+		"<!DOCTYPE html>",
+		"<h1>Open source contributions by octocat</h1>",
+		"--bg: " + light.Background + ";",
+		"--accent: #ff00ff;",
+		`<div class="value">150</div>`,   // Lines changed
+		`<div class="value">2 / 8</div>`, // Dependencies
+		`<a href="https://github.com/golang/go">golang/go</a>`,
+		`<td data-value="120000" class="star num">120,000</td>`,
+		`<td data-value="-1" class="star num">?</td>`, // Unknown stars sort last
+		"&lt;script&gt;alert(&#34;x&#34;)&lt;/script&gt;",
+		"<summary>2 pull requests</summary>",
+		`aria-label="Contribution timeline"`,
+		"<title>golang/go: 3 PRs, 2025-03-02 → 2025-03-20</title>",
+		`id="filter"`,
+	} {
+		if !strings.Contains(page, want) {
+			t.Errorf("Page does not contain %q", want)
+		}
+	}
+
+	if strings.Count(page, "<tr>") != len(stats.Contributions)+1 {
+		t.Errorf("Page has %d rows, want a header and %d contributions", strings.Count(page, "<tr>")-1, len(stats.Contributions))
+	}
+
+	// The page must work offline, e.g. published to GitHub Pages
+	for _, external := range []string{"<link", " src=", "@import", "url("} {
+		if strings.Contains(page, external) {
+			t.Errorf("Page references an external asset: %q", external)
+		}
+	}
+}
+
+func TestRenderHTMLLimit(t *testing.T) {
+	var buf bytes.Buffer
+	opts := Options{Report: ReportOptions{Columns: []Column{ColumnRepo, ColumnPRs}, Limit: 1}}
+	if err := Render(&buf, "html", reportStats(), opts); err != nil {
+		t.Fatalf("Render() error = %v", err)
+	}
+	page := buf.String()
+
+	if !strings.Contains(page, "Top 1 of 3 projects.") || strings.Contains(page, "small/lib") {
+		t.Error("Page does not show the top project only")
+	}
+	if !strings.Contains(page, `<th class="num">PRs</th>`) || strings.Contains(page, "<th>Description</th>") {
+		t.Error("Page does not show the selected columns")
+	}
+}
+
+func TestNewHTMLTimeline(t *testing.T) {
+	if timeline := newHTMLTimeline([]ossstats.Contribution{{Repo: "a/b"}}); timeline != nil {
+		t.Errorf("newHTMLTimeline() without dates = %+v, want nil", timeline)
+	}
+
+	day := time.Date(2023, 1, 10, 0, 0, 0, 0, time.UTC)
+	var contributions []ossstats.Contribution
+	for i := range timelineMaxRows + 2 {
+		contributions = append(contributions, ossstats.Contribution{
+			Repo:              "owner/repo",
+			FirstContribution: day.AddDate(0, i, 0),
+			LastContribution:  day.AddDate(1, i, 0),
+		})
+	}
+
+	timeline := newHTMLTimeline(contributions)
+	if len(timeline.Bars) != timelineMaxRows || timeline.More != 2 {
+		t.Fatalf("Timeline has %d bars and %d more, want %d and 2", len(timeline.Bars), timeline.More, timelineMaxRows)
+	}
+	for _, bar := range timeline.Bars {
+		if bar.X < timelineLabels || bar.X+bar.Width > timelineWidth {
+			t.Errorf("Bar %+v is outside the chart", bar)
+		}
+	}
+	if first, last := timeline.Bars[0], timeline.Bars[len(timeline.Bars)-1]; first.X >= last.X || first.Y >= last.Y {
+		t.Errorf("Bars are not laid out in order: first %+v, last %+v", first, last)
+	}
+	// The axis ends with the last drawn bar, not the later hidden ones
+	last := timeline.Bars[len(timeline.Bars)-1]
+	if end, area := last.X+last.Width, float64(timelineWidth-timelineLabels-10); end < float64(timelineWidth-10)-0.02*area {
+		t.Errorf("Last bar ends at %.1f, want the axis to end near %d", end, timelineWidth-10)
+	}
+
+	// A range of over two years is labeled by year
+	var labels []string
+	for _, tick := range timeline.Ticks {
+		labels = append(labels, tick.Label)
+	}
+	if got := strings.Join(labels, ","); got != "2024,2025" {
+		t.Errorf("Tick labels = %s, want 2024,2025", got)
+	}
+}
+
+func TestGroupDigits(t *testing.T) {
+	tests := map[int]string{0: "0", 999: "999", 1000: "1,000", 1234567: "1,234,567", -12450: "-12,450"}
+	for n, want := range tests {
+		if got := groupDigits(n); got != want {
+			t.Errorf("groupDigits(%d) = %q, want %q", n, got, want)
+		}
+	}
+}
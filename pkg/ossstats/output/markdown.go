@@ -0,0 +1,157 @@
+package output
+
+import (
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	"github.com/mabd-dev/gh-oss-stats/pkg/ossstats"
+)
+
+func init() {
+	Register("markdown", FormatterFunc(formatMarkdown))
+	Register("md", FormatterFunc(formatMarkdown))
+}
+
+// markdownHeaders are the table headers of the report columns.
+var markdownHeaders = map[Column]string{
+	ColumnRepo:        "Repository",
+	ColumnDescription: "Description",
+	ColumnLanguage:    "Language",
+	ColumnStars:       "Stars",
+	ColumnPRs:         "PRs",
+	ColumnCommits:     "Commits",
+	ColumnLOC:         "Lines",
+	ColumnFirst:       "First",
+	ColumnLast:        "Last",
+}
+
+// formatMarkdown writes the stats as a Markdown report: a summary line, a
+// table of the contributions configured by opts.Report, and when PR details
+// are present, a list of each shown contribution's PRs.
+func formatMarkdown(w io.Writer, stats *ossstats.Stats, opts Options) error {
+	var b strings.Builder
+
+	fmt.Fprintf(&b, "## Open source contributions by %s\n\n", stats.Username)
+	if !opts.Report.OmitGenerated {
+		fmt.Fprintf(&b, "_Generated %s_\n\n", markdownDate(stats.GeneratedAt))
+	}
+	fmt.Fprintf(&b, "%d projects, %d merged PRs, %d commits, +%d -%d lines.\n",
+		stats.Summary.TotalProjects, stats.Summary.TotalPRsMerged, stats.Summary.TotalCommits,
+		stats.Summary.TotalAdditions, stats.Summary.TotalDeletions)
+
+	contributions := reportContributions(stats, opts.Report)
+	if len(contributions) > 0 {
+		columns := opts.Report.columns()
+
+		b.WriteString("\n|")
+		for _, column := range columns {
+			fmt.Fprintf(&b, " %s |", markdownHeaders[column])
+		}
+		b.WriteString("\n|")
+		for _, column := range columns {
+			b.WriteString(strings.Repeat("-", len(markdownHeaders[column])+2) + "|")
+		}
+		b.WriteString("\n")
+
+		for _, contrib := range contributions {
+			b.WriteString("|")
+			for _, column := range columns {
+				fmt.Fprintf(&b, " %s |", markdownCell(contrib, column))
+			}
+			b.WriteString("\n")
+		}
+
+		if len(contributions) < len(stats.Contributions) {
+			fmt.Fprintf(&b, "\n_Top %d of %d projects._\n", len(contributions), len(stats.Contributions))
+		}
+	}
+
+	writePullRequests(&b, contributions)
+
+	_, err := io.WriteString(w, b.String())
+	return err
+}
+
+// writePullRequests writes a section listing the PRs of each contribution
+// that has PR details.
+func writePullRequests(b *strings.Builder, contributions []ossstats.Contribution) {
+	header := false
+	for _, contrib := range contributions {
+		if len(contrib.PullRequests) == 0 {
+			continue
+		}
+		if !header {
+			b.WriteString("\n### Pull requests\n")
+			header = true
+		}
+
+		fmt.Fprintf(b, "\n#### %s\n\n", markdownLink(contrib.Repo, contrib.RepoURL))
+		for _, pr := range contrib.PullRequests {
+			title := markdownLink(fmt.Sprintf("#%d", pr.Number), pr.URL)
+			if t := strings.TrimSpace(pr.Title); t != "" {
+				title += " " + markdownEscape(t)
+			}
+			fmt.Fprintf(b, "- %s — merged %s", title, markdownDate(pr.MergedAt))
+			if pr.Additions > 0 || pr.Deletions > 0 {
+				fmt.Fprintf(b, " (+%d -%d)", pr.Additions, pr.Deletions)
+			}
+			b.WriteString("\n")
+		}
+	}
+}
+
+// markdownCell returns a contribution's value in a table column.
+func markdownCell(contrib ossstats.Contribution, column Column) string {
+	switch column {
+	case ColumnRepo:
+		return markdownLink(contrib.Repo, contrib.RepoURL)
+	case ColumnDescription:
+		return markdownEscape(contrib.Description)
+	case ColumnLanguage:
+		return markdownEscape(contrib.Language)
+	case ColumnStars:
+		if contrib.Incomplete {
+			return "?"
+		}
+		return fmt.Sprintf("%d", contrib.Stars)
+	case ColumnPRs:
+		return fmt.Sprintf("%d", contrib.PRsMerged)
+	case ColumnCommits:
+		return fmt.Sprintf("%d", contrib.Commits)
+	case ColumnLOC:
+		return fmt.Sprintf("+%d -%d", contrib.Additions, contrib.Deletions)
+	case ColumnFirst:
+		return markdownDate(contrib.FirstContribution)
+	case ColumnLast:
+		return markdownDate(contrib.LastContribution)
+	}
+	return ""
+}
+
+// markdownLink links text to url, or returns the text if url is empty.
+func markdownLink(text, url string) string {
+	if url == "" {
+		return markdownEscape(text)
+	}
+	return fmt.Sprintf("[%s](%s)", markdownEscape(text), url)
+}
+
+// markdownEscape keeps text on one table row and stops it from closing
+// table cells or links.
+var markdownEscape = strings.NewReplacer(
+	"|", `\|`,
+	"[", `\[`,
+	"]", `\]`,
+	"\r\n", " ",
+	"\n", " ",
+).Replace
+
+// markdownDate formats a date as YYYY-MM-DD.
+func markdownDate(t time.Time) string {
+	if t.IsZero() {
+		return "unknown"
+	}
+	return t.UTC().Format("2006-01-02")
+}
@@ -0,0 +1,143 @@
+package output
+
+import (
+	"bytes"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/mabd-dev/gh-oss-stats/pkg/ossstats"
+	"github.com/mabd-dev/gh-oss-stats/pkg/ossstats/badge"
+)
+
+func reportStats() *ossstats.Stats {
+	day := func(d int) time.Time { return time.Date(2025, 3, d, 12, 0, 0, 0, time.UTC) }
+	return &ossstats.Stats{
+		Username:    "octocat",
+		GeneratedAt: day(31),
+		Summary:     ossstats.Summary{TotalProjects: 3, TotalPRsMerged: 6, TotalCommits: 9, TotalAdditions: 120, TotalDeletions: 30},
+		Contributions: []ossstats.Contribution{
+			{Repo: "small/lib", RepoURL: "https://github.com/small/lib", Description: "A | piped\ndescription", Stars: 10, PRsMerged: 1, Commits: 5,
+				FirstContribution: day(1), LastContribution: day(1)},
+			{Repo: "golang/go", RepoURL: "https://github.com/golang/go", Description: "The Go language", Language: "Go", Stars: 120000, PRsMerged: 3, Commits: 3,
+				Additions: 100, Deletions: 20, FirstContribution: day(2), LastContribution: day(20),
+				PullRequests: []ossstats.PullRequest{
+					{Number: 42, Title: "cmd/go: fix [flaky] test ", URL: "https://github.com/golang/go/pull/42", MergedAt: day(20), Additions: 80, Deletions: 10},
+					{Number: 7, URL: "https://github.com/golang/go/pull/7", MergedAt: day(2)},
+				}},
+			{Repo: "lost/repo", Incomplete: true, PRsMerged: 2, Commits: 1, Additions: 20, Deletions: 10,
+				FirstContribution: day(3), LastContribution: day(4)},
+		},
+	}
+}
+
+func TestRenderMarkdown(t *testing.T) {
+	var buf bytes.Buffer
+	if err := Render(&buf, "markdown", reportStats(), Options{}); err != nil {
+		t.Fatalf("Render() error = %v", err)
+	}
+
+	want := `## Open source contributions by octocat
+
+_Generated 2025-03-31_
+
+3 projects, 6 merged PRs, 9 commits, +120 -30 lines.
+
+| Repository | Description | Stars | PRs | Commits | Lines | First | Last |
+|------------|-------------|-------|-----|---------|-------|-------|------|
+| [golang/go](https://github.com/golang/go) | The Go language | 120000 | 3 | 3 | +100 -20 | 2025-03-02 | 2025-03-20 |
+| lost/repo |  | ? | 2 | 1 | +20 -10 | 2025-03-03 | 2025-03-04 |
+| [small/lib](https://github.com/small/lib) | A \| piped description | 10 | 1 | 5 | +0 -0 | 2025-03-01 | 2025-03-01 |
+
+### Pull requests
+
+#### [golang/go](https://github.com/golang/go)
+
+- [#42](https://github.com/golang/go/pull/42) cmd/go: fix \[flaky\] test — merged 2025-03-20 (+80 -10)
+- [#7](https://github.com/golang/go/pull/7) — merged 2025-03-02
+`
+	if got := buf.String(); got != want {
+		t.Errorf("Render() =\n%s\nwant:\n%s", got, want)
+	}
+}
+
+func TestRenderMarkdownOptions(t *testing.T) {
+	var buf bytes.Buffer
+	opts := Options{Report: ReportOptions{
+		Columns: []Column{ColumnRepo, ColumnLanguage, ColumnCommits},
+		SortBy:  badge.SortByCommits,
+		Limit:   2,
+	}}
+	if err := Render(&buf, "md", reportStats(), opts); err != nil {
+		t.Fatalf("Render() error = %v", err)
+	}
+
+	want := `| Repository | Language | Commits |
+|------------|----------|---------|
+| [small/lib](https://github.com/small/lib) |  | 5 |
+| [golang/go](https://github.com/golang/go) | Go | 3 |
+
+_Top 2 of 3 projects._
+`
+	if got := buf.String(); !strings.Contains(got, want) {
+		t.Errorf("Render() =\n%s\nwant table:\n%s", got, want)
+	}
+}
+
+func TestRenderMarkdownEmpty(t *testing.T) {
+	var buf bytes.Buffer
+	if err := Render(&buf, "markdown", &ossstats.Stats{Username: "octocat"}, Options{}); err != nil {
+		t.Fatalf("Render() error = %v", err)
+	}
+	if got := buf.String(); strings.Contains(got, "|") || !strings.HasSuffix(got, "0 projects, 0 merged PRs, 0 commits, +0 -0 lines.\n") {
+		t.Errorf("Render() = %q, want the summary only", got)
+	}
+}
+
+func TestParseColumns(t *testing.T) {
+	columns, err := ParseColumns(" Repo, stars,,loc ")
+	if err != nil {
+		t.Fatalf("ParseColumns() error = %v", err)
+	}
+	if want := []Column{ColumnRepo, ColumnStars, ColumnLOC}; !slices.Equal(columns, want) {
+		t.Errorf("ParseColumns() = %v, want %v", columns, want)
+	}
+
+	for _, input := range []string{"repo,bogus", "", " , "} {
+		if _, err := ParseColumns(input); err == nil {
+			t.Errorf("ParseColumns(%q) error = nil", input)
+		}
+	}
+}
+
+func TestReportContributions(t *testing.T) {
+	stats := reportStats()
+
+	repos := func(contributions []ossstats.Contribution) []string {
+		var names []string
+		for _, contrib := range contributions {
+			names = append(names, contrib.Repo)
+		}
+		return names
+	}
+
+	tests := []struct {
+		opts ReportOptions
+		want []string
+	}{
+		{ReportOptions{}, []string{"golang/go", "lost/repo", "small/lib"}},
+		{ReportOptions{SortBy: badge.SortByStars}, []string{"golang/go", "small/lib", "lost/repo"}},
+		{ReportOptions{SortBy: badge.SortByCommits, Limit: 1}, []string{"small/lib"}},
+		{ReportOptions{Limit: 10}, []string{"golang/go", "lost/repo", "small/lib"}},
+	}
+	for _, tt := range tests {
+		if got := repos(reportContributions(stats, tt.opts)); !slices.Equal(got, tt.want) {
+			t.Errorf("reportContributions(%+v) = %v, want %v", tt.opts, got, tt.want)
+		}
+	}
+
+	if stats.Contributions[0].Repo != "small/lib" {
+		t.Error("reportContributions() reordered the stats")
+	}
+}
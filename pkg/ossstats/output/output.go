@@ -0,0 +1,106 @@
+// Package output renders stats in named output formats, such as JSON or an
+// SVG badge.
+//
+// Formats are looked up by name in a registry, so programs can offer every
+// registered format, e.g. behind a --format flag, and library users can add
+// their own with Register:
+//
+//	output.Register("count", output.FormatterFunc(func(w io.Writer, stats *ossstats.Stats, opts output.Options) error {
+//		_, err := fmt.Fprintln(w, stats.Summary.TotalPRsMerged)
+//		return err
+//	}))
+package output
+
+import (
+	"fmt"
+	"io"
+	"maps"
+	"slices"
+	"strings"
+	"sync"
+
+	"github.com/mabd-dev/gh-oss-stats/pkg/ossstats"
+	"github.com/mabd-dev/gh-oss-stats/pkg/ossstats/badge"
+)
+
+// Formatter renders stats in an output format.
+type Formatter interface {
+	Format(w io.Writer, stats *ossstats.Stats, opts Options) error
+}
+
+// FormatterFunc adapts a function to the Formatter interface.
+type FormatterFunc func(w io.Writer, stats *ossstats.Stats, opts Options) error
+
+// Format calls f(w, stats, opts).
+func (f FormatterFunc) Format(w io.Writer, stats *ossstats.Stats, opts Options) error {
+	return f(w, stats, opts)
+}
+
+// Options configures formatters. Each formatter uses the options relevant to
+// it and ignores the rest.
+type Options struct {
+	Badge  badge.BadgeOptions // Badge formats, such as svg
+	Report ReportOptions      // Report formats, such as markdown
+}
+
+// ErrUnknownFormat is returned when no formatter is registered under a name.
+type ErrUnknownFormat struct {
+	Name string
+}
+
+func (e *ErrUnknownFormat) Error() string {
+	return fmt.Sprintf("unknown format %q (valid: %s)", e.Name, strings.Join(Formats(), ", "))
+}
+
+var (
+	mu         sync.RWMutex
+	formatters = make(map[string]Formatter)
+)
+
+// Register makes a formatter available under a name. Names are case
+// insensitive. It panics if the name is empty, the formatter is nil or the
+// name is already registered.
+func Register(name string, f Formatter) {
+	name = strings.ToLower(strings.TrimSpace(name))
+	if name == "" {
+		panic("output: Register with an empty name")
+	}
+	if f == nil {
+		panic("output: Register formatter is nil for " + name)
+	}
+
+	mu.Lock()
+	defer mu.Unlock()
+	if _, exists := formatters[name]; exists {
+		panic("output: Register called twice for " + name)
+	}
+	formatters[name] = f
+}
+
+// Lookup returns the formatter registered under name, or an
+// *ErrUnknownFormat.
+func Lookup(name string) (Formatter, error) {
+	mu.RLock()
+	f, ok := formatters[strings.ToLower(strings.TrimSpace(name))]
+	mu.RUnlock()
+	if !ok {
+		return nil, &ErrUnknownFormat{Name: name}
+	}
+	return f, nil
+}
+
+// Formats returns the sorted names of the registered formats.
+func Formats() []string {
+	mu.RLock()
+	defer mu.RUnlock()
+	return slices.Sorted(maps.Keys(formatters))
+}
+
+// Render writes stats to w in the named format.
+func Render(w io.Writer, name string, stats *ossstats.Stats, opts Options) error {
+	f, err := Lookup(name)
+	if err != nil {
+		return err
+	}
+	return f.Format(w, stats, opts)
+}
@@ -0,0 +1,164 @@
+package output
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"image"
+	"image/png"
+	"io"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/mabd-dev/gh-oss-stats/pkg/ossstats"
+	"github.com/mabd-dev/gh-oss-stats/pkg/ossstats/badge"
+)
+
+func testStats() *ossstats.Stats {
+	return &ossstats.Stats{
+		SchemaVersion: ossstats.StatsSchemaVersion,
+		Username:      "octocat",
+		Summary:       ossstats.Summary{TotalProjects: 1, TotalPRsMerged: 3},
+		Contributions: []ossstats.Contribution{{Repo: "golang/go", Owner: "golang", RepoName: "go", PRsMerged: 3}},
+	}
+}
+
+func TestBuiltinFormats(t *testing.T) {
+	formats := Formats()
+	for _, name := range []string{"json", "svg", "png", "shields"} {
+		if !slices.Contains(formats, name) {
+			t.Errorf("Formats() = %v, missing %s", formats, name)
+		}
+	}
+	if !slices.IsSorted(formats) {
+		t.Errorf("Formats() = %v, want sorted", formats)
+	}
+}
+
+func TestRenderJSON(t *testing.T) {
+	var buf bytes.Buffer
+	if err := Render(&buf, "JSON", testStats(), Options{}); err != nil {
+		t.Fatalf("Render() error = %v", err)
+	}
+
+	stats, err := ossstats.ParseStats(buf.Bytes())
+	if err != nil {
+		t.Fatalf("ParseStats() error = %v", err)
+	}
+	if stats.Username != "octocat" || len(stats.Contributions) != 1 {
+		t.Errorf("Round-tripped stats = %+v", stats)
+	}
+	if !strings.HasSuffix(buf.String(), "}\n") {
+		t.Error("JSON output does not end with a newline")
+	}
+}
+
+func TestRenderSVG(t *testing.T) {
+	tests := []struct {
+		name string
+		opts Options
+	}{
+		{name: "defaults", opts: Options{}},
+		{name: "configured", opts: Options{Badge: badge.BadgeOptions{Style: badge.StyleCompact, Variant: badge.VariantTextBased, Theme: badge.ThemeNord}}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var buf bytes.Buffer
+			if err := Render(&buf, "svg", testStats(), tt.opts); err != nil {
+				t.Fatalf("Render() error = %v", err)
+			}
+			if !strings.HasPrefix(buf.String(), "<svg") {
+				t.Errorf("Render() = %.40q, want an SVG", buf.String())
+			}
+		})
+	}
+}
+
+func TestRenderPNG(t *testing.T) {
+	var buf bytes.Buffer
+	opts := Options{Badge: badge.BadgeOptions{Style: badge.StyleCompact, Scale: 1}}
+	if err := Render(&buf, "png", testStats(), opts); err != nil {
+		t.Fatalf("Render() error = %v", err)
+	}
+
+	img, err := png.Decode(&buf)
+	if err != nil {
+		t.Fatalf("png.Decode() error = %v", err)
+	}
+	if got := img.Bounds().Size(); got != image.Pt(280, 32) {
+		t.Errorf("Size = %v, want 280x32", got)
+	}
+}
+
+func TestRenderShields(t *testing.T) {
+	var buf bytes.Buffer
+	opts := Options{Badge: badge.BadgeOptions{Metric: badge.ShieldsMetricProjects, Theme: badge.ThemeNord}}
+	if err := Render(&buf, "shields", testStats(), opts); err != nil {
+		t.Fatalf("Render() error = %v", err)
+	}
+
+	var endpoint badge.ShieldsEndpoint
+	if err := json.Unmarshal(buf.Bytes(), &endpoint); err != nil {
+		t.Fatalf("Unmarshal() error = %v", err)
+	}
+	want := badge.ShieldsEndpoint{
+		SchemaVersion: 1,
+		Label:         "OSS",
+		Message:       "1 project",
+		Color:         badge.GetThemeColors(badge.ThemeNord).Accent,
+		LabelColor:    badge.GetThemeColors(badge.ThemeNord).BackgroundAlt,
+		NamedLogo:     "github",
+		LogoColor:     badge.GetThemeColors(badge.ThemeNord).Text,
+	}
+	if endpoint != want {
+		t.Errorf("Render() = %+v, want %+v", endpoint, want)
+	}
+}
+
+func TestRenderUnknownFormat(t *testing.T) {
+	err := Render(io.Discard, "yaml", testStats(), Options{})
+
+	var unknownErr *ErrUnknownFormat
+	if !errors.As(err, &unknownErr) || unknownErr.Name != "yaml" {
+		t.Fatalf("Render() error = %v, want ErrUnknownFormat", err)
+	}
+	if !strings.Contains(err.Error(), "json") || !strings.Contains(err.Error(), "svg") {
+		t.Errorf("Error %q does not list the valid formats", err)
+	}
+}
+
+func TestRegister(t *testing.T) {
+	Register("Test-Count", FormatterFunc(func(w io.Writer, stats *ossstats.Stats, _ Options) error {
+		_, err := fmt.Fprintln(w, stats.Summary.TotalPRsMerged)
+		return err
+	}))
+
+	var buf bytes.Buffer
+	if err := Render(&buf, "test-count", testStats(), Options{}); err != nil {
+		t.Fatalf("Render() error = %v", err)
+	}
+	if buf.String() != "3\n" {
+		t.Errorf("Render() = %q, want %q", buf.String(), "3\n")
+	}
+	if !slices.Contains(Formats(), "test-count") {
+		t.Errorf("Formats() = %v, missing test-count", Formats())
+	}
+
+	panics := map[string]func(){
+		"duplicate":  func() { Register("json", FormatterFunc(formatJSON)) },
+		"empty name": func() { Register(" ", FormatterFunc(formatJSON)) },
+		"nil":        func() { Register("nil-format", nil) },
+	}
+	for name, register := range panics {
+		t.Run(name, func(t *testing.T) {
+			defer func() {
+				if recover() == nil {
+					t.Error("Register() did not panic")
+				}
+			}()
+			register()
+		})
+	}
+}
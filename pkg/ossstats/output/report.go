@@ -0,0 +1,97 @@
+package output
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/mabd-dev/gh-oss-stats/pkg/ossstats"
+	"github.com/mabd-dev/gh-oss-stats/pkg/ossstats/badge"
+)
+
+// Column is a contribution field shown by the report formats.
+type Column string
+
+const (
+	ColumnRepo        Column = "repo"        // Repository, linked to its URL
+	ColumnDescription Column = "description" // Repository description
+	ColumnLanguage    Column = "language"    // Primary language
+	ColumnStars       Column = "stars"       // Star count
+	ColumnPRs         Column = "prs"         // Merged PRs
+	ColumnCommits     Column = "commits"     // Commits across PRs
+	ColumnLOC         Column = "loc"         // Lines added and deleted
+	ColumnFirst       Column = "first"       // First PR merge date
+	ColumnLast        Column = "last"        // Most recent PR merge date
+)
+
+// Columns lists every column, in the default order.
+var Columns = []Column{
+	ColumnRepo, ColumnDescription, ColumnLanguage, ColumnStars, ColumnPRs,
+	ColumnCommits, ColumnLOC, ColumnFirst, ColumnLast,
+}
+
+// DefaultColumns are the columns shown when ReportOptions.Columns is empty.
+var DefaultColumns = []Column{
+	ColumnRepo, ColumnDescription, ColumnStars, ColumnPRs,
+	ColumnCommits, ColumnLOC, ColumnFirst, ColumnLast,
+}
+
+// ParseColumns parses a comma-separated list of column names, e.g.
+// "repo,stars,prs".
+func ParseColumns(s string) ([]Column, error) {
+	var columns []Column
+	for _, name := range strings.Split(s, ",") {
+		name = strings.ToLower(strings.TrimSpace(name))
+		if name == "" {
+			continue
+		}
+		if !slices.Contains(Columns, Column(name)) {
+			valid := make([]string, len(Columns))
+			for i, column := range Columns {
+				valid[i] = string(column)
+			}
+			return nil, fmt.Errorf("invalid column %q (valid: %s)", name, strings.Join(valid, ", "))
+		}
+		columns = append(columns, Column(name))
+	}
+	if len(columns) == 0 {
+		return nil, fmt.Errorf("no columns given")
+	}
+	return columns, nil
+}
+
+// ReportOptions configures the report formats, such as markdown.
+type ReportOptions struct {
+	Columns []Column     // Columns to show, in order. Default: DefaultColumns
+	SortBy  badge.SortBy // Sort order, as for the detailed badge. Default: badge.DefaultSortBy
+	Limit   int          // Maximum number of contributions. Default: 0, for all
+
+	// OmitGenerated leaves the generation date out of Markdown reports, so a
+	// file kept in git only changes when the numbers do
+	OmitGenerated bool
+}
+
+// columns returns the configured columns, or DefaultColumns.
+func (o ReportOptions) columns() []Column {
+	if len(o.Columns) == 0 {
+		return DefaultColumns
+	}
+	return o.Columns
+}
+
+// reportContributions returns the contributions to report: a sorted copy of
+// the stats' contributions, cut to the limit.
+func reportContributions(stats *ossstats.Stats, opts ReportOptions) []ossstats.Contribution {
+	contributions := slices.Clone(stats.Contributions)
+
+	sortBy := opts.SortBy
+	if sortBy == "" {
+		sortBy = badge.DefaultSortBy
+	}
+	badge.SortContributions(contributions, sortBy)
+
+	if opts.Limit > 0 && opts.Limit < len(contributions) {
+		contributions = contributions[:opts.Limit]
+	}
+	return contributions
+}
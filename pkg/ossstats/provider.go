@@ -0,0 +1,370 @@
+package ossstats
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Forge types used to tag contributions.
+const (
+	ForgeGitHub = "github"
+	ForgeGitLab = "gitlab"
+	ForgeGitea  = "gitea" // Gitea and its forks, Forgejo and Codeberg
+)
+
+// Provider finds a user's merged pull requests on one forge and aggregates
+// them by repository. GetContributions always queries GitHub, and any
+// providers added with WithProvider, WithGitLab or WithGitea, then filters,
+// sorts and summarizes the combined contributions.
+type Provider interface {
+	// Name identifies the provider in logs and errors, e.g. "gitlab.gnome.org".
+	Name() string
+
+	// Contributions returns the user's contributions to repositories outside
+	// their own namespace, each tagged with its Forge and Host. A returned
+	// error means nothing could be collected; failures of individual
+	// lookups go into ProviderResult.Errors instead.
+	Contributions(ctx context.Context, username string, opts ProviderOptions) (*ProviderResult, error)
+}
+
+// ProviderOptions holds the Client configuration that applies to providers.
+type ProviderOptions struct {
+	IncludeLOC       bool     // Fetch commit counts and lines added/deleted per PR
+	IncludePRDetails bool     // List each contribution's merged PRs in Contribution.PullRequests
+	MaxPRs           int      // Maximum number of merged PRs to collect (0 = unlimited)
+	ExcludeOrgs      []string // Top-level namespaces to skip
+	ExcludeUsers     []string // Other accounts of the user, whose namespaces are skipped like their own
+	Logger           Logger
+}
+
+// ProviderResult is the outcome of a provider run.
+type ProviderResult struct {
+	Contributions []Contribution
+	Errors        []error // Failed lookups; the contributions are partial
+
+	// UnprocessedPRs and UnprocessedRepos count the PRs and repositories
+	// skipped because the context was done before they were fetched
+	UnprocessedPRs   int
+	UnprocessedRepos int
+}
+
+// providerOptions returns the Client configuration passed to providers,
+// excluding the organizations and users of run on top of the configured
+// organizations.
+func (c *Client) providerOptions(run runOptions) ProviderOptions {
+	return ProviderOptions{
+		IncludeLOC:       c.includeLOC,
+		IncludePRDetails: c.includePRDetails,
+		MaxPRs:           c.maxPRs,
+		ExcludeOrgs:      append(slices.Clone(c.excludeOrgs), run.excludeOrgs...),
+		ExcludeUsers:     run.excludeUsers,
+		Logger:           c.logger,
+	}
+}
+
+// excludes reports whether contributions to namespace are skipped.
+func (o ProviderOptions) excludes(namespace string) bool {
+	return containsFold(o.ExcludeOrgs, namespace) || containsFold(o.ExcludeUsers, namespace)
+}
+
+// githubProvider runs the GitHub pipeline: search merged PRs, fetch their
+// details and enrich the repositories with metadata.
+type githubProvider struct {
+	client         *Client
+	checkpointPath string
+
+	// cp is the checkpoint of the last run, removed once the whole run succeeds
+	cp *checkpoint
+}
+
+// Name implements Provider.
+func (p *githubProvider) Name() string {
+	return p.client.githubHost()
+}
+
+// Contributions implements Provider.
+func (p *githubProvider) Contributions(ctx context.Context, username string, opts ProviderOptions) (*ProviderResult, error) {
+	c := p.client
+	query := searchQuery(username, opts.ExcludeOrgs, opts.ExcludeUsers)
+
+	// Initialize GitHub API client
+	apiClient, err := c.newAPIClient()
+	if err != nil {
+		return nil, err
+	}
+
+	// Load checkpoint to resume previously completed work
+	if p.checkpointPath != "" {
+		cp, resumed, err := loadCheckpoint(p.checkpointPath, username, query)
+		if err != nil {
+			return nil, err
+		}
+		if resumed {
+			c.logger.Printf("Resuming from checkpoint %s (%d PRs, %d repos already fetched)",
+				p.checkpointPath, len(cp.PullRequests), len(cp.Repositories))
+		}
+		p.cp = cp
+	}
+
+	// Step 1: Search for merged PRs to external repos
+	c.logger.Printf("Searching for merged PRs...")
+	issues, _, err := c.searchMergedPRs(ctx, apiClient, username, query, p.cp)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(issues) == 0 {
+		c.logger.Printf("No contributions found")
+		return &ProviderResult{Contributions: []Contribution{}}, nil
+	}
+
+	c.logger.Printf("Found %d merged PRs", len(issues))
+
+	// Step 2: Fetch PR details and aggregate by repository
+	c.logger.Printf("Fetching PR details...")
+	contributions, errors, unprocessedPRs := c.fetchPRDetails(ctx, apiClient, issues, p.cp)
+
+	// Step 3: Fetch repository metadata
+	c.logger.Printf("Fetching repository metadata...")
+	contributions, repoErrors, unprocessedRepos := c.enrichWithRepoData(ctx, apiClient, contributions, p.cp)
+	c.saveCheckpoint(p.cp.flush())
+
+	return &ProviderResult{
+		Contributions:    contributions,
+		Errors:           append(errors, repoErrors...),
+		UnprocessedPRs:   unprocessedPRs,
+		UnprocessedRepos: unprocessedRepos,
+	}, nil
+}
+
+// githubHost returns the host of the GitHub instance, e.g. "github.com" or
+// the host of a GitHub Enterprise Server set with WithBaseURL.
+func (c *Client) githubHost() string {
+	if c.baseURL == "" {
+		return "github.com"
+	}
+	u, err := url.Parse(c.baseURL)
+	if err != nil || u.Host == "" {
+		return "github.com"
+	}
+	return strings.TrimPrefix(u.Host, "api.")
+}
+
+// contributionKey identifies a contribution's repository across forges.
+func contributionKey(c Contribution) string {
+	return strings.ToLower(c.Host + "/" + c.Repo)
+}
+
+// forgeRepo aggregates a user's merged PRs to one repository of a forge
+// other than GitHub. P is the forge's pull request type.
+type forgeRepo[P any] struct {
+	contrib Contribution
+	prs     []P
+}
+
+// forgeAggregation adapts the pull requests of a forge other than GitHub,
+// of type P, to forgeContributions.
+type forgeAggregation[P any] struct {
+	repoNoun string // Names repositories in logs and errors, e.g. "project"
+
+	// repository returns the key grouping PRs by repository and an empty
+	// contribution to it.
+	repository func(pr P) (key string, contrib Contribution)
+
+	// pullRequest returns the number, title, URL and merge date of a PR.
+	pullRequest func(pr P) PullRequest
+
+	// prRef names a PR to repo in errors, e.g. "MR GNOME/gtk!12".
+	prRef func(repo string, number int) string
+
+	// count returns the commits and changed lines of a PR.
+	count func(ctx context.Context, pr P) (commits, additions, deletions int, err error)
+
+	// fetchRepository fetches the metadata of a repository into its
+	// contribution.
+	fetchRepository func(ctx context.Context, repo *forgeRepo[P]) error
+
+	// apiError converts API failures into the package's typed errors.
+	apiError func(err error, resource string) error
+}
+
+// forgeContributions aggregates the merged PRs of a forge other than GitHub
+// by repository, with commit and line counts if enabled, and fetches the
+// repositories' metadata.
+func forgeContributions[P any](ctx context.Context, prs []P, opts ProviderOptions, logger Logger, agg forgeAggregation[P]) *ProviderResult {
+	result := &ProviderResult{Contributions: []Contribution{}}
+
+	repos, errs, unprocessed := aggregateForgePRs(ctx, prs, opts, agg)
+	result.Errors = append(result.Errors, errs...)
+	result.UnprocessedPRs = unprocessed
+
+	errs, unprocessed = enrichForgeRepos(ctx, repos, logger, agg)
+	result.Errors = append(result.Errors, errs...)
+	result.UnprocessedRepos = unprocessed
+
+	for _, repo := range repos {
+		result.Contributions = append(result.Contributions, repo.contrib)
+	}
+	return result
+}
+
+// aggregateForgePRs groups PRs by repository. With LOC enabled it also
+// counts each PR's commits and changed lines; otherwise every PR counts as
+// one commit. With PR details enabled, each contribution lists its PRs,
+// most recent first. It returns the failed lookups and how many PRs were skipped
+// because ctx was done.
+func aggregateForgePRs[P any](ctx context.Context, prs []P, opts ProviderOptions, agg forgeAggregation[P]) ([]*forgeRepo[P], []error, int) {
+	type repoPR struct {
+		repo *forgeRepo[P]
+		pr   P
+	}
+
+	var (
+		repos   []*forgeRepo[P]
+		byKey   = make(map[string]*forgeRepo[P])
+		repoPRs []repoPR
+		mu      sync.Mutex
+		errs    []error
+	)
+
+	for _, pr := range prs {
+		key, contrib := agg.repository(pr)
+		repo, ok := byKey[key]
+		if !ok {
+			repo = &forgeRepo[P]{contrib: contrib}
+			byKey[key] = repo
+			repos = append(repos, repo)
+		}
+		repo.prs = append(repo.prs, pr)
+		repoPRs = append(repoPRs, repoPR{repo: repo, pr: pr})
+	}
+
+	add := func(repo *forgeRepo[P], pr P, commits, additions, deletions int) {
+		details := agg.pullRequest(pr)
+		details.Commits, details.Additions, details.Deletions = commits, additions, deletions
+		mergedAt := details.MergedAt
+		mu.Lock()
+		defer mu.Unlock()
+
+		contrib := &repo.contrib
+		contrib.PRsMerged++
+		contrib.Commits += commits
+		contrib.Additions += additions
+		contrib.Deletions += deletions
+		if opts.IncludePRDetails {
+			contrib.PullRequests = append(contrib.PullRequests, details)
+		}
+		if contrib.FirstContribution.IsZero() || mergedAt.Before(contrib.FirstContribution) {
+			contrib.FirstContribution = mergedAt
+		}
+		if mergedAt.After(contrib.LastContribution) {
+			contrib.LastContribution = mergedAt
+		}
+	}
+
+	var unprocessed int
+	if !opts.IncludeLOC {
+		for _, item := range repoPRs {
+			add(item.repo, item.pr, 1, 0, 0) // Default to 1 commit per PR if not fetching details
+		}
+	} else {
+		unprocessed = forEachLimited(ctx, maxConcurrentRequests, repoPRs, func(item repoPR) bool {
+			commits, additions, deletions, err := agg.count(ctx, item.pr)
+			if err != nil && ctx.Err() != nil {
+				return true
+			}
+			if err != nil {
+				repo := item.repo.contrib.Repo
+				ref := agg.prRef(repo, agg.pullRequest(item.pr).Number)
+				mu.Lock()
+				errs = append(errs, fmt.Errorf("fetching %s: %w", ref, agg.apiError(err, repo)))
+				mu.Unlock()
+				return false
+			}
+			add(item.repo, item.pr, commits, additions, deletions)
+			return false
+		})
+	}
+
+	// Repositories whose PRs all failed have nothing to report
+	counted := repos[:0]
+	for _, repo := range repos {
+		if repo.contrib.PRsMerged > 0 {
+			sortPullRequests(repo.contrib.PullRequests)
+			counted = append(counted, repo)
+		}
+	}
+	return counted, errs, unprocessed
+}
+
+// enrichForgeRepos fetches repository metadata into the contributions.
+// Contributions whose lookup fails are marked Incomplete.
+func enrichForgeRepos[P any](ctx context.Context, repos []*forgeRepo[P], logger Logger, agg forgeAggregation[P]) ([]error, int) {
+	var mu sync.Mutex
+	var errs []error
+
+	// Contributions stay incomplete until their metadata is known
+	for _, repo := range repos {
+		repo.contrib.Incomplete = true
+	}
+
+	unprocessed := forEachLimited(ctx, maxConcurrentRequests, repos, func(repo *forgeRepo[P]) bool {
+		name := repo.contrib.Repo
+		err := agg.fetchRepository(ctx, repo)
+		if err != nil && ctx.Err() != nil {
+			return true
+		}
+		if err != nil {
+			err = agg.apiError(err, name)
+			logger.Printf("Failed to fetch %s %s: %v", agg.repoNoun, name, err)
+			mu.Lock()
+			errs = append(errs, fmt.Errorf("fetching %s %s: %w", agg.repoNoun, name, err))
+			mu.Unlock()
+			return false
+		}
+		repo.contrib.Incomplete = false
+		return false
+	})
+
+	return errs, unprocessed
+}
+
+// forgeStatusError converts the authentication, rate limit and permission
+// failures of another forge's API into the package's typed errors. Other
+// errors are returned unchanged.
+func forgeStatusError(err error, forge string, statusCode int, message string, retryAt time.Time, resource string) error {
+	switch statusCode {
+	case http.StatusUnauthorized:
+		return &ErrAuthentication{Message: "invalid or missing " + forge + " token"}
+	case http.StatusTooManyRequests:
+		if retryAt.IsZero() {
+			retryAt = time.Now().Add(time.Minute)
+		}
+		return &ErrRateLimited{
+			ResetAt: retryAt,
+			Message: resource + " rate limit exceeded",
+		}
+	case http.StatusForbidden:
+		return &ErrForbidden{
+			Resource: resource,
+			Message:  message,
+		}
+	}
+	return err
+}
+
+// containsFold reports whether list contains s, ignoring case.
+func containsFold(list []string, s string) bool {
+	for _, item := range list {
+		if strings.EqualFold(item, s) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,204 @@
+package ossstats
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestGetContributionsEdgeCaseScenario(t *testing.T) {
+	client := New(
+		WithLOC(true),
+		WithDebugScenario(filepath.Join("testdata", "scenarios", "edge-cases.json")),
+	)
+
+	_, err := client.GetContributions(context.Background(), "octocat")
+
+	partialErr, ok := err.(*ErrPartialResults)
+	if !ok {
+		t.Fatalf("Expected *ErrPartialResults, got %T (%v)", err, err)
+	}
+	if len(partialErr.Errors) != 2 {
+		t.Fatalf("Errors = %v, want the failed kubernetes and acme-corp PR lookups", partialErr.Errors)
+	}
+
+	var ssoErr *ErrSSORequired
+	found := false
+	for _, e := range partialErr.Errors {
+		if errors.As(e, &ssoErr) {
+			found = true
+		}
+	}
+	if !found {
+		t.Errorf("Expected an ErrSSORequired among %v", partialErr.Errors)
+	} else if ssoErr.URL == "" {
+		t.Error("ErrSSORequired.URL is empty, want the authorization URL")
+	}
+
+	stats := partialErr.Stats
+	if len(stats.Contributions) != 1 {
+		t.Fatalf("Contributions = %+v, want only golang/go", stats.Contributions)
+	}
+
+	contrib := stats.Contributions[0]
+	if contrib.Repo != "golang/go" {
+		t.Errorf("Repo = %s, want golang/go", contrib.Repo)
+	}
+	if contrib.PRsMerged != 2 || contrib.Additions != 130 || contrib.Deletions != 32 {
+		t.Errorf("golang/go = %d PRs, +%d -%d, want 2 PRs, +130 -32", contrib.PRsMerged, contrib.Additions, contrib.Deletions)
+	}
+	if contrib.Stars != 125000 {
+		t.Errorf("Stars = %d, want 125000", contrib.Stars)
+	}
+}
+
+func TestGetContributionsScenarioExcludeOrgs(t *testing.T) {
+	client := New(
+		WithLOC(true),
+		WithExcludeOrgs([]string{"kubernetes", "acme-corp"}),
+		WithDebugScenario(filepath.Join("testdata", "scenarios", "edge-cases.json")),
+	)
+
+	stats, err := client.GetContributions(context.Background(), "octocat")
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if stats.Summary.TotalProjects != 1 || stats.Summary.TotalPRsMerged != 2 {
+		t.Errorf("Summary = %+v, want 1 project with 2 PRs", stats.Summary)
+	}
+}
+
+func TestGetContributionsScenarioSearchRateLimited(t *testing.T) {
+	client := New(WithDebugScenario(filepath.Join("testdata", "scenarios", "search-rate-limited.json")))
+
+	_, err := client.GetContributions(context.Background(), "octocat")
+	if _, ok := err.(*ErrRateLimited); !ok {
+		t.Errorf("Expected *ErrRateLimited, got %T (%v)", err, err)
+	}
+}
+
+func TestGetContributionsInvalidScenario(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "scenario.json")
+	if err := os.WriteFile(path, []byte(`{"failures": [{"call": "GetEverything"}]}`), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	client := New(WithDebugScenario(path))
+	if _, err := client.GetContributions(context.Background(), "octocat"); err == nil {
+		t.Error("Expected error for an invalid scenario")
+	}
+}
+
+// writeScenario writes a scenario with count PRs, each to its own repository.
+func writeScenario(t *testing.T, count int, failures string) string {
+	t.Helper()
+
+	var prs, repos []string
+	for i := 1; i <= count; i++ {
+		repo := fmt.Sprintf("org%d/repo", i)
+		prs = append(prs, fmt.Sprintf(`{"repo": %q, "number": %d, "merged_at": "2025-03-04T12:00:00Z", "additions": 1}`, repo, i))
+		repos = append(repos, fmt.Sprintf(`{"full_name": %q, "stargazers_count": 10}`, repo))
+	}
+
+	scenario := fmt.Sprintf(`{"users": {"octocat": [%s]}, "repositories": [%s], "failures": %s}`,
+		strings.Join(prs, ","), strings.Join(repos, ","), failures)
+
+	path := filepath.Join(t.TempDir(), "scenario.json")
+	if err := os.WriteFile(path, []byte(scenario), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+func TestGetContributionsTimeoutReturnsPartialResults(t *testing.T) {
+	tests := []struct {
+		name          string
+		failures      string
+		wantPRsLeft   bool
+		wantReposLeft bool
+	}{
+		{
+			name:        "during PR details",
+			failures:    `[{"call": "GetPullRequest", "latency": "40ms"}]`,
+			wantPRsLeft: true,
+		},
+		{
+			name:          "during repository metadata",
+			failures:      `[{"call": "GetRepository", "latency": "40ms"}]`,
+			wantReposLeft: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client := New(
+				WithLOC(true),
+				WithTimeout(100*time.Millisecond),
+				WithDebugScenario(writeScenario(t, 20, tt.failures)),
+			)
+
+			_, err := client.GetContributions(context.Background(), "octocat")
+			partialErr, ok := err.(*ErrPartialResults)
+			if !ok {
+				t.Fatalf("Expected *ErrPartialResults, got %T (%v)", err, err)
+			}
+
+			if tt.wantPRsLeft && partialErr.UnprocessedPRs == 0 {
+				t.Error("UnprocessedPRs = 0, want PRs skipped after the timeout")
+			}
+			if tt.wantReposLeft && partialErr.UnprocessedRepos == 0 {
+				t.Error("UnprocessedRepos = 0, want repositories skipped after the timeout")
+			}
+			if !strings.Contains(partialErr.Error(), "unprocessed") {
+				t.Errorf("Error() = %q, want the unprocessed counts", partialErr.Error())
+			}
+
+			deadline := false
+			for _, e := range partialErr.Errors {
+				if errors.Is(e, context.DeadlineExceeded) {
+					deadline = true
+				}
+			}
+			if !deadline {
+				t.Errorf("Errors = %v, want a context.DeadlineExceeded", partialErr.Errors)
+			}
+
+			incomplete := 0
+			for _, contrib := range partialErr.Stats.Contributions {
+				if contrib.Incomplete {
+					incomplete++
+				}
+			}
+			if incomplete != partialErr.UnprocessedRepos {
+				t.Errorf("Incomplete contributions = %d, want %d", incomplete, partialErr.UnprocessedRepos)
+			}
+		})
+	}
+}
+
+func TestGetContributionsCancelReturnsPartialResults(t *testing.T) {
+	client := New(
+		WithLOC(true),
+		WithDebugScenario(writeScenario(t, 20, `[{"call": "GetPullRequest", "latency": "40ms"}]`)),
+	)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	time.AfterFunc(60*time.Millisecond, cancel)
+
+	_, err := client.GetContributions(ctx, "octocat")
+	partialErr, ok := err.(*ErrPartialResults)
+	if !ok {
+		t.Fatalf("Expected *ErrPartialResults, got %T (%v)", err, err)
+	}
+	if partialErr.UnprocessedPRs == 0 {
+		t.Error("UnprocessedPRs = 0, want PRs skipped after cancellation")
+	}
+	if partialErr.UnprocessedPRs+partialErr.Stats.Summary.TotalPRsMerged != 20 {
+		t.Errorf("UnprocessedPRs (%d) + merged PRs (%d) != 20", partialErr.UnprocessedPRs, partialErr.Stats.Summary.TotalPRsMerged)
+	}
+}
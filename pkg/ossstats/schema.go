@@ -0,0 +1,422 @@
+package ossstats
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"reflect"
+	"strings"
+	"time"
+)
+
+// StatsSchemaVersion is the current version of the stats JSON layout.
+// Bump it, and add a migration to statsMigrations, whenever a field is
+// renamed or removed, changes meaning or is added as a required field.
+// Optional (omitempty) fields are added without a bump, since older
+// releases ignore fields they do not know.
+const StatsSchemaVersion = 2
+
+// StatsSchemaID is the published location of the stats JSON schema.
+const StatsSchemaID = "https://raw.githubusercontent.com/mabd-dev/gh-oss-stats/main/docs/stats.schema.json"
+
+// jsonSchema is the subset of JSON Schema used to describe Stats.
+type jsonSchema struct {
+	Schema      string                 `json:"$schema,omitempty"`
+	ID          string                 `json:"$id,omitempty"`
+	Title       string                 `json:"title,omitempty"`
+	Description string                 `json:"description,omitempty"`
+	Type        string                 `json:"type"`
+	Format      string                 `json:"format,omitempty"`
+	Const       *int                   `json:"const,omitempty"`
+	Minimum     *int                   `json:"minimum,omitempty"`
+	Properties  map[string]*jsonSchema `json:"properties,omitempty"`
+	Required    []string               `json:"required,omitempty"`
+	Items       *jsonSchema            `json:"items,omitempty"`
+
+	order []string // Property names in struct field order, for validation messages
+}
+
+// statsSchema is the schema of the current stats JSON layout, generated
+// from the Stats type.
+var statsSchema = newStatsSchema()
+
+// statsMigrations[v] migrates a decoded stats document from schema version v
+// to v+1.
+var statsMigrations = []func(doc map[string]any) error{
+	0: migrateStatsV0,
+	1: migrateStatsV1,
+}
+
+// JSONSchema returns the JSON Schema document describing the current stats
+// JSON layout. It is generated from the Stats type.
+func JSONSchema() []byte {
+	data, err := json.MarshalIndent(statsSchema, "", "  ")
+	if err != nil {
+		panic(fmt.Sprintf("ossstats: encoding JSON schema: %v", err))
+	}
+	return append(data, '\n')
+}
+
+// ParseStats decodes stats JSON, as written by the CLI or by encoding a
+// Stats value. Documents written with an older schema version, including
+// those without a schemaVersion, are migrated to the current version.
+//
+// Returns *ErrInvalidStats for malformed JSON or input that does not match
+// the schema, and *ErrUnsupportedSchemaVersion for documents written by a
+// newer version of this package.
+func ParseStats(data []byte) (*Stats, error) {
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.UseNumber()
+
+	var raw any
+	if err := decoder.Decode(&raw); err != nil {
+		return nil, &ErrInvalidStats{Reason: fmt.Sprintf("malformed JSON: %v", err)}
+	}
+	if decoder.More() {
+		return nil, &ErrInvalidStats{Reason: "malformed JSON: unexpected data after the stats object"}
+	}
+
+	doc, ok := raw.(map[string]any)
+	if !ok {
+		return nil, &ErrInvalidStats{Reason: fmt.Sprintf("expected a JSON object, got %s", jsonTypeName(raw))}
+	}
+
+	version, err := schemaVersionOf(doc)
+	if err != nil {
+		return nil, err
+	}
+	for ; version < StatsSchemaVersion; version++ {
+		if err := statsMigrations[version](doc); err != nil {
+			return nil, err
+		}
+		doc["schemaVersion"] = json.Number(fmt.Sprint(version + 1))
+	}
+
+	if err := statsSchema.validate("", doc); err != nil {
+		return nil, err
+	}
+
+	migrated, err := json.Marshal(doc)
+	if err != nil {
+		return nil, fmt.Errorf("encoding migrated stats: %w", err)
+	}
+	var stats Stats
+	if err := json.Unmarshal(migrated, &stats); err != nil {
+		return nil, &ErrInvalidStats{Reason: err.Error()}
+	}
+	return &stats, nil
+}
+
+// schemaVersionOf returns the schema version of a decoded stats document.
+// Documents without a schemaVersion predate versioning and are version 0.
+func schemaVersionOf(doc map[string]any) (int, error) {
+	value, ok := doc["schemaVersion"]
+	if !ok {
+		return 0, nil
+	}
+
+	number, ok := value.(json.Number)
+	if !ok {
+		return 0, &ErrInvalidStats{Field: "schemaVersion", Reason: fmt.Sprintf("expected integer, got %s", jsonTypeName(value))}
+	}
+	version, err := number.Int64()
+	if err != nil || version < 0 {
+		return 0, &ErrInvalidStats{Field: "schemaVersion", Reason: fmt.Sprintf("expected a non-negative integer, got %s", number)}
+	}
+	if version > StatsSchemaVersion {
+		return 0, &ErrUnsupportedSchemaVersion{Version: int(version)}
+	}
+	return int(version), nil
+}
+
+// migrateStatsV0 migrates stats written before schemaVersion was added.
+// Those files were written by hand or by older releases that always set
+// every field, so missing fields get their zero value, a missing summary is
+// recalculated and owner/repoName are derived from repo.
+func migrateStatsV0(doc map[string]any) error {
+	if contributions, ok := doc["contributions"]; !ok || contributions == nil {
+		doc["contributions"] = []any{}
+	}
+
+	if contributions, ok := doc["contributions"].([]any); ok {
+		for _, item := range contributions {
+			contrib, ok := item.(map[string]any)
+			if !ok {
+				continue
+			}
+			repo, _ := contrib["repo"].(string)
+			owner, name, found := strings.Cut(repo, "/")
+			if !found {
+				continue
+			}
+			if value, _ := contrib["owner"].(string); value == "" {
+				contrib["owner"] = owner
+			}
+			if value, _ := contrib["repoName"].(string); value == "" {
+				contrib["repoName"] = name
+			}
+		}
+
+		if _, ok := doc["summary"]; !ok {
+			doc["summary"] = summarize(contributions)
+		}
+	}
+
+	statsSchema.fillZeroValues(doc)
+	return nil
+}
+
+// migrateStatsV1 migrates stats written before contributions were tagged
+// with their forge. Those were all GitHub contributions; the host is taken
+// from repoURL, so GitHub Enterprise Server stats keep their host.
+func migrateStatsV1(doc map[string]any) error {
+	contributions, _ := doc["contributions"].([]any)
+	for _, item := range contributions {
+		contrib, ok := item.(map[string]any)
+		if !ok {
+			continue
+		}
+		if value, _ := contrib["forge"].(string); value == "" {
+			contrib["forge"] = ForgeGitHub
+		}
+		if value, _ := contrib["host"].(string); value == "" {
+			host := "github.com"
+			repoURL, _ := contrib["repoURL"].(string)
+			if u, err := url.Parse(repoURL); err == nil && u.Host != "" {
+				host = u.Host
+			}
+			contrib["host"] = host
+		}
+	}
+	return nil
+}
+
+// summarize calculates the summary of decoded contributions, ignoring
+// values that are not numbers. Validation reports those afterwards.
+func summarize(contributions []any) map[string]any {
+	totals := map[string]int64{"prsMerged": 0, "commits": 0, "additions": 0, "deletions": 0}
+	for _, item := range contributions {
+		contrib, _ := item.(map[string]any)
+		for key := range totals {
+			if number, ok := contrib[key].(json.Number); ok {
+				n, _ := number.Int64()
+				totals[key] += n
+			}
+		}
+	}
+
+	return map[string]any{
+		"totalProjects":  json.Number(fmt.Sprint(len(contributions))),
+		"totalPRsMerged": json.Number(fmt.Sprint(totals["prsMerged"])),
+		"totalCommits":   json.Number(fmt.Sprint(totals["commits"])),
+		"totalAdditions": json.Number(fmt.Sprint(totals["additions"])),
+		"totalDeletions": json.Number(fmt.Sprint(totals["deletions"])),
+	}
+}
+
+// newStatsSchema generates the schema of the Stats type.
+func newStatsSchema() *jsonSchema {
+	schema := schemaFor(reflect.TypeOf(Stats{}))
+	schema.Schema = "https://json-schema.org/draft/2020-12/schema"
+	schema.ID = StatsSchemaID
+	schema.Title = "gh-oss-stats stats"
+	schema.Description = "Open source contribution statistics of a GitHub user, as written by gh-oss-stats."
+
+	version := StatsSchemaVersion
+	schema.Properties["schemaVersion"].Const = &version
+	schema.Properties["schemaVersion"].Minimum = nil
+	return schema
+}
+
+// schemaFor generates the schema of a Go type from its JSON encoding.
+// It panics on types that Stats does not use, so a new field of an
+// unsupported type fails the tests rather than producing a wrong schema.
+func schemaFor(t reflect.Type) *jsonSchema {
+	if t == reflect.TypeOf(time.Time{}) {
+		return &jsonSchema{Type: "string", Format: "date-time"}
+	}
+
+	switch t.Kind() {
+	case reflect.String:
+		return &jsonSchema{Type: "string"}
+	case reflect.Bool:
+		return &jsonSchema{Type: "boolean"}
+	case reflect.Int, reflect.Int32, reflect.Int64:
+		minimum := 0
+		return &jsonSchema{Type: "integer", Minimum: &minimum}
+	case reflect.Slice:
+		return &jsonSchema{Type: "array", Items: schemaFor(t.Elem())}
+	case reflect.Pointer:
+		return schemaFor(t.Elem())
+	case reflect.Struct:
+		schema := &jsonSchema{Type: "object", Properties: make(map[string]*jsonSchema)}
+		for i := 0; i < t.NumField(); i++ {
+			field := t.Field(i)
+			if !field.IsExported() {
+				continue
+			}
+			name, options, _ := strings.Cut(field.Tag.Get("json"), ",")
+			if name == "-" {
+				continue
+			}
+			if name == "" {
+				name = field.Name
+			}
+
+			schema.Properties[name] = schemaFor(field.Type)
+			schema.order = append(schema.order, name)
+			if !strings.Contains(options, "omitempty") {
+				schema.Required = append(schema.Required, name)
+			}
+		}
+		return schema
+	}
+	panic(fmt.Sprintf("ossstats: no JSON schema for type %s", t))
+}
+
+// validate checks a decoded JSON value against the schema. path is the
+// location of value in the document, used in error messages.
+func (s *jsonSchema) validate(path string, value any) error {
+	invalid := func(format string, args ...any) error {
+		return &ErrInvalidStats{Field: path, Reason: fmt.Sprintf(format, args...)}
+	}
+
+	switch s.Type {
+	case "object":
+		object, ok := value.(map[string]any)
+		if !ok {
+			return invalid("expected object, got %s", jsonTypeName(value))
+		}
+		for _, name := range s.Required {
+			if _, ok := object[name]; !ok {
+				return &ErrInvalidStats{Field: joinPath(path, name), Reason: "missing required field"}
+			}
+		}
+		for _, name := range s.order {
+			if property, ok := object[name]; ok {
+				if err := s.Properties[name].validate(joinPath(path, name), property); err != nil {
+					return err
+				}
+			}
+		}
+
+	case "array":
+		array, ok := value.([]any)
+		if !ok {
+			return invalid("expected array, got %s", jsonTypeName(value))
+		}
+		for i, item := range array {
+			if err := s.Items.validate(fmt.Sprintf("%s[%d]", path, i), item); err != nil {
+				return err
+			}
+		}
+
+	case "integer":
+		number, ok := value.(json.Number)
+		if !ok {
+			return invalid("expected integer, got %s", jsonTypeName(value))
+		}
+		n, err := number.Int64()
+		if err != nil {
+			return invalid("expected integer, got %s", number)
+		}
+		if s.Const != nil && n != int64(*s.Const) {
+			return invalid("expected %d, got %d", *s.Const, n)
+		}
+		if s.Minimum != nil && n < int64(*s.Minimum) {
+			return invalid("must be >= %d, got %d", *s.Minimum, n)
+		}
+
+	case "string":
+		str, ok := value.(string)
+		if !ok {
+			return invalid("expected string, got %s", jsonTypeName(value))
+		}
+		if s.Format == "date-time" {
+			if _, err := time.Parse(time.RFC3339Nano, str); err != nil {
+				return invalid("expected an RFC 3339 date-time, got %q", str)
+			}
+		}
+
+	case "boolean":
+		if _, ok := value.(bool); !ok {
+			return invalid("expected boolean, got %s", jsonTypeName(value))
+		}
+	}
+
+	return nil
+}
+
+// fillZeroValues sets missing required fields of a decoded JSON value to
+// their zero value, the way encoding a zero Go value would.
+func (s *jsonSchema) fillZeroValues(value any) {
+	switch s.Type {
+	case "object":
+		object, ok := value.(map[string]any)
+		if !ok {
+			return
+		}
+		for _, name := range s.Required {
+			if _, ok := object[name]; !ok {
+				object[name] = s.Properties[name].zeroValue()
+			}
+			s.Properties[name].fillZeroValues(object[name])
+		}
+	case "array":
+		if array, ok := value.([]any); ok {
+			for _, item := range array {
+				s.Items.fillZeroValues(item)
+			}
+		}
+	}
+}
+
+// zeroValue returns the JSON encoding of the Go zero value for the schema.
+func (s *jsonSchema) zeroValue() any {
+	switch s.Type {
+	case "object":
+		object := make(map[string]any)
+		s.fillZeroValues(object)
+		return object
+	case "array":
+		return []any{}
+	case "integer":
+		return json.Number("0")
+	case "boolean":
+		return false
+	case "string":
+		if s.Format == "date-time" {
+			return time.Time{}.Format(time.RFC3339)
+		}
+		return ""
+	}
+	return nil
+}
+
+// joinPath appends a field name to a document path.
+func joinPath(path, name string) string {
+	if path == "" {
+		return name
+	}
+	return path + "." + name
+}
+
+// jsonTypeName names the JSON type of a decoded value for error messages.
+func jsonTypeName(value any) string {
+	switch value.(type) {
+	case nil:
+		return "null"
+	case map[string]any:
+		return "object"
+	case []any:
+		return "array"
+	case string:
+		return "string"
+	case bool:
+		return "boolean"
+	case json.Number:
+		return "number"
+	}
+	return fmt.Sprintf("%T", value)
+}
@@ -0,0 +1,183 @@
+package ossstats
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestJSONSchemaUpToDate(t *testing.T) {
+	published, err := os.ReadFile(filepath.Join("..", "..", "docs", "stats.schema.json"))
+	if err != nil {
+		t.Fatalf("Reading published schema: %v", err)
+	}
+	if !bytes.Equal(published, JSONSchema()) {
+		t.Error("docs/stats.schema.json is out of date, regenerate it with: go run ./cmd/gh-oss-stats schema --output docs/stats.schema.json")
+	}
+}
+
+func TestJSONSchema(t *testing.T) {
+	var schema map[string]any
+	if err := json.Unmarshal(JSONSchema(), &schema); err != nil {
+		t.Fatalf("JSONSchema() is not valid JSON: %v", err)
+	}
+
+	properties := schema["properties"].(map[string]any)
+	version := properties["schemaVersion"].(map[string]any)
+	if version["const"] != float64(StatsSchemaVersion) {
+		t.Errorf("schemaVersion const = %v, want %d", version["const"], StatsSchemaVersion)
+	}
+
+	contribution := properties["contributions"].(map[string]any)["items"].(map[string]any)
+	for _, name := range contribution["required"].([]any) {
+		if name == "incomplete" {
+			t.Error("incomplete is omitempty and must not be required")
+		}
+	}
+}
+
+func TestParseStatsRoundTrip(t *testing.T) {
+	stats := &Stats{
+		SchemaVersion: StatsSchemaVersion,
+		Username:      "octocat",
+		GeneratedAt:   time.Date(2025, 6, 1, 12, 30, 0, 0, time.UTC),
+		Summary:       Summary{TotalProjects: 1, TotalPRsMerged: 2},
+		Contributions: []Contribution{
+			{Repo: "golang/go", Owner: "golang", RepoName: "go", PRsMerged: 2, Stars: 100, Incomplete: true, Dependency: true,
+				PullRequests: []PullRequest{
+					{Number: 2, Title: "Fix race", URL: "https://github.com/golang/go/pull/2", MergedAt: time.Date(2025, 5, 2, 0, 0, 0, 0, time.UTC), Commits: 1},
+					{Number: 1, Title: "Add docs", URL: "https://github.com/golang/go/pull/1", MergedAt: time.Date(2025, 5, 1, 0, 0, 0, 0, time.UTC), Commits: 1},
+				}},
+		},
+		Dependencies: &DependencySummary{Total: 3, Resolved: 2, Contributed: 1},
+	}
+
+	data, err := json.Marshal(stats)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	parsed, err := ParseStats(data)
+	if err != nil {
+		t.Fatalf("ParseStats() error: %v", err)
+	}
+	if parsed.Username != "octocat" || !parsed.GeneratedAt.Equal(stats.GeneratedAt) ||
+		!reflect.DeepEqual(parsed.Contributions, stats.Contributions) {
+		t.Errorf("ParseStats() = %+v, want %+v", parsed, stats)
+	}
+	if parsed.Dependencies == nil || *parsed.Dependencies != *stats.Dependencies {
+		t.Errorf("ParseStats() Dependencies = %+v, want %+v", parsed.Dependencies, stats.Dependencies)
+	}
+}
+
+func TestParseStatsMigratesUnversioned(t *testing.T) {
+	// Written before schemaVersion existed, with fields left out by hand
+	data := []byte(`{
+		"username": "octocat",
+		"generatedAt": "2025-01-02T03:04:05Z",
+		"contributions": [
+			{"repo": "golang/go", "prsMerged": 3, "commits": 4, "additions": 10, "deletions": 2, "stars": 100},
+			{"repo": "acme/app", "owner": "acme", "repoName": "app", "prsMerged": 1}
+		]
+	}`)
+
+	stats, err := ParseStats(data)
+	if err != nil {
+		t.Fatalf("ParseStats() error: %v", err)
+	}
+
+	if stats.SchemaVersion != StatsSchemaVersion {
+		t.Errorf("SchemaVersion = %d, want %d", stats.SchemaVersion, StatsSchemaVersion)
+	}
+	if got := stats.Contributions[0]; got.Owner != "golang" || got.RepoName != "go" {
+		t.Errorf("Owner/RepoName = %q/%q, want golang/go", got.Owner, got.RepoName)
+	}
+	want := Summary{TotalProjects: 2, TotalPRsMerged: 4, TotalCommits: 4, TotalAdditions: 10, TotalDeletions: 2}
+	if stats.Summary != want {
+		t.Errorf("Summary = %+v, want %+v", stats.Summary, want)
+	}
+}
+
+func TestParseStatsErrors(t *testing.T) {
+	valid := `"username": "octocat", "generatedAt": "2025-01-02T03:04:05Z", "summary": {}`
+
+	tests := []struct {
+		name      string
+		data      string
+		wantField string
+	}{
+		{"malformed", `{"username":`, ""},
+		{"not an object", `[1, 2]`, ""},
+		{"trailing data", `{"username": "octocat"} {}`, ""},
+		{"bad version", `{"schemaVersion": "1"}`, "schemaVersion"},
+		{"negative version", `{"schemaVersion": -1}`, "schemaVersion"},
+		{"missing username", `{"schemaVersion": 1, "generatedAt": "2025-01-02T03:04:05Z"}`, "username"},
+		{"bad date", `{"username": "octocat", "generatedAt": "yesterday"}`, "generatedAt"},
+		{"string stars", `{` + valid + `, "contributions": [{"repo": "a/b", "stars": "many"}]}`, "contributions[0].stars"},
+		{"negative PRs", `{` + valid + `, "contributions": [{"repo": "a/b", "prsMerged": -1}]}`, "contributions[0].prsMerged"},
+		{"fractional commits", `{` + valid + `, "contributions": [{"repo": "a/b", "commits": 1.5}]}`, "contributions[0].commits"},
+		{"contribution not an object", `{` + valid + `, "contributions": ["a/b"]}`, "contributions[0]"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := ParseStats([]byte(tt.data))
+
+			var invalidErr *ErrInvalidStats
+			if !errors.As(err, &invalidErr) {
+				t.Fatalf("ParseStats() error = %v, want *ErrInvalidStats", err)
+			}
+			if invalidErr.Field != tt.wantField {
+				t.Errorf("Field = %q, want %q (%v)", invalidErr.Field, tt.wantField, err)
+			}
+		})
+	}
+}
+
+func TestParseStatsUnsupportedVersion(t *testing.T) {
+	_, err := ParseStats([]byte(`{"schemaVersion": 99, "username": "octocat"}`))
+
+	var versionErr *ErrUnsupportedSchemaVersion
+	if !errors.As(err, &versionErr) {
+		t.Fatalf("ParseStats() error = %v, want *ErrUnsupportedSchemaVersion", err)
+	}
+	if versionErr.Version != 99 {
+		t.Errorf("Version = %d, want 99", versionErr.Version)
+	}
+}
+
+func TestParseStatsMigratesV1(t *testing.T) {
+	contribution := func(repoURL string) string {
+		return `{"repo": "alpha/one", "owner": "alpha", "repoName": "one", "description": "", "repoURL": "` + repoURL + `", "stars": 1, "prsMerged": 1, "commits": 1, "additions": 0, "deletions": 0, "firstContribution": "2025-01-01T00:00:00Z", "lastContribution": "2025-01-01T00:00:00Z"}`
+	}
+	stats, err := ParseStats([]byte(`{"schemaVersion": 1, "username": "octocat", "generatedAt": "2025-01-02T03:04:05Z", "summary": {"totalProjects": 2, "totalPRsMerged": 2, "totalCommits": 2, "totalAdditions": 0, "totalDeletions": 0}, "contributions": [` +
+		contribution("https://github.com/alpha/one") + `, ` + contribution("https://ghe.example.com/alpha/one") + `]}`))
+	if err != nil {
+		t.Fatalf("ParseStats() error: %v", err)
+	}
+
+	wantHosts := []string{"github.com", "ghe.example.com"}
+	for i, contrib := range stats.Contributions {
+		if contrib.Forge != ForgeGitHub || contrib.Host != wantHosts[i] {
+			t.Errorf("contributions[%d] forge/host = %s/%s, want %s/%s", i, contrib.Forge, contrib.Host, ForgeGitHub, wantHosts[i])
+		}
+	}
+}
+
+func TestParseStatsIgnoresUnknownFields(t *testing.T) {
+	// Optional fields are added without a schema bump, so a file with a
+	// field from a later release parses with that field dropped
+	stats, err := ParseStats([]byte(`{"schemaVersion": ` + fmt.Sprint(StatsSchemaVersion) + `, "username": "octocat", "generatedAt": "2025-01-02T03:04:05Z", "summary": {"totalProjects": 0, "totalPRsMerged": 0, "totalCommits": 0, "totalAdditions": 0, "totalDeletions": 0}, "contributions": [], "someFutureField": {"enabled": true}}`))
+	if err != nil {
+		t.Fatalf("ParseStats() error: %v", err)
+	}
+	if stats.Username != "octocat" {
+		t.Errorf("Username = %q, want octocat", stats.Username)
+	}
+}
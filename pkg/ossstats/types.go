@@ -7,11 +7,23 @@ import (
 
 // Stats represents the complete statistics for a GitHub user's
 // open source contributions to external repositories.
+//
+// SchemaVersion identifies the JSON layout; use ParseStats to read stats
+// JSON so that older layouts are migrated to the current one.
 type Stats struct {
+	SchemaVersion int            `json:"schemaVersion"`
 	Username      string         `json:"username"`
 	GeneratedAt   time.Time      `json:"generatedAt"`
 	Summary       Summary        `json:"summary"`
 	Contributions []Contribution `json:"contributions"`
+
+	// Accounts lists the GitHub accounts merged into these stats with
+	// MergeStats. It is empty for the stats of a single account.
+	Accounts []string `json:"accounts,omitempty"`
+
+	// Dependencies reports how many direct dependencies of the user's
+	// projects received contributions. It is only set by MarkDependencies.
+	Dependencies *DependencySummary `json:"dependencies,omitempty"`
 }
 
 // Summary contains aggregate statistics across all contributions.
@@ -26,10 +38,12 @@ type Summary struct {
 // Contribution represents a user's contribution to a single external repository.
 type Contribution struct {
 	Repo              string    `json:"repo"`              // Full repo name (owner/repo)
+	Forge             string    `json:"forge"`             // Forge type: github, gitlab, gitea
+	Host              string    `json:"host"`              // Forge instance host, e.g. github.com
 	Owner             string    `json:"owner"`             // Repository owner
 	RepoName          string    `json:"repoName"`          // Repository name
 	Description       string    `json:"description"`       // Repository description
-	RepoURL           string    `json:"repoURL"`           // Full GitHub URL
+	RepoURL           string    `json:"repoURL"`           // Full repository URL
 	Stars             int       `json:"stars"`             // Repository star count
 	PRsMerged         int       `json:"prsMerged"`         // Number of merged PRs
 	Commits           int       `json:"commits"`           // Total commits across PRs
@@ -37,6 +51,68 @@ type Contribution struct {
 	Deletions         int       `json:"deletions"`         // Lines deleted
 	FirstContribution time.Time `json:"firstContribution"` // First PR merged date
 	LastContribution  time.Time `json:"lastContribution"`  // Most recent PR merged date
+
+	// Language is the repository's primary language, if the forge reports it
+	Language string `json:"language,omitempty"`
+
+	// Dependency is set by Stats.MarkDependencies when the repository is a
+	// direct dependency of the user's projects
+	Dependency bool `json:"dependency,omitempty"`
+
+	// Incomplete is set when repository metadata (stars, description, URL)
+	// could not be fetched, so those fields are unknown rather than empty
+	Incomplete bool `json:"incomplete,omitempty"`
+
+	// PullRequests lists the merged PRs, most recent first. It is only set
+	// with WithPRDetails
+	PullRequests []PullRequest `json:"pullRequests,omitempty"`
+}
+
+// PullRequest is a merged pull request of a contribution. Commits and lines
+// are only counted with WithLOC.
+type PullRequest struct {
+	Number    int       `json:"number"`
+	Title     string    `json:"title"`
+	URL       string    `json:"url"`
+	MergedAt  time.Time `json:"mergedAt"`
+	Commits   int       `json:"commits"`
+	Additions int       `json:"additions"`
+	Deletions int       `json:"deletions"`
+}
+
+// UnknownStarsPolicy decides how WithMinStars treats contributions whose
+// star count is unknown because the repository lookup failed.
+type UnknownStarsPolicy int
+
+const (
+	// UnknownStarsExclude drops contributions with unknown star counts
+	// when a minimum is set, as if the repository had no stars.
+	UnknownStarsExclude UnknownStarsPolicy = iota
+
+	// UnknownStarsInclude keeps contributions with unknown star counts,
+	// so a failed lookup never hides a contribution.
+	UnknownStarsInclude
+)
+
+// String returns the policy name, as accepted by ParseUnknownStarsPolicy.
+func (p UnknownStarsPolicy) String() string {
+	switch p {
+	case UnknownStarsInclude:
+		return "include"
+	default:
+		return "exclude"
+	}
+}
+
+// ParseUnknownStarsPolicy parses "exclude" or "include".
+func ParseUnknownStarsPolicy(s string) (UnknownStarsPolicy, error) {
+	switch s {
+	case "exclude":
+		return UnknownStarsExclude, nil
+	case "include":
+		return UnknownStarsInclude, nil
+	}
+	return UnknownStarsExclude, fmt.Errorf("invalid unknown stars policy %q (valid: exclude, include)", s)
 }
 
 // ErrRateLimited indicates that GitHub's rate limit has been exceeded.
@@ -52,6 +128,61 @@ func (e *ErrRateLimited) Error() string {
 	return fmt.Sprintf("rate limited (resets at %s)", e.ResetAt.Format(time.RFC3339))
 }
 
+// ErrSecondaryRateLimited indicates that GitHub's secondary rate limit was
+// triggered by too many requests in a short period, even though the hourly
+// quota may not be exhausted.
+type ErrSecondaryRateLimited struct {
+	RetryAfter time.Duration
+	Message    string
+}
+
+func (e *ErrSecondaryRateLimited) Error() string {
+	msg := "secondary rate limit exceeded"
+	if e.Message != "" {
+		msg = fmt.Sprintf("%s: %s", msg, e.Message)
+	}
+	if e.RetryAfter > 0 {
+		return fmt.Sprintf("%s (retry after %s)", msg, e.RetryAfter)
+	}
+	return msg
+}
+
+// ErrSSORequired indicates that the token must be authorized for an
+// organization that enforces SAML single sign-on.
+type ErrSSORequired struct {
+	Resource string // Repository or API resource that was denied
+	URL      string // Authorization URL from the X-GitHub-SSO header, if any
+	Message  string
+}
+
+func (e *ErrSSORequired) Error() string {
+	msg := "SAML SSO authorization required"
+	if e.Resource != "" {
+		msg = fmt.Sprintf("%s for %s", msg, e.Resource)
+	}
+	if e.Message != "" {
+		msg = fmt.Sprintf("%s: %s", msg, e.Message)
+	}
+	return msg
+}
+
+// ErrForbidden indicates that the token is not allowed to access a resource.
+type ErrForbidden struct {
+	Resource string
+	Message  string
+}
+
+func (e *ErrForbidden) Error() string {
+	msg := "access forbidden"
+	if e.Resource != "" {
+		msg = fmt.Sprintf("%s to %s", msg, e.Resource)
+	}
+	if e.Message != "" {
+		msg = fmt.Sprintf("%s: %s", msg, e.Message)
+	}
+	return msg
+}
+
 // ErrAuthentication indicates an authentication failure with the GitHub API.
 type ErrAuthentication struct {
 	Message string
@@ -73,17 +204,69 @@ func (e *ErrNotFound) Error() string {
 	return fmt.Sprintf("user not found: %s", e.Username)
 }
 
+// ErrOrgNotFound indicates that the specified GitHub organization was not found.
+type ErrOrgNotFound struct {
+	Org string
+}
+
+func (e *ErrOrgNotFound) Error() string {
+	return fmt.Sprintf("organization not found: %s", e.Org)
+}
+
+// ErrRepoNotFound indicates that the specified GitHub repository was not found.
+type ErrRepoNotFound struct {
+	Repo string
+}
+
+func (e *ErrRepoNotFound) Error() string {
+	return fmt.Sprintf("repository not found: %s", e.Repo)
+}
+
+// ErrInvalidStats indicates that stats JSON is malformed or does not match
+// the stats JSON schema.
+type ErrInvalidStats struct {
+	Field  string // Path of the offending field, e.g. "contributions[2].stars"; empty for the whole document
+	Reason string
+}
+
+func (e *ErrInvalidStats) Error() string {
+	if e.Field != "" {
+		return fmt.Sprintf("invalid stats: %s: %s", e.Field, e.Reason)
+	}
+	return fmt.Sprintf("invalid stats: %s", e.Reason)
+}
+
+// ErrUnsupportedSchemaVersion indicates that stats JSON was written with a
+// newer schema version than this version of the package can read.
+type ErrUnsupportedSchemaVersion struct {
+	Version int
+}
+
+func (e *ErrUnsupportedSchemaVersion) Error() string {
+	return fmt.Sprintf("unsupported stats schema version %d (supported: up to %d), upgrade gh-oss-stats to read it", e.Version, StatsSchemaVersion)
+}
+
 // ErrPartialResults indicates that the operation completed with partial results
-// due to errors encountered during processing (e.g., rate limiting).
+// due to errors encountered during processing (e.g., rate limiting), or
+// because the timeout or context cancellation cut the run short.
 type ErrPartialResults struct {
 	Stats   *Stats
 	Errors  []error
 	Message string
+
+	// UnprocessedPRs and UnprocessedRepos count the PRs and repositories
+	// skipped because the context was done before they were fetched
+	UnprocessedPRs   int
+	UnprocessedRepos int
 }
 
 func (e *ErrPartialResults) Error() string {
+	msg := "partial results"
 	if e.Message != "" {
-		return fmt.Sprintf("partial results: %s (%d errors encountered)", e.Message, len(e.Errors))
+		msg = fmt.Sprintf("%s: %s", msg, e.Message)
+	}
+	if e.UnprocessedPRs > 0 || e.UnprocessedRepos > 0 {
+		msg = fmt.Sprintf("%s, %d PRs and %d repositories unprocessed", msg, e.UnprocessedPRs, e.UnprocessedRepos)
 	}
-	return fmt.Sprintf("partial results (%d errors encountered)", len(e.Errors))
+	return fmt.Sprintf("%s (%d errors encountered)", msg, len(e.Errors))
 }
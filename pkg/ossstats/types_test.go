@@ -1,6 +1,7 @@
 package ossstats
 
 import (
+	"context"
 	"encoding/json"
 	"errors"
 	"testing"
@@ -162,6 +163,36 @@ func TestErrNotFoundError(t *testing.T) {
 	}
 }
 
+func TestErrSecondaryRateLimitedError(t *testing.T) {
+	err := &ErrSecondaryRateLimited{RetryAfter: 60 * time.Second, Message: "slow down"}
+	got := err.Error()
+
+	if !contains(got, "secondary rate limit") {
+		t.Errorf("Error() = %q, want to contain 'secondary rate limit'", got)
+	}
+	if !contains(got, "slow down") || !contains(got, "1m0s") {
+		t.Errorf("Error() = %q, want message and retry delay", got)
+	}
+}
+
+func TestErrSSORequiredError(t *testing.T) {
+	err := &ErrSSORequired{Resource: "acme/repo", URL: "https://github.com/orgs/acme/sso"}
+	got := err.Error()
+
+	if !contains(got, "SSO") || !contains(got, "acme/repo") {
+		t.Errorf("Error() = %q, want to mention SSO and the resource", got)
+	}
+}
+
+func TestErrForbiddenError(t *testing.T) {
+	err := &ErrForbidden{Resource: "acme/private", Message: "Resource not accessible"}
+	got := err.Error()
+
+	if !contains(got, "forbidden") || !contains(got, "acme/private") || !contains(got, "Resource not accessible") {
+		t.Errorf("Error() = %q, want to mention forbidden, resource and message", got)
+	}
+}
+
 func TestErrPartialResultsError(t *testing.T) {
 	stats := &Stats{
 		Username:    "testuser",
@@ -196,6 +227,17 @@ func TestErrPartialResultsError(t *testing.T) {
 			},
 			wantErrCnt: 1,
 		},
+		{
+			name: "with unprocessed work",
+			err: &ErrPartialResults{
+				Stats:            stats,
+				Errors:           []error{context.DeadlineExceeded},
+				UnprocessedPRs:   12,
+				UnprocessedRepos: 3,
+			},
+			wantMsg:    "12 PRs and 3 repositories unprocessed",
+			wantErrCnt: 1,
+		},
 	}
 
 	for _, tt := range tests {
@@ -327,6 +369,9 @@ func TestErrorTypeImplementsError(t *testing.T) {
 	var _ error = &ErrAuthentication{}
 	var _ error = &ErrNotFound{}
 	var _ error = &ErrPartialResults{}
+	var _ error = &ErrSecondaryRateLimited{}
+	var _ error = &ErrSSORequired{}
+	var _ error = &ErrForbidden{}
 }
 
 func TestStatsEmptyContributions(t *testing.T) {